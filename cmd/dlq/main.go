@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/williampsena/bugs-channel/pkg/config"
+	"github.com/williampsena/bugs-channel/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/logger"
+	"github.com/williampsena/bugs-channel/pkg/storage"
+)
+
+func init() {
+	logger.Setup()
+}
+
+// Lists or re-drives dead-lettered events.
+//
+//	go run cmd/dlq/main.go list
+//	go run cmd/dlq/main.go redrive
+func main() {
+	topic := flag.String("topic", config.DispatchDeadLetterTopic(), "the dead letter topic")
+	timeout := flag.Duration("timeout", 10*time.Second, "how long to consume the dead letter topic")
+	flag.Parse()
+
+	queue, err := storage.BuildQueue(config.EventChannel())
+
+	if err != nil {
+		log.Fatal("❌ Something went wrong when trying to construct Queue's connection.", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	switch flag.Arg(0) {
+	case "list":
+		encoder := json.NewEncoder(os.Stdout)
+
+		err = event.ListDeadLetters(ctx, queue, *topic, func(d event.DeadLetter) {
+			encoder.Encode(d)
+		})
+	case "redrive":
+		var redriven int
+
+		redriven, err = event.RedriveDeadLetters(ctx, queue, *topic)
+
+		log.Infof("♻️ %v dead letters were redriven from %v", redriven, *topic)
+	default:
+		log.Fatal("❌ Usage: dlq [-topic dlq.events] [-timeout 10s] list|redrive")
+	}
+
+	if err != nil {
+		log.Fatal("❌ Something went wrong when consuming the dead letter topic.", err)
+	}
+}
@@ -5,13 +5,24 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"github.com/williampsena/bugs-channel-plugins/pkg/sentry"
+	"github.com/williampsena/bugs-channel/pkg/airbrake"
+	"github.com/williampsena/bugs-channel/pkg/bugsnag"
 	"github.com/williampsena/bugs-channel/pkg/config"
 	"github.com/williampsena/bugs-channel/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/honeybadger"
 	"github.com/williampsena/bugs-channel/pkg/logger"
+	"github.com/williampsena/bugs-channel/pkg/otlp"
+	"github.com/williampsena/bugs-channel/pkg/ratelimit"
+	"github.com/williampsena/bugs-channel/pkg/rollbar"
+	"github.com/williampsena/bugs-channel/pkg/routing"
+	"github.com/williampsena/bugs-channel/pkg/rpc"
 	"github.com/williampsena/bugs-channel/pkg/service"
 	"github.com/williampsena/bugs-channel/pkg/settings"
+	"github.com/williampsena/bugs-channel/pkg/spool"
 	"github.com/williampsena/bugs-channel/pkg/storage"
+	"github.com/williampsena/bugs-channel/pkg/store"
 	"github.com/williampsena/bugs-channel/pkg/web"
+	"github.com/williampsena/bugs-channel/pkg/worker"
 )
 
 func init() {
@@ -25,39 +36,117 @@ func main() {
 		log.Fatal("❌ The configuration file is in incorrect format or does not exist.", err)
 	}
 
-	nats := buildQueue()
+	if err := routing.ValidateTopics(configFile, config.EventChannel()); err != nil {
+		log.Fatal("❌ The routing topics are not supported by the event channel.", err)
+	}
+
+	nats := maybeUseSpool(buildQueue())
+	maybeRunWorker(nats, configFile)
+	serviceFetcher := service.NewYAMLServiceFetcher(configFile.Services)
+	serviceLimiter := ratelimit.NewServiceLimiter(configFile.Services)
+	dispatcher := event.NewDispatcherWithSettings(nats, buildDispatcherSettings(configFile))
 
 	sentryServerContext := sentry.ServerContext{
 		Context:          context.Background(),
-		ServiceFetcher:   service.NewYAMLServiceFetcher(configFile.Services),
-		EventsDispatcher: event.NewDispatcher(nats),
+		ServiceFetcher:   serviceFetcher,
+		EventsDispatcher: event.NewRateLimitedDispatcher(dispatcher, serviceLimiter),
 	}
 
 	sentrySvr := sentry.BuildServer(&sentryServerContext)
 	go sentry.SetupServer(sentrySvr)
 
+	honeybadgerServerContext := honeybadger.ServerContext{
+		Context:          context.Background(),
+		ServiceFetcher:   serviceFetcher,
+		EventsDispatcher: event.NewRateLimitedDispatcher(dispatcher, serviceLimiter),
+	}
+
+	go honeybadger.SetupServer(honeybadger.BuildServer(&honeybadgerServerContext))
+
+	rollbarServerContext := rollbar.ServerContext{
+		Context:          context.Background(),
+		ServiceFetcher:   serviceFetcher,
+		EventsDispatcher: event.NewRateLimitedDispatcher(dispatcher, serviceLimiter),
+	}
+
+	go rollbar.SetupServer(rollbar.BuildServer(&rollbarServerContext))
+
+	bugsnagServerContext := bugsnag.ServerContext{
+		Context:          context.Background(),
+		ServiceFetcher:   serviceFetcher,
+		EventsDispatcher: event.NewRateLimitedDispatcher(dispatcher, serviceLimiter),
+	}
+
+	go bugsnag.SetupServer(bugsnag.BuildServer(&bugsnagServerContext))
+
+	airbrakeServerContext := airbrake.ServerContext{
+		Context:          context.Background(),
+		ServiceFetcher:   serviceFetcher,
+		EventsDispatcher: event.NewRateLimitedDispatcher(dispatcher, serviceLimiter),
+	}
+
+	go airbrake.SetupServer(airbrake.BuildServer(&airbrakeServerContext))
+
+	otlpServerContext := otlp.ServerContext{
+		Context:          context.Background(),
+		ServiceFetcher:   serviceFetcher,
+		EventsDispatcher: event.NewRateLimitedDispatcher(dispatcher, serviceLimiter),
+	}
+
+	if resolver, ok := serviceFetcher.(otlp.ServiceResolver); ok {
+		otlpServerContext.ServiceResolver = resolver
+	}
+
+	go otlp.SetupServer(otlp.BuildServer(&otlpServerContext))
+	go otlp.SetupGrpcServer(otlp.BuildGrpcServer(&otlpServerContext))
+
+	rpcServerContext := rpc.ServerContext{
+		Context:          context.Background(),
+		ServiceFetcher:   serviceFetcher,
+		EventsDispatcher: event.NewRateLimitedDispatcher(dispatcher, serviceLimiter),
+	}
+
+	go rpc.SetupServer(rpc.BuildServer(&rpcServerContext))
+
+	eventRepository := buildEventRepository()
+
 	webServerContext := web.ServerContext{
-		Context: context.Background(),
-		Queue:   nats,
+		Context:          context.Background(),
+		Queue:            nats,
+		ServiceFetcher:   serviceFetcher,
+		EventsDispatcher: event.NewRateLimitedDispatcher(dispatcher, serviceLimiter),
+		EventRepository:  eventRepository,
+	}
+
+	if issueRepository, ok := eventRepository.(store.IssueRepository); ok {
+		webServerContext.IssueRepository = issueRepository
 	}
 
 	web.SetupServer(&webServerContext)
 }
 
-func buildQueue() storage.Queue {
-	var queue storage.Queue
-	var err error
+func buildEventRepository() store.EventRepository {
+	eventStore := config.EventStore()
 
+	if eventStore == "" {
+		return nil
+	}
+
+	repo, err := store.BuildEventRepository(context.Background(), eventStore)
+
+	if err != nil {
+		log.Fatal("❌ Something went wrong when trying to connect to the event store.", err)
+	}
+
+	return repo
+}
+
+func buildQueue() storage.Queue {
 	eventChannel := config.EventChannel()
 
 	log.Infof("The event channel is %v", eventChannel)
 
-	switch eventChannel {
-	case "nats":
-		queue, err = storage.NewNatsConnection(config.NatsConnectionUrl())
-	case "redis":
-		queue, err = storage.NewRedisConnection(config.RedisConnectionUrl())
-	}
+	queue, err := storage.BuildQueue(eventChannel)
 
 	if err != nil {
 		log.Fatal("❌ Something went wrong when trying to construct Queue's connection.", err)
@@ -65,3 +154,57 @@ func buildQueue() storage.Queue {
 
 	return queue
 }
+
+func buildDispatcherSettings(configFile *settings.ConfigFile) event.DispatcherSettings {
+	return event.DispatcherSettings{
+		Topic:           routing.DefaultTopic,
+		Router:          routing.NewRouter(configFile),
+		DeadLetterTopic: config.DispatchDeadLetterTopic(),
+		Retry: event.RetryPolicy{
+			Attempts:       config.DispatchRetryAttempts(),
+			InitialBackoff: config.DispatchRetryBackoff(),
+			MaxBackoff:     config.DispatchRetryMaxBackoff(),
+		},
+	}
+}
+
+// Runs the worker in process, so a single node needs no broker, e.g. with the memory queue and SQLite
+func maybeRunWorker(queue storage.Queue, configFile *settings.ConfigFile) {
+	if !config.WorkerEmbedded() {
+		return
+	}
+
+	w, err := worker.BuildWorker(queue, configFile)
+
+	if err != nil {
+		log.Fatal("❌ Something went wrong when trying to build the sinks.", err)
+	}
+
+	w.PublishMetrics()
+
+	go func() {
+		if err := w.Run(context.Background()); err != nil {
+			log.Errorf("⛔ The embedded worker stopped: %v", err)
+		}
+	}()
+}
+
+func maybeUseSpool(queue storage.Queue) storage.Queue {
+	if config.SpoolDir() == "" {
+		return queue
+	}
+
+	s, err := spool.Open(config.SpoolDir(), config.SpoolSegmentBytes(), config.SpoolMaxBytes())
+
+	if err != nil {
+		log.Fatal("❌ Something went wrong when trying to open the spool.", err)
+	}
+
+	s.PublishMetrics()
+
+	spoolingQueue := spool.NewSpoolingQueue(queue, s)
+
+	go spoolingQueue.Drain(context.Background(), config.SpoolDrainInterval())
+
+	return spoolingQueue
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/williampsena/bugs-channel/pkg/config"
+	"github.com/williampsena/bugs-channel/pkg/logger"
+	"github.com/williampsena/bugs-channel/pkg/routing"
+	"github.com/williampsena/bugs-channel/pkg/settings"
+	"github.com/williampsena/bugs-channel/pkg/storage"
+	"github.com/williampsena/bugs-channel/pkg/worker"
+)
+
+func init() {
+	logger.Setup()
+}
+
+// Consumes the events topic and writes the events to the configured sinks,
+// a SIGINT or SIGTERM stops consuming and drains the events in flight.
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	configFile, err := settings.BuildConfigFile(config.ConfigFile())
+
+	if err != nil {
+		log.Fatal("❌ The configuration file is in incorrect format or does not exist.", err)
+	}
+
+	if err := routing.ValidateTopics(configFile, config.EventChannel()); err != nil {
+		log.Fatal("❌ The routing topics are not supported by the event channel.", err)
+	}
+
+	queue, err := storage.BuildQueue(config.EventChannel())
+
+	if err != nil {
+		log.Fatal("❌ Something went wrong when trying to construct Queue's connection.", err)
+	}
+
+	w, err := worker.BuildWorker(queue, configFile)
+
+	if err != nil {
+		log.Fatal("❌ Something went wrong when trying to build the sinks.", err)
+	}
+
+	w.PublishMetrics()
+
+	if err := w.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		log.Fatal("❌ Something went wrong when consuming the events topic.", err)
+	}
+}
@@ -0,0 +1,203 @@
+// This package accepts the Airbrake v3 notice API, so Airbrake notifiers report to BugsChannel
+package airbrake
+
+import (
+	"strings"
+
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/ingest"
+)
+
+// Represents an Airbrake notice
+type Notice struct {
+	Errors      []Error        `json:"errors"`
+	Context     Context        `json:"context"`
+	Environment map[string]any `json:"environment"`
+	Session     map[string]any `json:"session"`
+	Params      map[string]any `json:"params"`
+}
+
+// Represents an error, the first one of a notice is the raised error and the next ones its causes
+type Error struct {
+	Type      string          `json:"type"`
+	Message   string          `json:"message"`
+	Backtrace []BacktraceLine `json:"backtrace"`
+}
+
+// Represents a backtrace line, the most recent call first
+type BacktraceLine struct {
+	File     string         `json:"file"`
+	Line     any            `json:"line"`
+	Column   any            `json:"column"`
+	Function string         `json:"function"`
+	Code     map[string]any `json:"code"`
+}
+
+// Represents the notice context
+type Context struct {
+	Notifier      Notifier       `json:"notifier"`
+	Environment   string         `json:"environment"`
+	Version       string         `json:"version"`
+	Os            string         `json:"os"`
+	Hostname      string         `json:"hostname"`
+	Language      string         `json:"language"`
+	Component     string         `json:"component"`
+	Action        string         `json:"action"`
+	Route         string         `json:"route"`
+	HttpMethod    string         `json:"httpMethod"`
+	Url           string         `json:"url"`
+	UserAgent     string         `json:"userAgent"`
+	UserAddr      string         `json:"userAddr"`
+	RootDirectory string         `json:"rootDirectory"`
+	Severity      string         `json:"severity"`
+	User          map[string]any `json:"user"`
+}
+
+// Represents the notifier library of the notice
+type Notifier struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Url     string `json:"url"`
+}
+
+// The Airbrake severities mapped to the BugsChannel levels
+var levels = map[string]string{
+	"debug":     "debug",
+	"info":      "info",
+	"notice":    "info",
+	"warning":   "warning",
+	"error":     "error",
+	"critical":  "fatal",
+	"alert":     "fatal",
+	"emergency": "fatal",
+}
+
+// The notifier names that are not the language, e.g. pybrake
+var platforms = map[string]string{
+	"py": "python",
+	"js": "javascript",
+}
+
+// Returns true when the notice carries an error
+func (n Notice) Valid() bool {
+	return len(n.Errors) > 0 && (n.Errors[0].Type != "" || n.Errors[0].Message != "")
+}
+
+// Maps the notice onto a BugsChannel event of the service
+func (n Notice) Event(serviceId string) (event.Event, error) {
+	raised := n.Errors[0]
+	extra := event.EventExtra{}
+
+	ingest.SetExtra(extra, "params", n.Params)
+	ingest.SetExtra(extra, "session", n.Session)
+	ingest.SetExtra(extra, "environment", n.Environment)
+	ingest.SetExtra(extra, "user", n.Context.User)
+	ingest.SetExtra(extra, "url", n.Context.Url)
+	ingest.SetExtra(extra, "http_method", n.Context.HttpMethod)
+	ingest.SetExtra(extra, "user_agent", n.Context.UserAgent)
+	ingest.SetExtra(extra, "user_addr", n.Context.UserAddr)
+	ingest.SetExtra(extra, "root_directory", n.Context.RootDirectory)
+	ingest.SetExtra(extra, "os", n.Context.Os)
+	ingest.SetExtra(extra, "language", n.Context.Language)
+	ingest.SetExtra(extra, "causes", n.causes())
+	ingest.SetExtra(extra, "notifier", strings.TrimSpace(n.Context.Notifier.Name+" "+n.Context.Notifier.Version))
+
+	id, err := ingest.NewEventId()
+
+	if err != nil {
+		return event.Event{}, err
+	}
+
+	return event.Event{
+		ID:          id,
+		ServiceId:   serviceId,
+		Platform:    n.platform(),
+		Environment: n.Context.Environment,
+		Release:     n.Context.Version,
+		ServerName:  n.Context.Hostname,
+		Title:       raised.Type,
+		Body:        raised.Message,
+		StackTrace:  raised.stackTrace(),
+		Kind:        "error",
+		Level:       n.level(),
+		Tags: ingest.Tags(
+			ingest.Tag("environment", n.Context.Environment),
+			ingest.Tag("release", n.Context.Version),
+			ingest.Tag("server_name", n.Context.Hostname),
+			ingest.Tag("component", n.Context.Component),
+			ingest.Tag("action", n.Context.Action),
+			ingest.Tag("route", n.Context.Route),
+			ingest.Tag("user", n.Context.User["id"]),
+		),
+		Extra: extra,
+	}, nil
+}
+
+// Returns the causes of the raised error, with their frames
+func (n Notice) causes() []any {
+	causes := make([]any, 0, len(n.Errors))
+
+	for _, e := range n.Errors[1:] {
+		causes = append(causes, map[string]any{
+			"class":       e.Type,
+			"message":     e.Message,
+			"stack_trace": e.stackTrace(),
+		})
+	}
+
+	return causes
+}
+
+// Returns the BugsChannel level of the severity, error when it is unknown
+func (n Notice) level() string {
+	if level, ok := levels[strings.ToLower(n.Context.Severity)]; ok {
+		return level
+	}
+
+	return "error"
+}
+
+// Returns the notifier language, e.g. php for phpbrake or ruby for airbrake-ruby
+func (n Notice) platform() string {
+	name := strings.ToLower(n.Context.Notifier.Name)
+	name, _, _ = strings.Cut(name, "/")
+
+	if language, ok := strings.CutPrefix(name, "airbrake-"); ok {
+		name = language
+	} else if language, ok := strings.CutSuffix(name, "brake"); ok {
+		name = language
+	}
+
+	if platform, ok := platforms[name]; ok {
+		return platform
+	}
+
+	if name == "" {
+		return "airbrake"
+	}
+
+	return name
+}
+
+// Returns the backtrace as frames
+func (e Error) stackTrace() event.StackTrace {
+	frames := make(event.StackTrace, 0, len(e.Backtrace))
+
+	for _, line := range e.Backtrace {
+		frame := map[string]interface{}{
+			"filename": line.File,
+			"function": line.Function,
+			"lineno":   ingest.Number(line.Line),
+			"colno":    ingest.Number(line.Column),
+		}
+
+		// the project files are rooted at /PROJECT_ROOT by the notifiers
+		if strings.HasPrefix(line.File, "/PROJECT_ROOT/") {
+			frame["in_app"] = true
+		}
+
+		frames = append(frames, frame)
+	}
+
+	return frames
+}
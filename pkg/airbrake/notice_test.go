@@ -0,0 +1,94 @@
+package airbrake
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+)
+
+func TestNoticeEvent(t *testing.T) {
+	notice := readNotice(t)
+
+	require.True(t, notice.Valid())
+
+	e, err := notice.Event("1")
+
+	require.Nil(t, err)
+
+	assert.Len(t, e.ID, 32)
+	assert.Equal(t, "1", e.ServiceId)
+	assert.Equal(t, "php", e.Platform)
+	assert.Equal(t, "production", e.Environment)
+	assert.Equal(t, "1.4.2", e.Release)
+	assert.Equal(t, "php-1", e.ServerName)
+	assert.Equal(t, "RuntimeException", e.Title)
+	assert.Equal(t, "Checkout failed", e.Body)
+	assert.Equal(t, "fatal", e.Level)
+
+	assert.Equal(t, []string{
+		"environment:production",
+		"release:1.4.2",
+		"server_name:php-1",
+		"component:orders",
+		"action:create",
+		"route:/orders",
+		"user:42",
+	}, e.Tags)
+
+	assert.Equal(t, event.StackTrace{
+		{"filename": "/PROJECT_ROOT/src/Controller/OrderController.php", "function": "App\\Controller\\OrderController->create", "lineno": 42, "colno": nil, "in_app": true},
+		{"filename": "/var/www/vendor/symfony/http-kernel/HttpKernel.php", "function": "Symfony\\Component\\HttpKernel\\HttpKernel->handleRaw", "lineno": 163, "colno": nil},
+	}, e.StackTrace)
+
+	causes := e.Extra["causes"].([]any)
+
+	require.Len(t, causes, 1)
+	assert.Equal(t, "PDOException", causes[0].(map[string]any)["class"])
+
+	assert.Equal(t, map[string]any{"order_id": "123"}, e.Extra["params"])
+	assert.Equal(t, "POST", e.Extra["http_method"])
+	assert.Equal(t, "phpbrake 0.8.0", e.Extra["notifier"])
+	assert.NotContains(t, e.Extra, "session")
+}
+
+func TestNoticePlatform(t *testing.T) {
+	cases := map[string]string{
+		"airbrake-ruby":       "ruby",
+		"airbrake-js/browser": "javascript",
+		"gobrake":             "go",
+		"pybrake":             "python",
+		"":                    "airbrake",
+	}
+
+	for name, platform := range cases {
+		notice := Notice{Context: Context{Notifier: Notifier{Name: name}}}
+
+		assert.Equal(t, platform, notice.platform(), name)
+	}
+}
+
+func TestNoticeLevel(t *testing.T) {
+	assert.Equal(t, "info", Notice{Context: Context{Severity: "notice"}}.level())
+	assert.Equal(t, "error", Notice{}.level())
+}
+
+func TestNoticeValid(t *testing.T) {
+	assert.False(t, Notice{}.Valid())
+	assert.False(t, Notice{Errors: []Error{{}}}.Valid())
+}
+
+func readNotice(t *testing.T) Notice {
+	body, err := os.ReadFile("../../fixtures/airbrake/notice.json")
+
+	require.Nil(t, err)
+
+	var notice Notice
+
+	require.Nil(t, json.Unmarshal(body, &notice))
+
+	return notice
+}
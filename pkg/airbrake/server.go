@@ -0,0 +1,95 @@
+package airbrake
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	plugin "github.com/williampsena/bugs-channel-plugins/pkg/service"
+	"github.com/williampsena/bugs-channel/pkg/config"
+	"github.com/williampsena/bugs-channel/pkg/ingest"
+)
+
+// The Airbrake server context
+type ServerContext struct {
+	context.Context
+	ServiceFetcher   plugin.ServiceFetcher
+	EventsDispatcher event.EventsDispatcher
+}
+
+// Represents the notice response
+type NoticeResponse struct {
+	Id string `json:"id"`
+}
+
+// Receives a notice, the project key is the key query parameter or the bearer token
+func NoticeEndpoint(c *ServerContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		service, err := c.ServiceFetcher.GetServiceByAuthKey(projectKey(req))
+
+		if err != nil {
+			ingest.WriteError(w, http.StatusUnauthorized, errors.Join(ingest.ErrUnauthorized, err))
+			return
+		}
+
+		var notice Notice
+
+		if err := ingest.DecodeJson(w, req, &notice); err != nil {
+			ingest.WriteError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if !notice.Valid() {
+			ingest.WriteError(w, http.StatusUnprocessableEntity, ingest.ErrInvalidPayload)
+			return
+		}
+
+		e, err := notice.Event(service.Id)
+
+		if err != nil {
+			ingest.WriteError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		if err := c.EventsDispatcher.Dispatch(e); err != nil {
+			ingest.WriteError(w, ingest.DispatchStatus(err), err)
+			return
+		}
+
+		ingest.WriteJson(w, http.StatusCreated, NoticeResponse{Id: e.ID})
+	}
+}
+
+// Returns the project key of the request
+func projectKey(req *http.Request) string {
+	if key := req.URL.Query().Get("key"); key != "" {
+		return key
+	}
+
+	if token := ingest.BearerToken(req.Header.Get("Authorization")); token != "" {
+		return token
+	}
+
+	return req.Header.Get("X-Airbrake-Token")
+}
+
+// Build the Airbrake router, the project id is not checked since the key identifies the service
+func BuildRouter(c *ServerContext) *mux.Router {
+	r := mux.NewRouter()
+
+	r.HandleFunc("/api/v3/projects/{id}/notices", NoticeEndpoint(c)).Methods("POST")
+
+	return r
+}
+
+// Build the Airbrake server listening at AIRBRAKE_PORT
+func BuildServer(c *ServerContext) *http.Server {
+	return ingest.NewServer(config.AirbrakePort(), BuildRouter(c))
+}
+
+// Listens the Airbrake server
+func SetupServer(srv *http.Server) {
+	ingest.ListenAndServe("Airbrake", srv)
+}
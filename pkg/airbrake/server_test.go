@@ -0,0 +1,113 @@
+package airbrake
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel/pkg/test"
+)
+
+func TestNoticeEndpoint(t *testing.T) {
+	dispatcher := &test.MockDispatcher{}
+	svr := buildTestServer(t, dispatcher)
+
+	res := postNotice(t, svr.URL+"/api/v3/projects/1/notices?key=key", nil, readFixture(t))
+
+	require.Equal(t, http.StatusCreated, res.StatusCode)
+
+	var body NoticeResponse
+
+	require.Nil(t, json.NewDecoder(res.Body).Decode(&body))
+	require.Len(t, dispatcher.Events, 1)
+
+	assert.Equal(t, dispatcher.Events[0].ID, body.Id)
+	assert.Equal(t, "1", dispatcher.Events[0].ServiceId)
+	assert.Equal(t, "RuntimeException", dispatcher.Events[0].Title)
+}
+
+func TestNoticeEndpointBearer(t *testing.T) {
+	dispatcher := &test.MockDispatcher{}
+	svr := buildTestServer(t, dispatcher)
+
+	res := postNotice(t, svr.URL+"/api/v3/projects/1/notices", map[string]string{"Authorization": "Bearer key"}, readFixture(t))
+
+	assert.Equal(t, http.StatusCreated, res.StatusCode)
+	assert.Len(t, dispatcher.Events, 1)
+}
+
+func TestNoticeEndpointUnauthorized(t *testing.T) {
+	dispatcher := &test.MockDispatcher{}
+	svr := buildTestServer(t, dispatcher)
+
+	for _, url := range []string{"/api/v3/projects/1/notices?key=invalid", "/api/v3/projects/1/notices"} {
+		res := postNotice(t, svr.URL+url, nil, readFixture(t))
+
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode, url)
+	}
+
+	assert.Empty(t, dispatcher.Events)
+}
+
+func TestNoticeEndpointInvalid(t *testing.T) {
+	svr := buildTestServer(t, &test.MockDispatcher{})
+
+	res := postNotice(t, svr.URL+"/api/v3/projects/1/notices?key=key", nil, []byte(`foo`))
+
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res = postNotice(t, svr.URL+"/api/v3/projects/1/notices?key=key", nil, []byte(`{"errors": []}`))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, res.StatusCode)
+}
+
+func TestNoticeEndpointDispatchError(t *testing.T) {
+	svr := buildTestServer(t, &test.MockDispatcher{Err: errors.New("queue is down")})
+
+	res := postNotice(t, svr.URL+"/api/v3/projects/1/notices?key=key", nil, readFixture(t))
+
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+}
+
+func postNotice(t *testing.T, url string, headers map[string]string, body []byte) *http.Response {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+
+	require.Nil(t, err)
+
+	req.Header.Set("Content-Type", "application/json")
+
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+
+	require.Nil(t, err)
+
+	t.Cleanup(func() { res.Body.Close() })
+
+	return res
+}
+
+func readFixture(t *testing.T) []byte {
+	body, err := os.ReadFile("../../fixtures/airbrake/notice.json")
+
+	require.Nil(t, err)
+
+	return body
+}
+
+func buildTestServer(t *testing.T, dispatcher *test.MockDispatcher) *httptest.Server {
+	return test.BuildServer(t, BuildRouter(&ServerContext{
+		Context:          context.Background(),
+		ServiceFetcher:   test.BuildServiceFetcher(t),
+		EventsDispatcher: dispatcher,
+	}))
+}
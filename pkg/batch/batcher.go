@@ -0,0 +1,180 @@
+// This package groups the items of concurrent writers into batches, each writer waits for the batch holding its item
+package batch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Represents an error when an item is added after the batcher was closed
+var ErrClosed = errors.New("the batcher is closed")
+
+// Represents the batch size and how long a batch waits to fill up
+type Settings struct {
+	// How many items are sent per batch
+	Size int
+	// How long a batch waits for more items before being sent, zero sends it once the previous batch is done
+	Linger time.Duration
+}
+
+// Sends a batch, returning the error of each item in order, or nil when every item was sent
+type FlushFunc[T any] func(ctx context.Context, items []T) []error
+
+type request[T any] struct {
+	item T
+	done chan error
+}
+
+// Batches the added items, sending one batch at a time
+type Batcher[T any] struct {
+	flush    FlushFunc[T]
+	settings Settings
+	mu       sync.Mutex
+	pending  []request[T]
+	closed   bool
+	ready    chan struct{}
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// Adds the item to the next batch, returning once the batch is sent with its error
+func (b *Batcher[T]) Add(ctx context.Context, item T) error {
+	done := make(chan error, 1)
+
+	b.mu.Lock()
+
+	if b.closed {
+		b.mu.Unlock()
+		return ErrClosed
+	}
+
+	b.pending = append(b.pending, request[T]{item, done})
+
+	b.mu.Unlock()
+
+	select {
+	case b.ready <- struct{}{}:
+	default:
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Sends the pending items whenever an item is added, waiting the linger first
+func (b *Batcher[T]) run() {
+	defer b.wg.Done()
+
+	for {
+		select {
+		case <-b.stop:
+			b.flushPending()
+			return
+		case <-b.ready:
+		}
+
+		if b.settings.Linger > 0 {
+			select {
+			case <-b.stop:
+			case <-time.After(b.settings.Linger):
+			}
+		}
+
+		b.flushPending()
+	}
+}
+
+// Sends the pending items in batches of the size until none is left
+func (b *Batcher[T]) flushPending() {
+	for {
+		batch := b.take()
+
+		if len(batch) == 0 {
+			return
+		}
+
+		items := make([]T, len(batch))
+
+		for i, r := range batch {
+			items[i] = r.item
+		}
+
+		errs := b.flush(context.Background(), items)
+
+		for i, r := range batch {
+			if i < len(errs) {
+				r.done <- errs[i]
+			} else {
+				r.done <- nil
+			}
+		}
+	}
+}
+
+// Returns the error of a batch sent at once as the error of each of its items, nil when the batch was sent
+func Errors(n int, err error) []error {
+	if err == nil {
+		return nil
+	}
+
+	errs := make([]error, n)
+
+	for i := range errs {
+		errs[i] = err
+	}
+
+	return errs
+}
+
+func (b *Batcher[T]) take() []request[T] {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	n := min(len(b.pending), b.settings.Size)
+	batch := b.pending[:n:n]
+	b.pending = b.pending[n:]
+
+	return batch
+}
+
+// Sends the pending items and stops, the later adds fail with ErrClosed
+func (b *Batcher[T]) Close() {
+	b.mu.Lock()
+
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+
+	b.closed = true
+
+	b.mu.Unlock()
+
+	close(b.stop)
+	b.wg.Wait()
+}
+
+// Build a new batcher sending the batches with the flush function, by default batches hold up to 500 items
+func NewBatcher[T any](flush FlushFunc[T], settings Settings) *Batcher[T] {
+	if settings.Size <= 0 {
+		settings.Size = 500
+	}
+
+	b := &Batcher[T]{
+		flush:    flush,
+		settings: settings,
+		ready:    make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+	}
+
+	b.wg.Add(1)
+	go b.run()
+
+	return b
+}
@@ -0,0 +1,169 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatcherGroupsConcurrentAdds(t *testing.T) {
+	flusher := &mockFlusher{}
+	b := NewBatcher(flusher.flush, Settings{Size: 2, Linger: 50 * time.Millisecond})
+
+	defer b.Close()
+
+	errs := addConcurrently(b, "a", "b", "c")
+
+	assert.Equal(t, []error{nil, nil, nil}, errs)
+
+	// the linger lets the concurrent adds share the batches, which hold up to the size
+	batches := flusher.sent()
+
+	require.Len(t, batches, 2)
+	assert.Len(t, batches[0], 2)
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, slices.Concat(batches...))
+}
+
+func TestBatcherReturnsTheFlushError(t *testing.T) {
+	flusher := &mockFlusher{err: errors.New("backend is down")}
+	b := NewBatcher(flusher.flush, Settings{Size: 10, Linger: 50 * time.Millisecond})
+
+	defer b.Close()
+
+	for _, err := range addConcurrently(b, "a", "b") {
+		assert.EqualError(t, err, "backend is down")
+	}
+}
+
+func TestBatcherReturnsTheItemErrors(t *testing.T) {
+	flusher := &mockFlusher{rejected: map[string]error{"b": errors.New("b is invalid")}}
+	b := NewBatcher(flusher.flush, Settings{Size: 10, Linger: 50 * time.Millisecond})
+
+	defer b.Close()
+
+	errs := addConcurrently(b, "a", "b", "c")
+
+	// only the writer of the rejected item gets an error
+	require.Len(t, flusher.sent(), 1)
+	assert.Nil(t, errs[0])
+	assert.EqualError(t, errs[1], "b is invalid")
+	assert.Nil(t, errs[2])
+}
+
+func TestErrors(t *testing.T) {
+	err := errors.New("backend is down")
+
+	assert.Nil(t, Errors(2, nil))
+	assert.Equal(t, []error{err, err}, Errors(2, err))
+}
+
+func TestBatcherWithoutLinger(t *testing.T) {
+	flusher := &mockFlusher{}
+	b := NewBatcher(flusher.flush, Settings{})
+
+	defer b.Close()
+
+	require.Nil(t, b.Add(context.Background(), "a"))
+	require.Nil(t, b.Add(context.Background(), "b"))
+
+	assert.Equal(t, [][]string{{"a"}, {"b"}}, flusher.sent())
+}
+
+func TestBatcherClose(t *testing.T) {
+	flusher := &mockFlusher{}
+	b := NewBatcher(flusher.flush, Settings{Linger: time.Hour})
+
+	errs := make(chan error, 1)
+
+	go func() {
+		errs <- b.Add(context.Background(), "a")
+	}()
+
+	assert.Eventually(t, func() bool {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		return len(b.pending) == 1
+	}, time.Second, time.Millisecond)
+
+	// the pending items are sent on close instead of waiting the linger
+	b.Close()
+	b.Close()
+
+	assert.Nil(t, <-errs)
+	assert.Equal(t, [][]string{{"a"}}, flusher.sent())
+	assert.ErrorIs(t, b.Add(context.Background(), "b"), ErrClosed)
+}
+
+func TestBatcherAddContext(t *testing.T) {
+	b := NewBatcher((&mockFlusher{}).flush, Settings{Linger: time.Hour})
+
+	defer b.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, b.Add(ctx, "a"), context.DeadlineExceeded)
+}
+
+func addConcurrently(b *Batcher[string], items ...string) []error {
+	errs := make([]error, len(items))
+
+	var wg sync.WaitGroup
+
+	for i, item := range items {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			errs[i] = b.Add(context.Background(), item)
+		}()
+	}
+
+	wg.Wait()
+
+	return errs
+}
+
+type mockFlusher struct {
+	mu       sync.Mutex
+	batches  [][]string
+	err      error
+	rejected map[string]error
+}
+
+// Records the batch
+func (m *mockFlusher) flush(ctx context.Context, items []string) []error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.batches = append(m.batches, items)
+
+	errs := Errors(len(items), m.err)
+
+	for i, item := range items {
+		if err, ok := m.rejected[item]; ok {
+			if errs == nil {
+				errs = make([]error, len(items))
+			}
+
+			errs[i] = err
+		}
+	}
+
+	return errs
+}
+
+// Returns the recorded batches
+func (m *mockFlusher) sent() [][]string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.batches
+}
@@ -0,0 +1,219 @@
+// This package accepts the Bugsnag notify API, so Bugsnag notifiers report to BugsChannel
+package bugsnag
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/fingerprint"
+	"github.com/williampsena/bugs-channel/pkg/ingest"
+)
+
+// Represents a Bugsnag notify payload, it carries many events
+type Payload struct {
+	ApiKey         string   `json:"apiKey"`
+	PayloadVersion string   `json:"payloadVersion"`
+	Notifier       Notifier `json:"notifier"`
+	Events         []Event  `json:"events"`
+}
+
+// Represents the notifier library of the payload
+type Notifier struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Url     string `json:"url"`
+}
+
+// Represents a Bugsnag event
+type Event struct {
+	Exceptions     []Exception    `json:"exceptions"`
+	Breadcrumbs    []any          `json:"breadcrumbs"`
+	Request        map[string]any `json:"request"`
+	Threads        []any          `json:"threads"`
+	Context        string         `json:"context"`
+	GroupingHash   string         `json:"groupingHash"`
+	Unhandled      *bool          `json:"unhandled"`
+	Severity       string         `json:"severity"`
+	SeverityReason map[string]any `json:"severityReason"`
+	User           map[string]any `json:"user"`
+	App            App            `json:"app"`
+	Device         Device         `json:"device"`
+	MetaData       map[string]any `json:"metaData"`
+}
+
+// Represents an exception, the first one of an event is the raised exception and the next ones its causes
+type Exception struct {
+	ErrorClass string           `json:"errorClass"`
+	Message    string           `json:"message"`
+	Type       string           `json:"type"`
+	Stacktrace []StacktraceLine `json:"stacktrace"`
+}
+
+// Represents a stack frame, the most recent call first
+type StacktraceLine struct {
+	File         string         `json:"file"`
+	LineNumber   any            `json:"lineNumber"`
+	ColumnNumber any            `json:"columnNumber"`
+	Method       string         `json:"method"`
+	InProject    *bool          `json:"inProject"`
+	Code         map[string]any `json:"code"`
+}
+
+// Represents the application the event happened in
+type App struct {
+	Id           string `json:"id"`
+	Version      string `json:"version"`
+	ReleaseStage string `json:"releaseStage"`
+	Type         string `json:"type"`
+}
+
+// Represents the device or server the event happened on
+type Device struct {
+	Hostname     string `json:"hostname"`
+	OsName       string `json:"osName"`
+	OsVersion    string `json:"osVersion"`
+	Manufacturer string `json:"manufacturer"`
+	Model        string `json:"model"`
+}
+
+// Returns true when the event carries an exception
+func (e Event) Valid() bool {
+	return len(e.Exceptions) > 0 && (e.Exceptions[0].ErrorClass != "" || e.Exceptions[0].Message != "")
+}
+
+// Maps the payload events onto BugsChannel events of the service
+func (p Payload) BugsChannelEvents(serviceId string) ([]event.Event, error) {
+	events := make([]event.Event, 0, len(p.Events))
+
+	for _, e := range p.Events {
+		ev, err := e.Event(serviceId, p.Notifier)
+
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, ev)
+	}
+
+	return events, nil
+}
+
+// Maps the event onto a BugsChannel event of the service
+func (e Event) Event(serviceId string, notifier Notifier) (event.Event, error) {
+	exception := e.Exceptions[0]
+	extra := event.EventExtra{}
+
+	ingest.SetExtra(extra, "metadata", e.MetaData)
+	ingest.SetExtra(extra, "user", e.User)
+	ingest.SetExtra(extra, "request", e.Request)
+	ingest.SetExtra(extra, "breadcrumbs", e.Breadcrumbs)
+	ingest.SetExtra(extra, "threads", e.Threads)
+	ingest.SetExtra(extra, "context", e.Context)
+	ingest.SetExtra(extra, "severity_reason", e.SeverityReason)
+	ingest.SetExtra(extra, "app_id", e.App.Id)
+	ingest.SetExtra(extra, "app_type", e.App.Type)
+	ingest.SetExtra(extra, "causes", e.causes())
+	ingest.SetExtra(extra, "notifier", strings.TrimSpace(notifier.Name+" "+notifier.Version))
+	ingest.SetExtra(extra, fingerprint.ExtraKey, e.GroupingHash)
+
+	tags := ingest.Tags(
+		ingest.Tag("environment", e.App.ReleaseStage),
+		ingest.Tag("release", e.App.Version),
+		ingest.Tag("server_name", e.Device.Hostname),
+		ingest.Tag("context", e.Context),
+		ingest.Tag("os", e.Device.OsName),
+		ingest.Tag("user", e.User["id"]),
+	)
+
+	if e.Unhandled != nil {
+		tags = append(tags, ingest.Tag("unhandled", *e.Unhandled))
+	}
+
+	id, err := ingest.NewEventId()
+
+	if err != nil {
+		return event.Event{}, err
+	}
+
+	return event.Event{
+		ID:          id,
+		ServiceId:   serviceId,
+		Platform:    platform(exception, notifier),
+		Environment: e.App.ReleaseStage,
+		Release:     e.App.Version,
+		ServerName:  e.Device.Hostname,
+		Title:       exception.ErrorClass,
+		Body:        exception.Message,
+		StackTrace:  exception.stackTrace(),
+		Kind:        "error",
+		Level:       e.level(),
+		Tags:        tags,
+		Extra:       extra,
+	}, nil
+}
+
+// Returns the causes of the raised exception, with their frames
+func (e Event) causes() []any {
+	causes := make([]any, 0, len(e.Exceptions))
+
+	for _, exception := range e.Exceptions[1:] {
+		causes = append(causes, map[string]any{
+			"class":       exception.ErrorClass,
+			"message":     exception.Message,
+			"stack_trace": exception.stackTrace(),
+		})
+	}
+
+	return causes
+}
+
+// Returns the severity, error when it is not set
+func (e Event) level() string {
+	if e.Severity == "" {
+		return "error"
+	}
+
+	return e.Severity
+}
+
+// Returns the exception type, e.g. android, or the notifier language, e.g. php for Bugsnag PHP
+func platform(exception Exception, notifier Notifier) string {
+	if exception.Type != "" {
+		return exception.Type
+	}
+
+	name := strings.ToLower(notifier.Name)
+
+	if language, ok := strings.CutPrefix(name, "bugsnag "); ok {
+		return strings.ReplaceAll(language, " ", "-")
+	}
+
+	return "bugsnag"
+}
+
+// Returns the stack frames, the project ones are in app
+func (x Exception) stackTrace() event.StackTrace {
+	frames := make(event.StackTrace, 0, len(x.Stacktrace))
+
+	for _, line := range x.Stacktrace {
+		frame := map[string]interface{}{
+			"filename": line.File,
+			"function": line.Method,
+			"lineno":   ingest.Number(line.LineNumber),
+			"colno":    ingest.Number(line.ColumnNumber),
+		}
+
+		if line.InProject != nil {
+			frame["in_app"] = *line.InProject
+		}
+
+		if code, ok := line.Code[fmt.Sprint(frame["lineno"])]; ok {
+			frame["context_line"] = code
+		}
+
+		frames = append(frames, frame)
+	}
+
+	return frames
+}
@@ -0,0 +1,97 @@
+package bugsnag
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+)
+
+func TestPayloadEvents(t *testing.T) {
+	payload := readPayload(t)
+
+	events, err := payload.BugsChannelEvents("1")
+
+	require.Nil(t, err)
+
+	require.Len(t, events, 2)
+
+	e := events[0]
+
+	assert.Len(t, e.ID, 32)
+	assert.Equal(t, "1", e.ServiceId)
+	assert.Equal(t, "android", e.Platform)
+	assert.Equal(t, "production", e.Environment)
+	assert.Equal(t, "2.4.0", e.Release)
+	assert.Equal(t, "java.lang.IllegalStateException", e.Title)
+	assert.Equal(t, "Checkout failed", e.Body)
+	assert.Equal(t, "error", e.Level)
+
+	assert.Equal(t, []string{
+		"environment:production",
+		"release:2.4.0",
+		"context:CheckoutActivity",
+		"os:android",
+		"user:42",
+		"unhandled:true",
+	}, e.Tags)
+
+	assert.Equal(t, event.StackTrace{
+		{
+			"filename":     "CheckoutActivity.kt",
+			"function":     "com.example.shop.CheckoutActivity.pay",
+			"lineno":       42,
+			"colno":        nil,
+			"in_app":       true,
+			"context_line": "throw IllegalStateException(\"Checkout failed\", e)",
+		},
+		{"filename": "View.java", "function": "android.view.View.performClick", "lineno": 7448, "colno": nil, "in_app": false},
+	}, e.StackTrace)
+
+	assert.Equal(t, []any{
+		map[string]any{
+			"class":   "java.net.SocketTimeoutException",
+			"message": "timeout",
+			"stack_trace": event.StackTrace{
+				{"filename": "PaymentClient.kt", "function": "com.example.shop.PaymentClient.charge", "lineno": 17, "colno": nil, "in_app": true},
+			},
+		},
+	}, e.Extra["causes"])
+
+	assert.Equal(t, map[string]any{"order": map[string]any{"id": "123", "total": 99.9}}, e.Extra["metadata"])
+	assert.Equal(t, "Jane", e.Extra["user"].(map[string]any)["name"])
+	assert.Equal(t, "Bugsnag Android 5.28.4", e.Extra["notifier"])
+	assert.NotContains(t, e.Extra, "fingerprint")
+
+	assert.Equal(t, "warning", events[1].Level)
+	assert.Equal(t, "android", events[1].Platform)
+	assert.Equal(t, "cart", events[1].Extra["fingerprint"])
+	assert.NotContains(t, events[1].Extra, "causes")
+}
+
+func TestEventValid(t *testing.T) {
+	assert.False(t, Event{}.Valid())
+	assert.False(t, Event{Exceptions: []Exception{{}}}.Valid())
+	assert.True(t, Event{Exceptions: []Exception{{Message: "foo"}}}.Valid())
+}
+
+func TestPlatform(t *testing.T) {
+	assert.Equal(t, "ruby", platform(Exception{}, Notifier{Name: "Bugsnag Ruby"}))
+	assert.Equal(t, "react-native", platform(Exception{}, Notifier{Name: "Bugsnag React Native"}))
+	assert.Equal(t, "bugsnag", platform(Exception{}, Notifier{}))
+}
+
+func readPayload(t *testing.T) Payload {
+	body, err := os.ReadFile("../../fixtures/bugsnag/payload.json")
+
+	require.Nil(t, err)
+
+	var payload Payload
+
+	require.Nil(t, json.Unmarshal(body, &payload))
+
+	return payload
+}
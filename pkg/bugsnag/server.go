@@ -0,0 +1,105 @@
+package bugsnag
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	plugin "github.com/williampsena/bugs-channel-plugins/pkg/service"
+	"github.com/williampsena/bugs-channel/pkg/config"
+	"github.com/williampsena/bugs-channel/pkg/ingest"
+)
+
+// The header carrying the project API key, one of the service auth keys
+const ApiKeyHeader = "Bugsnag-Api-Key"
+
+// The Bugsnag server context
+type ServerContext struct {
+	context.Context
+	ServiceFetcher   plugin.ServiceFetcher
+	EventsDispatcher event.EventsDispatcher
+}
+
+// Represents the notify response
+type NotifyResponse struct {
+	Ids []string `json:"ids"`
+}
+
+// Receives a payload of events, dispatching them together
+func NotifyEndpoint(c *ServerContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		var payload Payload
+
+		if err := ingest.DecodeJson(w, req, &payload); err != nil {
+			ingest.WriteError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		// notifiers before the payload version 4 send the key in the body
+		apiKey := req.Header.Get(ApiKeyHeader)
+
+		if apiKey == "" {
+			apiKey = payload.ApiKey
+		}
+
+		service, err := c.ServiceFetcher.GetServiceByAuthKey(apiKey)
+
+		if err != nil {
+			ingest.WriteError(w, http.StatusUnauthorized, errors.Join(ingest.ErrUnauthorized, err))
+			return
+		}
+
+		if len(payload.Events) == 0 {
+			ingest.WriteError(w, http.StatusUnprocessableEntity, ingest.ErrInvalidPayload)
+			return
+		}
+
+		for _, e := range payload.Events {
+			if !e.Valid() {
+				ingest.WriteError(w, http.StatusUnprocessableEntity, ingest.ErrInvalidPayload)
+				return
+			}
+		}
+
+		events, err := payload.BugsChannelEvents(service.Id)
+
+		if err != nil {
+			ingest.WriteError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		if err := c.EventsDispatcher.DispatchMany(events); err != nil {
+			ingest.WriteError(w, ingest.DispatchStatus(err), err)
+			return
+		}
+
+		ids := make([]string, len(events))
+
+		for i, e := range events {
+			ids[i] = e.ID
+		}
+
+		ingest.WriteJson(w, http.StatusOK, NotifyResponse{Ids: ids})
+	}
+}
+
+// Build the Bugsnag router
+func BuildRouter(c *ServerContext) *mux.Router {
+	r := mux.NewRouter()
+
+	r.HandleFunc("/", NotifyEndpoint(c)).Methods("POST")
+
+	return r
+}
+
+// Build the Bugsnag server listening at BUGSNAG_PORT
+func BuildServer(c *ServerContext) *http.Server {
+	return ingest.NewServer(config.BugsnagPort(), BuildRouter(c))
+}
+
+// Listens the Bugsnag server
+func SetupServer(srv *http.Server) {
+	ingest.ListenAndServe("Bugsnag", srv)
+}
@@ -0,0 +1,120 @@
+package bugsnag
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel/pkg/ratelimit"
+	"github.com/williampsena/bugs-channel/pkg/test"
+)
+
+func TestNotifyEndpoint(t *testing.T) {
+	dispatcher := &test.MockDispatcher{}
+	svr := buildTestServer(t, dispatcher)
+
+	res := postPayload(t, svr.URL, "key", readFixture(t))
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var body NotifyResponse
+
+	require.Nil(t, json.NewDecoder(res.Body).Decode(&body))
+	require.Len(t, dispatcher.Events, 2)
+
+	assert.Equal(t, []string{dispatcher.Events[0].ID, dispatcher.Events[1].ID}, body.Ids)
+	assert.Equal(t, "1", dispatcher.Events[1].ServiceId)
+}
+
+func TestNotifyEndpointBodyKey(t *testing.T) {
+	dispatcher := &test.MockDispatcher{}
+	svr := buildTestServer(t, dispatcher)
+
+	res := postPayload(t, svr.URL, "", readFixture(t))
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Len(t, dispatcher.Events, 2)
+}
+
+func TestNotifyEndpointUnauthorized(t *testing.T) {
+	dispatcher := &test.MockDispatcher{}
+	svr := buildTestServer(t, dispatcher)
+
+	res := postPayload(t, svr.URL, "invalid", readFixture(t))
+
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+
+	res = postPayload(t, svr.URL, "", []byte(`{"events": [{"exceptions": [{"message": "foo"}]}]}`))
+
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	assert.Empty(t, dispatcher.Events)
+}
+
+func TestNotifyEndpointInvalid(t *testing.T) {
+	dispatcher := &test.MockDispatcher{}
+	svr := buildTestServer(t, dispatcher)
+
+	res := postPayload(t, svr.URL, "key", []byte(`foo`))
+
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	for _, body := range []string{`{"events": []}`, `{"events": [{"exceptions": [{"message": "foo"}]}, {}]}`} {
+		res = postPayload(t, svr.URL, "key", []byte(body))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, res.StatusCode, body)
+	}
+
+	assert.Empty(t, dispatcher.Events)
+}
+
+func TestNotifyEndpointRateLimited(t *testing.T) {
+	svr := buildTestServer(t, &test.MockDispatcher{Err: fmt.Errorf("%w: service 1", ratelimit.ErrRateLimitExceeded)})
+
+	res := postPayload(t, svr.URL, "key", readFixture(t))
+
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+}
+
+func postPayload(t *testing.T, url string, apiKey string, body []byte) *http.Response {
+	req, err := http.NewRequest(http.MethodPost, url+"/", bytes.NewReader(body))
+
+	require.Nil(t, err)
+
+	if apiKey != "" {
+		req.Header.Set(ApiKeyHeader, apiKey)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Bugsnag-Payload-Version", "5")
+
+	res, err := http.DefaultClient.Do(req)
+
+	require.Nil(t, err)
+
+	t.Cleanup(func() { res.Body.Close() })
+
+	return res
+}
+
+func readFixture(t *testing.T) []byte {
+	body, err := os.ReadFile("../../fixtures/bugsnag/payload.json")
+
+	require.Nil(t, err)
+
+	return body
+}
+
+func buildTestServer(t *testing.T, dispatcher *test.MockDispatcher) *httptest.Server {
+	return test.BuildServer(t, BuildRouter(&ServerContext{
+		Context:          context.Background(),
+		ServiceFetcher:   test.BuildServiceFetcher(t),
+		EventsDispatcher: dispatcher,
+	}))
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // This occurs when an invalid server port number is provided.
@@ -33,15 +34,53 @@ func IsProduction() bool {
 
 // Returns the listen api port application
 func ApiPort() int {
-	env := getEnv("PORT", "4000")
+	return portEnv("PORT", "4000")
+}
 
-	port, err := strconv.Atoi(env)
+// Returns the Honeybadger notice API port
+func HoneybadgerPort() int {
+	return portEnv("HONEYBADGER_PORT", "4002")
+}
 
-	if err != nil {
-		panic(errors.Join(ErrInvalidPort, err))
+// Returns the Rollbar item API port
+func RollbarPort() int {
+	return portEnv("ROLLBAR_PORT", "4003")
+}
+
+// Returns the Bugsnag notify API port
+func BugsnagPort() int {
+	return portEnv("BUGSNAG_PORT", "4004")
+}
+
+// Returns the Airbrake notice API port
+func AirbrakePort() int {
+	return portEnv("AIRBRAKE_PORT", "4005")
+}
+
+// Returns the OTLP/HTTP receiver port
+func OtlpHttpPort() int {
+	return portEnv("OTLP_HTTP_PORT", "4318")
+}
+
+// Returns the OTLP/gRPC receiver port
+func OtlpGrpcPort() int {
+	return portEnv("OTLP_GRPC_PORT", "4317")
+}
+
+// The maximum size of a decompressed OTLP export, batches of spans and logs are larger than SDK payloads
+func OtlpMaxBodyBytes() int64 {
+	value, err := strconv.ParseInt(getEnv("OTLP_MAX_BODY_BYTES", "8388608"), 10, 64)
+
+	if err != nil || value <= 0 {
+		return 8388608
 	}
 
-	return port
+	return value
+}
+
+// Returns the gRPC event service port
+func GrpcPort() int {
+	return portEnv("GRPC_PORT", "4006")
 }
 
 // Returns the config file path
@@ -65,21 +104,471 @@ func NatsConnectionUrl() string {
 	return os.Getenv("NATS_URL")
 }
 
+// Indicate that Nats should use JetStream durable consumers
+func NatsJetStream() bool {
+	value, err := strconv.ParseBool(getEnv("NATS_JETSTREAM", "false"))
+
+	if err != nil {
+		return false
+	}
+
+	return value
+}
+
+// The Nats JetStream stream name
+func NatsStream() string {
+	return getEnv("NATS_STREAM", "EVENTS")
+}
+
+// The subjects captured by the Nats JetStream stream
+func NatsStreamSubjects() []string {
+	return strings.Split(getEnv("NATS_STREAM_SUBJECTS", "events,events.>"), ",")
+}
+
+// The Nats JetStream durable consumer name
+func NatsDurable() string {
+	return getEnv("NATS_DURABLE", "bugs-channel")
+}
+
+// How long Nats JetStream waits for an ack before redelivering
+func NatsAckWait() time.Duration {
+	value, err := time.ParseDuration(getEnv("NATS_ACK_WAIT", "30s"))
+
+	if err != nil {
+		return 30 * time.Second
+	}
+
+	return value
+}
+
+// The maximum delivery attempts of a Nats JetStream message
+func NatsMaxDeliver() int {
+	value, err := strconv.Atoi(getEnv("NATS_MAX_DELIVER", "5"))
+
+	if err != nil {
+		return 5
+	}
+
+	return value
+}
+
 // The Redis connection url
 func RedisConnectionUrl() string {
 	return os.Getenv("REDIS_URL")
 }
 
-// The event channel (redis/nats)
+// The RabbitMQ connection url
+func RabbitMQConnectionUrl() string {
+	return os.Getenv("RABBITMQ_URL")
+}
+
+// The RabbitMQ topic exchange name
+func RabbitMQExchange() string {
+	return getEnv("RABBITMQ_EXCHANGE", "bugs-channel")
+}
+
+// The RabbitMQ queue name prefix
+func RabbitMQQueue() string {
+	return getEnv("RABBITMQ_QUEUE", "bugs-channel")
+}
+
+// The topics whose RabbitMQ queues are declared on connect, so they keep the messages published before the worker subscribes
+func RabbitMQTopics() []string {
+	return strings.Split(getEnv("RABBITMQ_TOPICS", "events"), ",")
+}
+
+// How many unacked messages a RabbitMQ consumer may hold
+func RabbitMQPrefetch() int {
+	value, err := strconv.Atoi(getEnv("RABBITMQ_PREFETCH", "10"))
+
+	if err != nil {
+		return 10
+	}
+
+	return value
+}
+
+// The Kafka bootstrap brokers
+func KafkaBrokers() []string {
+	return strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ",")
+}
+
+// The Kafka consumer group id
+func KafkaGroupId() string {
+	return getEnv("KAFKA_GROUP_ID", "bugs-channel")
+}
+
+// The Kafka SASL mechanism (plain/scram-sha-256/scram-sha-512)
+func KafkaSASLMechanism() string {
+	return os.Getenv("KAFKA_SASL_MECHANISM")
+}
+
+// The Kafka SASL username
+func KafkaUsername() string {
+	return os.Getenv("KAFKA_USERNAME")
+}
+
+// The Kafka SASL password
+func KafkaPassword() string {
+	return os.Getenv("KAFKA_PASSWORD")
+}
+
+// Indicate that Kafka connections must use TLS
+func KafkaTLS() bool {
+	value, err := strconv.ParseBool(getEnv("KAFKA_TLS", "false"))
+
+	if err != nil {
+		return false
+	}
+
+	return value
+}
+
+// How many times a Kafka message is handled before being dead-lettered
+func KafkaMaxAttempts() int {
+	value, err := strconv.Atoi(getEnv("KAFKA_MAX_ATTEMPTS", "5"))
+
+	if err != nil {
+		return 5
+	}
+
+	return value
+}
+
+// The topic the dead letters of the Kafka messages whose attempts are exhausted go to, cmd/dlq manages it with -topic
+func KafkaDeadLetterTopic() string {
+	return getEnv("KAFKA_DEAD_LETTER_TOPIC", "events.consumer.dlq")
+}
+
+// The approximate maximum length of each Redis stream
+func RedisStreamMaxLen() int64 {
+	value, err := strconv.ParseInt(getEnv("REDIS_STREAM_MAXLEN", "100000"), 10, 64)
+
+	if err != nil {
+		return 100000
+	}
+
+	return value
+}
+
+// The Redis stream consumer group name
+func RedisStreamGroup() string {
+	return getEnv("REDIS_STREAM_GROUP", "bugs-channel")
+}
+
+// The Redis stream consumer name, defaults to the hostname
+func RedisStreamConsumer() string {
+	hostname, _ := os.Hostname()
+	return getEnv("REDIS_STREAM_CONSUMER", hostname)
+}
+
+// How long a Redis stream entry may stay pending before being claimed
+func RedisStreamClaimIdle() time.Duration {
+	value, err := time.ParseDuration(getEnv("REDIS_STREAM_CLAIM_IDLE", "1m"))
+
+	if err != nil {
+		return time.Minute
+	}
+
+	return value
+}
+
+// The event channel (redis/redis-streams/nats/rabbitmq/kafka/memory)
 func EventChannel() string {
 	return os.Getenv("EVENT_CHANNEL")
 }
 
+// The in-memory queue buffer size per subscriber
+func MemoryQueueBufferSize() int {
+	value, err := strconv.Atoi(getEnv("MEMORY_QUEUE_BUFFER_SIZE", "1024"))
+
+	if err != nil {
+		return 1024
+	}
+
+	return value
+}
+
+// The in-memory queue overflow policy (block/drop_oldest/drop_newest)
+func MemoryQueueOverflow() string {
+	return getEnv("MEMORY_QUEUE_OVERFLOW", "block")
+}
+
+// The spool directory used when the queue is unavailable, empty disables the spool
+func SpoolDir() string {
+	return os.Getenv("SPOOL_DIR")
+}
+
+// The maximum size of a spool segment file
+func SpoolSegmentBytes() int64 {
+	value, err := strconv.ParseInt(getEnv("SPOOL_SEGMENT_BYTES", "16777216"), 10, 64)
+
+	if err != nil {
+		return 16777216
+	}
+
+	return value
+}
+
+// The maximum size of the spool
+func SpoolMaxBytes() int64 {
+	value, err := strconv.ParseInt(getEnv("SPOOL_MAX_BYTES", "1073741824"), 10, 64)
+
+	if err != nil {
+		return 1073741824
+	}
+
+	return value
+}
+
+// How often the spool is replayed into the queue
+func SpoolDrainInterval() time.Duration {
+	value, err := time.ParseDuration(getEnv("SPOOL_DRAIN_INTERVAL", "5s"))
+
+	if err != nil {
+		return 5 * time.Second
+	}
+
+	return value
+}
+
+// The topic events go to after the publish retries are exhausted
+func DispatchDeadLetterTopic() string {
+	return getEnv("DISPATCH_DEAD_LETTER_TOPIC", "dlq.events")
+}
+
+// The total publish attempts of an event
+func DispatchRetryAttempts() int {
+	value, err := strconv.Atoi(getEnv("DISPATCH_RETRY_ATTEMPTS", "3"))
+
+	if err != nil {
+		return 3
+	}
+
+	return value
+}
+
+// The wait before the first publish retry
+func DispatchRetryBackoff() time.Duration {
+	value, err := time.ParseDuration(getEnv("DISPATCH_RETRY_BACKOFF", "100ms"))
+
+	if err != nil {
+		return 100 * time.Millisecond
+	}
+
+	return value
+}
+
+// The maximum wait between publish retries
+func DispatchRetryMaxBackoff() time.Duration {
+	value, err := time.ParseDuration(getEnv("DISPATCH_RETRY_MAX_BACKOFF", "2s"))
+
+	if err != nil {
+		return 2 * time.Second
+	}
+
+	return value
+}
+
+// The topics the worker consumes, empty means every topic the routing sends events to
+func WorkerTopics() []string {
+	value := getEnv("WORKER_TOPIC", "")
+
+	if value == "" {
+		return nil
+	}
+
+	return strings.Split(value, ",")
+}
+
+// The sinks the worker writes events to
+func WorkerSinks() []string {
+	return strings.Split(getEnv("WORKER_SINKS", "stdout"), ",")
+}
+
+// How many events the worker writes to the sinks at the same time
+func WorkerConcurrency() int {
+	value, err := strconv.Atoi(getEnv("WORKER_CONCURRENCY", "4"))
+
+	if err != nil {
+		return 4
+	}
+
+	return value
+}
+
+// The file the file sink appends events to
+func WorkerFilePath() string {
+	return getEnv("WORKER_FILE_PATH", "events.jsonl")
+}
+
+// The store the events are persisted to and queried from, empty disables it
+func EventStore() string {
+	return os.Getenv("EVENT_STORE")
+}
+
+// How long persisted events are kept, zero keeps them forever
+func EventRetention() time.Duration {
+	value, err := time.ParseDuration(getEnv("EVENT_RETENTION", "720h"))
+
+	if err != nil {
+		return 720 * time.Hour
+	}
+
+	return value
+}
+
+// The MongoDB connection url
+func MongoConnectionUrl() string {
+	return os.Getenv("MONGO_URL")
+}
+
+// The MongoDB database name
+func MongoDatabase() string {
+	return getEnv("MONGO_DATABASE", "bugs-channel")
+}
+
+// The PostgreSQL connection url
+func PostgresConnectionUrl() string {
+	return os.Getenv("POSTGRES_URL")
+}
+
+// The SQLite database file path
+func SQLitePath() string {
+	return getEnv("SQLITE_PATH", "bugs-channel.db")
+}
+
+// Indicate that the web application runs the worker in process
+func WorkerEmbedded() bool {
+	value, err := strconv.ParseBool(getEnv("WORKER_EMBEDDED", "false"))
+
+	if err != nil {
+		return false
+	}
+
+	return value
+}
+
+// The topic regressed issues are published to
+func IssueRegressedTopic() string {
+	return getEnv("ISSUE_REGRESSED_TOPIC", "issue.regressed")
+}
+
+// The Graylog input address of the services without a gelf setting, empty skips them
+func GelfAddress() string {
+	return os.Getenv("GELF_ADDRESS")
+}
+
+// The Graylog input protocol, udp or tcp
+func GelfProtocol() string {
+	return getEnv("GELF_PROTOCOL", "udp")
+}
+
+// The Elasticsearch or OpenSearch url
+func ElasticsearchUrl() string {
+	return getEnv("ELASTICSEARCH_URL", "http://localhost:9200")
+}
+
+// The Elasticsearch basic auth username, empty disables the basic auth
+func ElasticsearchUsername() string {
+	return os.Getenv("ELASTICSEARCH_USERNAME")
+}
+
+// The Elasticsearch basic auth password
+func ElasticsearchPassword() string {
+	return os.Getenv("ELASTICSEARCH_PASSWORD")
+}
+
+// The prefix of the daily indices and the index template name
+func ElasticsearchIndexPrefix() string {
+	return getEnv("ELASTICSEARCH_INDEX_PREFIX", "bugs-channel")
+}
+
+// How many events are sent per bulk request
+func ElasticsearchBatchSize() int {
+	value, err := strconv.Atoi(getEnv("ELASTICSEARCH_BATCH_SIZE", "500"))
+
+	if err != nil {
+		return 500
+	}
+
+	return value
+}
+
+// How long a batch of events waits for more events before being sent
+func ElasticsearchFlushInterval() time.Duration {
+	value, err := time.ParseDuration(getEnv("ELASTICSEARCH_FLUSH_INTERVAL", "0s"))
+
+	if err != nil {
+		return 0
+	}
+
+	return value
+}
+
+// The Loki url
+func LokiUrl() string {
+	return getEnv("LOKI_URL", "http://localhost:3100")
+}
+
+// The Loki tenant, sent as the X-Scope-OrgID header when set
+func LokiTenantId() string {
+	return os.Getenv("LOKI_TENANT_ID")
+}
+
+// The event fields allowed as Loki labels, each one multiplies the streams
+func LokiLabels() []string {
+	return strings.Split(getEnv("LOKI_LABELS", "service_id,platform,environment,level"), ",")
+}
+
+// How many events are sent per Loki push
+func LokiBatchSize() int {
+	value, err := strconv.Atoi(getEnv("LOKI_BATCH_SIZE", "500"))
+
+	if err != nil {
+		return 500
+	}
+
+	return value
+}
+
+// How long a batch of events waits for more events before being pushed to Loki
+func LokiFlushInterval() time.Duration {
+	value, err := time.ParseDuration(getEnv("LOKI_FLUSH_INTERVAL", "0s"))
+
+	if err != nil {
+		return 0
+	}
+
+	return value
+}
+
+// How long a webhook delivery may take
+func WebhookTimeout() time.Duration {
+	value, err := time.ParseDuration(getEnv("WEBHOOK_TIMEOUT", "10s"))
+
+	if err != nil {
+		return 10 * time.Second
+	}
+
+	return value
+}
+
 // The sensitive keys to hide from events
 func ScrubSensitiveKeys() []string {
 	return strings.Split(getEnv("SCRUB_SENSITIVE_KEYS", ""), ",")
 }
 
+func portEnv(key string, defaultValue string) int {
+	port, err := strconv.Atoi(getEnv(key, defaultValue))
+
+	if err != nil {
+		panic(errors.Join(ErrInvalidPort, err))
+	}
+
+	return port
+}
+
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
 	if len(value) == 0 {
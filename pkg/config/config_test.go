@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -31,6 +32,73 @@ func TestApiPort(t *testing.T) {
 	require.Equal(t, ApiPort(), 1000)
 }
 
+func TestHoneybadgerPort(t *testing.T) {
+	t.Setenv("HONEYBADGER_PORT", "")
+	require.Equal(t, HoneybadgerPort(), 4002)
+
+	t.Setenv("HONEYBADGER_PORT", "1000")
+	require.Equal(t, HoneybadgerPort(), 1000)
+
+	t.Setenv("HONEYBADGER_PORT", "foo")
+	require.Panics(t, func() { HoneybadgerPort() })
+}
+
+func TestRollbarPort(t *testing.T) {
+	t.Setenv("ROLLBAR_PORT", "")
+	require.Equal(t, RollbarPort(), 4003)
+
+	t.Setenv("ROLLBAR_PORT", "1000")
+	require.Equal(t, RollbarPort(), 1000)
+}
+
+func TestBugsnagPort(t *testing.T) {
+	t.Setenv("BUGSNAG_PORT", "")
+	require.Equal(t, BugsnagPort(), 4004)
+
+	t.Setenv("BUGSNAG_PORT", "1000")
+	require.Equal(t, BugsnagPort(), 1000)
+}
+
+func TestAirbrakePort(t *testing.T) {
+	t.Setenv("AIRBRAKE_PORT", "")
+	require.Equal(t, AirbrakePort(), 4005)
+
+	t.Setenv("AIRBRAKE_PORT", "1000")
+	require.Equal(t, AirbrakePort(), 1000)
+}
+
+func TestOtlpHttpPort(t *testing.T) {
+	t.Setenv("OTLP_HTTP_PORT", "")
+	require.Equal(t, OtlpHttpPort(), 4318)
+
+	t.Setenv("OTLP_HTTP_PORT", "1000")
+	require.Equal(t, OtlpHttpPort(), 1000)
+}
+
+func TestOtlpGrpcPort(t *testing.T) {
+	t.Setenv("OTLP_GRPC_PORT", "")
+	require.Equal(t, OtlpGrpcPort(), 4317)
+
+	t.Setenv("OTLP_GRPC_PORT", "1000")
+	require.Equal(t, OtlpGrpcPort(), 1000)
+}
+
+func TestOtlpMaxBodyBytes(t *testing.T) {
+	t.Setenv("OTLP_MAX_BODY_BYTES", "")
+	require.Equal(t, OtlpMaxBodyBytes(), int64(8388608))
+
+	t.Setenv("OTLP_MAX_BODY_BYTES", "1024")
+	require.Equal(t, OtlpMaxBodyBytes(), int64(1024))
+}
+
+func TestGrpcPort(t *testing.T) {
+	t.Setenv("GRPC_PORT", "")
+	require.Equal(t, GrpcPort(), 4006)
+
+	t.Setenv("GRPC_PORT", "1000")
+	require.Equal(t, GrpcPort(), 1000)
+}
+
 func TestConfigFille(t *testing.T) {
 	t.Setenv("CONFIG_FILE", "/tmp/config.yml")
 	require.Equal(t, ConfigFile(), "/tmp/config.yml")
@@ -46,15 +114,358 @@ func TestNatsConnectionUrl(t *testing.T) {
 	require.Equal(t, NatsConnectionUrl(), "nats://localhost")
 }
 
+func TestNatsJetStream(t *testing.T) {
+	t.Setenv("NATS_JETSTREAM", "true")
+	require.Equal(t, NatsJetStream(), true)
+
+	t.Setenv("NATS_JETSTREAM", "")
+	require.Equal(t, NatsJetStream(), false)
+}
+
+func TestNatsStream(t *testing.T) {
+	t.Setenv("NATS_STREAM", "")
+	require.Equal(t, NatsStream(), "EVENTS")
+	require.Equal(t, NatsStreamSubjects(), []string{"events", "events.>"})
+	require.Equal(t, NatsDurable(), "bugs-channel")
+
+	t.Setenv("NATS_STREAM", "BUGS")
+	t.Setenv("NATS_STREAM_SUBJECTS", "bugs.>")
+	t.Setenv("NATS_DURABLE", "worker")
+	require.Equal(t, NatsStream(), "BUGS")
+	require.Equal(t, NatsStreamSubjects(), []string{"bugs.>"})
+	require.Equal(t, NatsDurable(), "worker")
+}
+
+func TestNatsRedelivery(t *testing.T) {
+	t.Setenv("NATS_ACK_WAIT", "")
+	t.Setenv("NATS_MAX_DELIVER", "")
+	require.Equal(t, NatsAckWait(), 30*time.Second)
+	require.Equal(t, NatsMaxDeliver(), 5)
+
+	t.Setenv("NATS_ACK_WAIT", "1m")
+	t.Setenv("NATS_MAX_DELIVER", "10")
+	require.Equal(t, NatsAckWait(), time.Minute)
+	require.Equal(t, NatsMaxDeliver(), 10)
+}
+
 func TestRedisConnectionUrl(t *testing.T) {
 	t.Setenv("REDIS_URL", "redis://localhost")
 	require.Equal(t, RedisConnectionUrl(), "redis://localhost")
 }
 
+func TestRabbitMQ(t *testing.T) {
+	t.Setenv("RABBITMQ_URL", "amqp://localhost")
+	t.Setenv("RABBITMQ_EXCHANGE", "")
+	t.Setenv("RABBITMQ_QUEUE", "")
+	t.Setenv("RABBITMQ_PREFETCH", "")
+	t.Setenv("RABBITMQ_TOPICS", "")
+	require.Equal(t, RabbitMQConnectionUrl(), "amqp://localhost")
+	require.Equal(t, RabbitMQExchange(), "bugs-channel")
+	require.Equal(t, RabbitMQQueue(), "bugs-channel")
+	require.Equal(t, RabbitMQPrefetch(), 10)
+	require.Equal(t, RabbitMQTopics(), []string{"events"})
+
+	t.Setenv("RABBITMQ_EXCHANGE", "bugs")
+	t.Setenv("RABBITMQ_QUEUE", "workers")
+	t.Setenv("RABBITMQ_PREFETCH", "50")
+	t.Setenv("RABBITMQ_TOPICS", "events,events.foo.*.*")
+	require.Equal(t, RabbitMQExchange(), "bugs")
+	require.Equal(t, RabbitMQQueue(), "workers")
+	require.Equal(t, RabbitMQPrefetch(), 50)
+	require.Equal(t, RabbitMQTopics(), []string{"events", "events.foo.*.*"})
+}
+
+func TestKafka(t *testing.T) {
+	t.Setenv("KAFKA_BROKERS", "")
+	t.Setenv("KAFKA_GROUP_ID", "")
+	t.Setenv("KAFKA_TLS", "")
+	t.Setenv("KAFKA_MAX_ATTEMPTS", "")
+	t.Setenv("KAFKA_DEAD_LETTER_TOPIC", "")
+	require.Equal(t, KafkaBrokers(), []string{"localhost:9092"})
+	require.Equal(t, KafkaGroupId(), "bugs-channel")
+	require.Equal(t, KafkaTLS(), false)
+	require.Equal(t, KafkaMaxAttempts(), 5)
+	require.Equal(t, KafkaDeadLetterTopic(), "events.consumer.dlq")
+
+	t.Setenv("KAFKA_BROKERS", "foo:9092,bar:9092")
+	t.Setenv("KAFKA_GROUP_ID", "workers")
+	t.Setenv("KAFKA_SASL_MECHANISM", "plain")
+	t.Setenv("KAFKA_USERNAME", "foo")
+	t.Setenv("KAFKA_PASSWORD", "bar")
+	t.Setenv("KAFKA_TLS", "true")
+	t.Setenv("KAFKA_MAX_ATTEMPTS", "3")
+	t.Setenv("KAFKA_DEAD_LETTER_TOPIC", "events.failed")
+	require.Equal(t, KafkaBrokers(), []string{"foo:9092", "bar:9092"})
+	require.Equal(t, KafkaGroupId(), "workers")
+	require.Equal(t, KafkaSASLMechanism(), "plain")
+	require.Equal(t, KafkaUsername(), "foo")
+	require.Equal(t, KafkaPassword(), "bar")
+	require.Equal(t, KafkaTLS(), true)
+	require.Equal(t, KafkaMaxAttempts(), 3)
+	require.Equal(t, KafkaDeadLetterTopic(), "events.failed")
+}
+
+func TestRedisStreams(t *testing.T) {
+	t.Setenv("REDIS_STREAM_MAXLEN", "")
+	t.Setenv("REDIS_STREAM_GROUP", "")
+	t.Setenv("REDIS_STREAM_CLAIM_IDLE", "")
+	require.Equal(t, RedisStreamMaxLen(), int64(100000))
+	require.Equal(t, RedisStreamGroup(), "bugs-channel")
+	require.Equal(t, RedisStreamClaimIdle(), time.Minute)
+
+	t.Setenv("REDIS_STREAM_MAXLEN", "10")
+	t.Setenv("REDIS_STREAM_GROUP", "workers")
+	t.Setenv("REDIS_STREAM_CONSUMER", "worker-1")
+	t.Setenv("REDIS_STREAM_CLAIM_IDLE", "5m")
+	require.Equal(t, RedisStreamMaxLen(), int64(10))
+	require.Equal(t, RedisStreamGroup(), "workers")
+	require.Equal(t, RedisStreamConsumer(), "worker-1")
+	require.Equal(t, RedisStreamClaimIdle(), 5*time.Minute)
+}
+
 func TestEventChannel(t *testing.T) {
 	t.Setenv("EVENT_CHANNEL", "nats_or_redis")
 	require.Equal(t, EventChannel(), "nats_or_redis")
 }
+
+func TestMemoryQueueBufferSize(t *testing.T) {
+	t.Setenv("MEMORY_QUEUE_BUFFER_SIZE", "")
+	require.Equal(t, MemoryQueueBufferSize(), 1024)
+
+	t.Setenv("MEMORY_QUEUE_BUFFER_SIZE", "8")
+	require.Equal(t, MemoryQueueBufferSize(), 8)
+}
+
+func TestMemoryQueueOverflow(t *testing.T) {
+	t.Setenv("MEMORY_QUEUE_OVERFLOW", "")
+	require.Equal(t, MemoryQueueOverflow(), "block")
+
+	t.Setenv("MEMORY_QUEUE_OVERFLOW", "drop_oldest")
+	require.Equal(t, MemoryQueueOverflow(), "drop_oldest")
+}
+
+func TestSpool(t *testing.T) {
+	t.Setenv("SPOOL_DIR", "")
+	t.Setenv("SPOOL_SEGMENT_BYTES", "")
+	t.Setenv("SPOOL_MAX_BYTES", "")
+	t.Setenv("SPOOL_DRAIN_INTERVAL", "")
+	require.Equal(t, SpoolDir(), "")
+	require.Equal(t, SpoolSegmentBytes(), int64(16777216))
+	require.Equal(t, SpoolMaxBytes(), int64(1073741824))
+	require.Equal(t, SpoolDrainInterval(), 5*time.Second)
+
+	t.Setenv("SPOOL_DIR", "/tmp/spool")
+	t.Setenv("SPOOL_SEGMENT_BYTES", "1024")
+	t.Setenv("SPOOL_MAX_BYTES", "4096")
+	t.Setenv("SPOOL_DRAIN_INTERVAL", "1s")
+	require.Equal(t, SpoolDir(), "/tmp/spool")
+	require.Equal(t, SpoolSegmentBytes(), int64(1024))
+	require.Equal(t, SpoolMaxBytes(), int64(4096))
+	require.Equal(t, SpoolDrainInterval(), time.Second)
+}
+
+func TestDispatchRetry(t *testing.T) {
+	t.Setenv("DISPATCH_DEAD_LETTER_TOPIC", "")
+	t.Setenv("DISPATCH_RETRY_ATTEMPTS", "")
+	t.Setenv("DISPATCH_RETRY_BACKOFF", "")
+	t.Setenv("DISPATCH_RETRY_MAX_BACKOFF", "")
+	require.Equal(t, DispatchDeadLetterTopic(), "dlq.events")
+	require.Equal(t, DispatchRetryAttempts(), 3)
+	require.Equal(t, DispatchRetryBackoff(), 100*time.Millisecond)
+	require.Equal(t, DispatchRetryMaxBackoff(), 2*time.Second)
+
+	t.Setenv("DISPATCH_DEAD_LETTER_TOPIC", "dead")
+	t.Setenv("DISPATCH_RETRY_ATTEMPTS", "5")
+	t.Setenv("DISPATCH_RETRY_BACKOFF", "1s")
+	t.Setenv("DISPATCH_RETRY_MAX_BACKOFF", "10s")
+	require.Equal(t, DispatchDeadLetterTopic(), "dead")
+	require.Equal(t, DispatchRetryAttempts(), 5)
+	require.Equal(t, DispatchRetryBackoff(), time.Second)
+	require.Equal(t, DispatchRetryMaxBackoff(), 10*time.Second)
+}
+
+func TestWorker(t *testing.T) {
+	t.Setenv("WORKER_TOPIC", "")
+	t.Setenv("WORKER_SINKS", "")
+	t.Setenv("WORKER_CONCURRENCY", "")
+	t.Setenv("WORKER_FILE_PATH", "")
+	require.Nil(t, WorkerTopics())
+	require.Equal(t, WorkerSinks(), []string{"stdout"})
+	require.Equal(t, WorkerConcurrency(), 4)
+	require.Equal(t, WorkerFilePath(), "events.jsonl")
+
+	t.Setenv("WORKER_TOPIC", "events,events.>")
+	t.Setenv("WORKER_SINKS", "stdout,file")
+	t.Setenv("WORKER_CONCURRENCY", "8")
+	t.Setenv("WORKER_FILE_PATH", "/tmp/events.jsonl")
+	require.Equal(t, WorkerTopics(), []string{"events", "events.>"})
+	require.Equal(t, WorkerSinks(), []string{"stdout", "file"})
+	require.Equal(t, WorkerConcurrency(), 8)
+	require.Equal(t, WorkerFilePath(), "/tmp/events.jsonl")
+}
+
+func TestEventStore(t *testing.T) {
+	t.Setenv("EVENT_STORE", "")
+	t.Setenv("EVENT_RETENTION", "")
+	t.Setenv("MONGO_URL", "")
+	t.Setenv("MONGO_DATABASE", "")
+	require.Equal(t, EventStore(), "")
+	require.Equal(t, EventRetention(), 720*time.Hour)
+	require.Equal(t, MongoConnectionUrl(), "")
+	require.Equal(t, MongoDatabase(), "bugs-channel")
+
+	t.Setenv("EVENT_STORE", "mongo")
+	t.Setenv("EVENT_RETENTION", "24h")
+	t.Setenv("MONGO_URL", "mongodb://localhost:27017")
+	t.Setenv("MONGO_DATABASE", "foo")
+	require.Equal(t, EventStore(), "mongo")
+	require.Equal(t, EventRetention(), 24*time.Hour)
+	require.Equal(t, MongoConnectionUrl(), "mongodb://localhost:27017")
+	require.Equal(t, MongoDatabase(), "foo")
+}
+
+func TestPostgresConnectionUrl(t *testing.T) {
+	t.Setenv("POSTGRES_URL", "postgres://localhost:5432/bugs-channel")
+	require.Equal(t, PostgresConnectionUrl(), "postgres://localhost:5432/bugs-channel")
+}
+
+func TestSQLitePath(t *testing.T) {
+	t.Setenv("SQLITE_PATH", "")
+	require.Equal(t, SQLitePath(), "bugs-channel.db")
+
+	t.Setenv("SQLITE_PATH", "/tmp/foo.db")
+	require.Equal(t, SQLitePath(), "/tmp/foo.db")
+}
+
+func TestWorkerEmbedded(t *testing.T) {
+	t.Setenv("WORKER_EMBEDDED", "")
+	require.False(t, WorkerEmbedded())
+
+	t.Setenv("WORKER_EMBEDDED", "true")
+	require.True(t, WorkerEmbedded())
+}
+
+func TestIssueRegressedTopic(t *testing.T) {
+	t.Setenv("ISSUE_REGRESSED_TOPIC", "")
+	require.Equal(t, IssueRegressedTopic(), "issue.regressed")
+
+	t.Setenv("ISSUE_REGRESSED_TOPIC", "issues")
+	require.Equal(t, IssueRegressedTopic(), "issues")
+}
+
+func TestGelfAddress(t *testing.T) {
+	t.Setenv("GELF_ADDRESS", "")
+	require.Equal(t, GelfAddress(), "")
+
+	t.Setenv("GELF_ADDRESS", "localhost:12201")
+	require.Equal(t, GelfAddress(), "localhost:12201")
+}
+
+func TestGelfProtocol(t *testing.T) {
+	t.Setenv("GELF_PROTOCOL", "")
+	require.Equal(t, GelfProtocol(), "udp")
+
+	t.Setenv("GELF_PROTOCOL", "tcp")
+	require.Equal(t, GelfProtocol(), "tcp")
+}
+
+func TestElasticsearchUrl(t *testing.T) {
+	t.Setenv("ELASTICSEARCH_URL", "")
+	require.Equal(t, ElasticsearchUrl(), "http://localhost:9200")
+
+	t.Setenv("ELASTICSEARCH_URL", "http://elastic:9200")
+	require.Equal(t, ElasticsearchUrl(), "http://elastic:9200")
+}
+
+func TestElasticsearchCredentials(t *testing.T) {
+	t.Setenv("ELASTICSEARCH_USERNAME", "foo")
+	t.Setenv("ELASTICSEARCH_PASSWORD", "bar")
+
+	require.Equal(t, ElasticsearchUsername(), "foo")
+	require.Equal(t, ElasticsearchPassword(), "bar")
+}
+
+func TestElasticsearchIndexPrefix(t *testing.T) {
+	t.Setenv("ELASTICSEARCH_INDEX_PREFIX", "")
+	require.Equal(t, ElasticsearchIndexPrefix(), "bugs-channel")
+
+	t.Setenv("ELASTICSEARCH_INDEX_PREFIX", "errors")
+	require.Equal(t, ElasticsearchIndexPrefix(), "errors")
+}
+
+func TestElasticsearchBatchSize(t *testing.T) {
+	t.Setenv("ELASTICSEARCH_BATCH_SIZE", "")
+	require.Equal(t, ElasticsearchBatchSize(), 500)
+
+	t.Setenv("ELASTICSEARCH_BATCH_SIZE", "foo")
+	require.Equal(t, ElasticsearchBatchSize(), 500)
+
+	t.Setenv("ELASTICSEARCH_BATCH_SIZE", "100")
+	require.Equal(t, ElasticsearchBatchSize(), 100)
+}
+
+func TestElasticsearchFlushInterval(t *testing.T) {
+	t.Setenv("ELASTICSEARCH_FLUSH_INTERVAL", "")
+	require.Equal(t, ElasticsearchFlushInterval(), time.Duration(0))
+
+	t.Setenv("ELASTICSEARCH_FLUSH_INTERVAL", "foo")
+	require.Equal(t, ElasticsearchFlushInterval(), time.Duration(0))
+
+	t.Setenv("ELASTICSEARCH_FLUSH_INTERVAL", "5s")
+	require.Equal(t, ElasticsearchFlushInterval(), 5*time.Second)
+}
+
+func TestLokiUrl(t *testing.T) {
+	t.Setenv("LOKI_URL", "")
+	require.Equal(t, LokiUrl(), "http://localhost:3100")
+
+	t.Setenv("LOKI_URL", "http://loki:3100")
+	require.Equal(t, LokiUrl(), "http://loki:3100")
+}
+
+func TestLokiTenantId(t *testing.T) {
+	t.Setenv("LOKI_TENANT_ID", "foo")
+	require.Equal(t, LokiTenantId(), "foo")
+}
+
+func TestLokiLabels(t *testing.T) {
+	t.Setenv("LOKI_LABELS", "")
+	require.Equal(t, LokiLabels(), []string{"service_id", "platform", "environment", "level"})
+
+	t.Setenv("LOKI_LABELS", "service_id")
+	require.Equal(t, LokiLabels(), []string{"service_id"})
+}
+
+func TestLokiBatchSize(t *testing.T) {
+	t.Setenv("LOKI_BATCH_SIZE", "")
+	require.Equal(t, LokiBatchSize(), 500)
+
+	t.Setenv("LOKI_BATCH_SIZE", "foo")
+	require.Equal(t, LokiBatchSize(), 500)
+
+	t.Setenv("LOKI_BATCH_SIZE", "100")
+	require.Equal(t, LokiBatchSize(), 100)
+}
+
+func TestLokiFlushInterval(t *testing.T) {
+	t.Setenv("LOKI_FLUSH_INTERVAL", "")
+	require.Equal(t, LokiFlushInterval(), time.Duration(0))
+
+	t.Setenv("LOKI_FLUSH_INTERVAL", "5s")
+	require.Equal(t, LokiFlushInterval(), 5*time.Second)
+}
+
+func TestWebhookTimeout(t *testing.T) {
+	t.Setenv("WEBHOOK_TIMEOUT", "")
+	require.Equal(t, WebhookTimeout(), 10*time.Second)
+
+	t.Setenv("WEBHOOK_TIMEOUT", "foo")
+	require.Equal(t, WebhookTimeout(), 10*time.Second)
+
+	t.Setenv("WEBHOOK_TIMEOUT", "1s")
+	require.Equal(t, WebhookTimeout(), time.Second)
+}
+
 func TestScrubSensitiveKeys(t *testing.T) {
 	t.Setenv("SCRUB_SENSITIVE_KEYS", "foo,bar")
 	require.Equal(t, ScrubSensitiveKeys(), []string{"foo", "bar"})
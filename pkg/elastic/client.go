@@ -0,0 +1,166 @@
+// This package bulk indexes events into Elasticsearch or OpenSearch daily indices
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Represents an error when Elasticsearch answers a request with an unexpected status
+var ErrRequest = errors.New("the Elasticsearch request failed")
+
+// Represents a bulk action of a document
+type BulkItem struct {
+	// The index name
+	Index string
+	// The document id, a document is created once per id
+	ID string
+	// The document body
+	Document any
+}
+
+// Represents the bulk result of an item
+type BulkResult struct {
+	Index  string     `json:"_index"`
+	ID     string     `json:"_id"`
+	Status int        `json:"status"`
+	Error  *BulkError `json:"error,omitempty"`
+}
+
+// Represents the bulk error of an item
+type BulkError struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// Indicate that the item is indexed, a conflict means the document was already created
+func (r BulkResult) Indexed() bool {
+	return (r.Status >= 200 && r.Status < 300) || r.Status == http.StatusConflict
+}
+
+// Indicate that the item may be indexed by a later attempt
+func (r BulkResult) Retryable() bool {
+	return r.Status == http.StatusTooManyRequests || r.Status >= 500
+}
+
+// Returns the error type and reason
+func (r BulkResult) Reason() string {
+	if r.Error == nil {
+		return fmt.Sprintf("status %v", r.Status)
+	}
+
+	return fmt.Sprintf("%v: %v", r.Error.Type, r.Error.Reason)
+}
+
+type bulkResponse struct {
+	Errors bool                    `json:"errors"`
+	Items  []map[string]BulkResult `json:"items"`
+}
+
+// The Elasticsearch HTTP client
+type Client struct {
+	url      string
+	username string
+	password string
+	http     *http.Client
+}
+
+// Create or replace the index template
+func (c *Client) PutIndexTemplate(ctx context.Context, name string, template any) error {
+	body, err := json.Marshal(template)
+
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, http.MethodPut, "/_index_template/"+name, "application/json", body, nil)
+}
+
+// Create the documents, returning the result of each item in order
+func (c *Client) Bulk(ctx context.Context, items []BulkItem) ([]BulkResult, error) {
+	var body bytes.Buffer
+
+	encoder := json.NewEncoder(&body)
+
+	for _, item := range items {
+		action := map[string]any{"create": map[string]string{"_index": item.Index, "_id": item.ID}}
+
+		if err := encoder.Encode(action); err != nil {
+			return nil, err
+		}
+
+		if err := encoder.Encode(item.Document); err != nil {
+			return nil, err
+		}
+	}
+
+	var res bulkResponse
+
+	if err := c.do(ctx, http.MethodPost, "/_bulk", "application/x-ndjson", body.Bytes(), &res); err != nil {
+		return nil, err
+	}
+
+	if len(res.Items) != len(items) {
+		return nil, fmt.Errorf("%w: %v results for %v items", ErrRequest, len(res.Items), len(items))
+	}
+
+	results := make([]BulkResult, len(items))
+
+	for i, item := range res.Items {
+		for _, result := range item {
+			results[i] = result
+		}
+	}
+
+	return results, nil
+}
+
+func (c *Client) do(ctx context.Context, method string, path string, contentType string, body []byte, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.url+path, bytes.NewReader(body))
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	res, err := c.http.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		reason, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("%w: %v %v: %v", ErrRequest, method, path, strings.TrimSpace(string(reason)))
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+// Build a new client of the Elasticsearch url, the basic auth is used when the username is set
+func NewClient(url string, username string, password string) *Client {
+	return &Client{
+		url:      strings.TrimRight(url, "/"),
+		username: username,
+		password: password,
+		http:     &http.Client{Timeout: 30 * time.Second},
+	}
+}
@@ -0,0 +1,144 @@
+package elastic
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulk(t *testing.T) {
+	es := newMockElasticsearch(t)
+	es.statuses = [][]int{{201, 409, 429, 400}}
+
+	client := NewClient(es.URL+"/", "foo", "bar")
+
+	items := []BulkItem{
+		{Index: "bugs-channel-2024.01.02", ID: "a", Document: map[string]string{"id": "a"}},
+		{Index: "bugs-channel-2024.01.02", ID: "b", Document: map[string]string{"id": "b"}},
+		{Index: "bugs-channel-2024.01.02", ID: "c", Document: map[string]string{"id": "c"}},
+		{Index: "bugs-channel-2024.01.02", ID: "d", Document: map[string]string{"id": "d"}},
+	}
+
+	results, err := client.Bulk(context.Background(), items)
+
+	require.Nil(t, err)
+	require.Len(t, results, 4)
+
+	assert.True(t, results[0].Indexed())
+	assert.True(t, results[1].Indexed())
+	assert.True(t, results[2].Retryable())
+	assert.False(t, results[3].Indexed())
+	assert.False(t, results[3].Retryable())
+	assert.Equal(t, "mapper_parsing_exception: failed to parse", results[3].Reason())
+
+	assert.Equal(t, [][]string{{"a", "b", "c", "d"}}, es.bulks)
+	assert.Equal(t, "foo", es.username)
+}
+
+func TestBulkRequestError(t *testing.T) {
+	es := newMockElasticsearch(t)
+	es.failures = 1
+
+	_, err := NewClient(es.URL, "", "").Bulk(context.Background(), []BulkItem{{Index: "foo", ID: "a"}})
+
+	require.ErrorIs(t, err, ErrRequest)
+}
+
+func TestPutIndexTemplate(t *testing.T) {
+	es := newMockElasticsearch(t)
+
+	require.Nil(t, NewClient(es.URL, "", "").PutIndexTemplate(context.Background(), "bugs-channel", IndexTemplate("bugs-channel")))
+
+	require.Contains(t, es.templates, "bugs-channel")
+	assert.Equal(t, []any{"bugs-channel-*"}, es.templates["bugs-channel"]["index_patterns"])
+}
+
+// Emulates the bulk and index template APIs, answering the bulk items with the queued statuses
+type mockElasticsearch struct {
+	*httptest.Server
+	mu        sync.Mutex
+	statuses  [][]int
+	failures  int
+	bulks     [][]string
+	templates map[string]map[string]any
+	username  string
+}
+
+func newMockElasticsearch(t *testing.T) *mockElasticsearch {
+	es := &mockElasticsearch{templates: map[string]map[string]any{}}
+	es.Server = httptest.NewServer(http.HandlerFunc(es.handle))
+
+	t.Cleanup(es.Close)
+
+	return es
+}
+
+func (es *mockElasticsearch) handle(w http.ResponseWriter, req *http.Request) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	es.username, _, _ = req.BasicAuth()
+
+	if es.failures > 0 {
+		es.failures--
+		http.Error(w, `{"error": "unavailable"}`, http.StatusServiceUnavailable)
+		return
+	}
+
+	if req.Method == http.MethodPut {
+		var template map[string]any
+
+		json.NewDecoder(req.Body).Decode(&template)
+		es.templates[req.URL.Path[len("/_index_template/"):]] = template
+
+		w.Write([]byte(`{"acknowledged": true}`))
+		return
+	}
+
+	var ids []string
+
+	scanner := bufio.NewScanner(req.Body)
+
+	for scanner.Scan() {
+		var action map[string]map[string]string
+
+		json.Unmarshal(scanner.Bytes(), &action)
+		ids = append(ids, action["create"]["_id"])
+
+		// skips the document line
+		scanner.Scan()
+	}
+
+	es.bulks = append(es.bulks, ids)
+
+	var statuses []int
+
+	if len(es.statuses) > 0 {
+		statuses, es.statuses = es.statuses[0], es.statuses[1:]
+	}
+
+	items := make([]map[string]BulkResult, len(ids))
+
+	for i, id := range ids {
+		result := BulkResult{ID: id, Status: http.StatusCreated}
+
+		if i < len(statuses) {
+			result.Status = statuses[i]
+		}
+
+		if result.Status == http.StatusBadRequest {
+			result.Error = &BulkError{Type: "mapper_parsing_exception", Reason: "failed to parse"}
+		}
+
+		items[i] = map[string]BulkResult{"create": result}
+	}
+
+	json.NewEncoder(w).Encode(bulkResponse{Errors: len(statuses) > 0, Items: items})
+}
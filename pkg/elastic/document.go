@@ -0,0 +1,78 @@
+package elastic
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/fingerprint"
+)
+
+//go:embed mappings.json
+var mappings json.RawMessage
+
+// The daily index suffix layout, e.g. bugs-channel-2024.01.02
+const indexLayout = "2006.01.02"
+
+// Represents the indexed event, the stack trace and extra are stored but not searchable
+type Document struct {
+	Timestamp   time.Time        `json:"@timestamp"`
+	ID          string           `json:"id"`
+	ServiceId   string           `json:"service_id"`
+	Platform    string           `json:"platform,omitempty"`
+	Environment string           `json:"environment,omitempty"`
+	Release     string           `json:"release,omitempty"`
+	ServerName  string           `json:"server_name,omitempty"`
+	Kind        string           `json:"kind,omitempty"`
+	Level       string           `json:"level,omitempty"`
+	Fingerprint string           `json:"fingerprint,omitempty"`
+	Tags        []string         `json:"tags,omitempty"`
+	Title       string           `json:"title,omitempty"`
+	Body        string           `json:"body,omitempty"`
+	StackTrace  event.StackTrace `json:"stack_trace,omitempty"`
+	Extra       event.EventExtra `json:"extra,omitempty"`
+}
+
+// Build the document of the event received at the timestamp
+func NewDocument(e event.Event, timestamp time.Time) Document {
+	return Document{
+		Timestamp:   timestamp.UTC(),
+		ID:          e.ID,
+		ServiceId:   e.ServiceId,
+		Platform:    e.Platform,
+		Environment: e.Environment,
+		Release:     e.Release,
+		ServerName:  e.ServerName,
+		Kind:        e.Kind,
+		Level:       e.Level,
+		Fingerprint: fingerprint.FromTags(e.Tags),
+		Tags:        e.Tags,
+		Title:       e.Title,
+		Body:        e.Body,
+		StackTrace:  e.StackTrace,
+		Extra:       e.Extra,
+	}
+}
+
+// Returns the document id of the event, event ids are unique per service
+func DocumentID(e event.Event) string {
+	return e.ServiceId + ":" + e.ID
+}
+
+// Returns the daily index of the timestamp, e.g. bugs-channel-2024.01.02
+func IndexName(prefix string, timestamp time.Time) string {
+	return fmt.Sprintf("%v-%v", prefix, timestamp.UTC().Format(indexLayout))
+}
+
+// Returns the index template matching the daily indices of the prefix
+func IndexTemplate(prefix string) map[string]any {
+	return map[string]any{
+		"index_patterns": []string{prefix + "-*"},
+		"priority":       100,
+		"template": map[string]any{
+			"mappings": mappings,
+		},
+	}
+}
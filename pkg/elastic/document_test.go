@@ -0,0 +1,68 @@
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+)
+
+func TestNewDocument(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("BRT", -3*60*60))
+
+	doc := NewDocument(event.Event{ID: "foo", ServiceId: "1", Platform: "python", Tags: []string{"fingerprint:abc"}}, now)
+
+	assert.Equal(t, "abc", doc.Fingerprint)
+	assert.Equal(t, time.UTC, doc.Timestamp.Location())
+
+	body, err := json.Marshal(doc)
+
+	require.Nil(t, err)
+	assert.JSONEq(t, `{
+		"@timestamp": "2024-01-02T06:04:05Z",
+		"id": "foo",
+		"service_id": "1",
+		"platform": "python",
+		"fingerprint": "abc",
+		"tags": ["fingerprint:abc"]
+	}`, string(body))
+}
+
+func TestDocumentID(t *testing.T) {
+	assert.Equal(t, "1:foo", DocumentID(event.Event{ID: "foo", ServiceId: "1"}))
+}
+
+func TestIndexName(t *testing.T) {
+	now := time.Date(2024, 1, 2, 23, 0, 0, 0, time.FixedZone("BRT", -3*60*60))
+
+	assert.Equal(t, "bugs-channel-2024.01.03", IndexName("bugs-channel", now))
+}
+
+func TestIndexTemplate(t *testing.T) {
+	body, err := json.Marshal(IndexTemplate("foo"))
+
+	require.Nil(t, err)
+
+	var template struct {
+		IndexPatterns []string `json:"index_patterns"`
+		Template      struct {
+			Mappings struct {
+				Properties map[string]map[string]any `json:"properties"`
+			} `json:"mappings"`
+		} `json:"template"`
+	}
+
+	require.Nil(t, json.Unmarshal(body, &template))
+
+	assert.Equal(t, []string{"foo-*"}, template.IndexPatterns)
+
+	properties := template.Template.Mappings.Properties
+
+	assert.Equal(t, "date", properties["@timestamp"]["type"])
+	assert.Equal(t, "keyword", properties["tags"]["type"])
+	assert.Equal(t, "keyword", properties["platform"]["type"])
+	assert.Equal(t, "keyword", properties["service_id"]["type"])
+}
@@ -0,0 +1,109 @@
+package elastic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/williampsena/bugs-channel/pkg/batch"
+	"github.com/williampsena/bugs-channel/pkg/event"
+)
+
+// Represents an error when bulk items are still failing after the retries
+var ErrBulkItems = errors.New("the bulk items could not be indexed")
+
+// Represents the bulk indexer batching and retry policy
+type IndexerSettings struct {
+	// How many items are sent per bulk request
+	BatchSize int
+	// How long a bulk request waits for more items before being sent
+	FlushInterval time.Duration
+	// The retry policy of the failed requests and retryable items
+	Retry event.RetryPolicy
+}
+
+// Batches documents into bulk requests, retrying the items rejected with a retryable status
+type BulkIndexer struct {
+	client   *Client
+	settings IndexerSettings
+	batcher  *batch.Batcher[BulkItem]
+}
+
+// Adds the item to the next bulk request, returning once it is sent with the error of the item
+func (b *BulkIndexer) Add(ctx context.Context, item BulkItem) error {
+	return b.batcher.Add(ctx, item)
+}
+
+// Sends the items, retrying the failed request and the retryable items, and returns the error of each item
+func (b *BulkIndexer) Flush(ctx context.Context, items []BulkItem) []error {
+	errs := make([]error, len(items))
+
+	// the positions of the items left to send
+	remaining := make([]int, len(items))
+
+	for i := range items {
+		remaining[i] = i
+	}
+
+	attempts, err := b.settings.Retry.Do(ctx, func() error {
+		pending := make([]BulkItem, len(remaining))
+
+		for i, index := range remaining {
+			pending[i] = items[index]
+		}
+
+		results, err := b.client.Bulk(ctx, pending)
+
+		if err != nil {
+			return err
+		}
+
+		var retry []int
+
+		for i, result := range results {
+			switch {
+			case result.Indexed():
+			case result.Retryable():
+				retry = append(retry, remaining[i])
+			default:
+				errs[remaining[i]] = fmt.Errorf("%w: %v: %v", ErrBulkItems, result.ID, result.Reason())
+				log.Errorf("⛔ Elasticsearch rejected the event %v: %v", result.ID, result.Reason())
+			}
+		}
+
+		remaining = retry
+
+		if len(retry) > 0 {
+			return fmt.Errorf("%w: %v retryable items", ErrBulkItems, len(retry))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		log.Errorf("⛔ %v events could not be indexed after %v attempts: %v", len(remaining), attempts, err)
+
+		for _, index := range remaining {
+			errs[index] = err
+		}
+	}
+
+	return errs
+}
+
+// Sends the pending items and stops
+func (b *BulkIndexer) Close() error {
+	b.batcher.Close()
+	return nil
+}
+
+// Build a new bulk indexer, by default it sends up to 500 items per bulk request
+func NewBulkIndexer(client *Client, settings IndexerSettings) *BulkIndexer {
+	b := &BulkIndexer{client: client, settings: settings}
+
+	b.batcher = batch.NewBatcher(b.Flush, batch.Settings{Size: settings.BatchSize, Linger: settings.FlushInterval})
+
+	return b
+}
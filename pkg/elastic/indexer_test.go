@@ -0,0 +1,100 @@
+package elastic
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel/pkg/batch"
+	"github.com/williampsena/bugs-channel/pkg/event"
+)
+
+var retry = event.RetryPolicy{Attempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+func TestBulkIndexerPartialFailure(t *testing.T) {
+	es := newMockElasticsearch(t)
+	es.statuses = [][]int{{201, 429, 503}, {201, 201}}
+
+	indexer := NewBulkIndexer(NewClient(es.URL, "", ""), IndexerSettings{Retry: retry})
+
+	defer indexer.Close()
+
+	errs := indexer.Flush(context.Background(), []BulkItem{{Index: "foo", ID: "a"}, {Index: "foo", ID: "b"}, {Index: "foo", ID: "c"}})
+
+	assert.Equal(t, []error{nil, nil, nil}, errs)
+
+	// only the retryable items are sent again
+	assert.Equal(t, [][]string{{"a", "b", "c"}, {"b", "c"}}, es.bulks)
+}
+
+func TestBulkIndexerRejected(t *testing.T) {
+	es := newMockElasticsearch(t)
+	es.statuses = [][]int{{201, 400, 503}, {201}}
+
+	indexer := NewBulkIndexer(NewClient(es.URL, "", ""), IndexerSettings{Retry: retry})
+
+	defer indexer.Close()
+
+	errs := indexer.Flush(context.Background(), []BulkItem{{Index: "foo", ID: "a"}, {Index: "foo", ID: "b"}, {Index: "foo", ID: "c"}})
+
+	// only the rejected item fails, the rejection is not retried
+	require.Len(t, errs, 3)
+	assert.Nil(t, errs[0])
+	assert.ErrorIs(t, errs[1], ErrBulkItems)
+	assert.Nil(t, errs[2])
+	assert.Equal(t, [][]string{{"a", "b", "c"}, {"c"}}, es.bulks)
+}
+
+func TestBulkIndexerRetriesExhausted(t *testing.T) {
+	es := newMockElasticsearch(t)
+	es.statuses = [][]int{{201, 503}, {503}, {503}}
+
+	indexer := NewBulkIndexer(NewClient(es.URL, "", ""), IndexerSettings{Retry: retry})
+
+	defer indexer.Close()
+
+	errs := indexer.Flush(context.Background(), []BulkItem{{Index: "foo", ID: "a"}, {Index: "foo", ID: "b"}})
+
+	require.Len(t, errs, 2)
+	assert.Nil(t, errs[0])
+	assert.ErrorIs(t, errs[1], ErrBulkItems)
+}
+
+func TestBulkIndexerRequestRetry(t *testing.T) {
+	es := newMockElasticsearch(t)
+	es.failures = 1
+
+	indexer := NewBulkIndexer(NewClient(es.URL, "", ""), IndexerSettings{Retry: retry})
+
+	defer indexer.Close()
+
+	require.Nil(t, indexer.Add(context.Background(), BulkItem{Index: "foo", ID: "a"}))
+
+	assert.Equal(t, [][]string{{"a"}}, es.bulks)
+}
+
+func TestBulkIndexerAddFailure(t *testing.T) {
+	es := newMockElasticsearch(t)
+	es.statuses = [][]int{{400}}
+
+	indexer := NewBulkIndexer(NewClient(es.URL, "", ""), IndexerSettings{Retry: retry})
+
+	defer indexer.Close()
+
+	// the writer learns that its item was not indexed
+	require.ErrorIs(t, indexer.Add(context.Background(), BulkItem{Index: "foo", ID: "a"}), ErrBulkItems)
+}
+
+func TestBulkIndexerClose(t *testing.T) {
+	es := newMockElasticsearch(t)
+
+	indexer := NewBulkIndexer(NewClient(es.URL, "", ""), IndexerSettings{Retry: retry})
+
+	require.Nil(t, indexer.Add(context.Background(), BulkItem{Index: "foo", ID: "a"}))
+	require.Nil(t, indexer.Close())
+
+	assert.Equal(t, [][]string{{"a"}}, es.bulks)
+	assert.ErrorIs(t, indexer.Add(context.Background(), BulkItem{Index: "foo", ID: "b"}), batch.ErrClosed)
+}
@@ -3,35 +3,76 @@ package event
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
+	"errors"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/williampsena/bugs-channel-plugins/pkg/event"
 	"github.com/williampsena/bugs-channel/pkg/config"
+	"github.com/williampsena/bugs-channel/pkg/fingerprint"
 	"github.com/williampsena/bugs-channel/pkg/scrub"
 	"github.com/williampsena/bugs-channel/pkg/storage"
 )
 
 // A event of Dispatcher
 type BugsChannelEventsDispatcher struct {
-	queue storage.Queue
+	queue    storage.Queue
+	settings DispatcherSettings
+}
+
+// Returns the topic of an event
+type TopicRouter interface {
+	Route(event event.Event) string
+}
+
+// Represents the dispatcher topics and retry policy
+type DispatcherSettings struct {
+	// The topic events are published to when there is no router
+	Topic string
+	// Routes events to topics, it takes precedence over the topic
+	Router TopicRouter
+	// The topic events go to after the retries are exhausted, empty disables it
+	DeadLetterTopic string
+	// The publish retry policy
+	Retry RetryPolicy
+}
+
+// Returns the default dispatcher settings
+func DefaultDispatcherSettings() DispatcherSettings {
+	return DispatcherSettings{
+		Topic:           "events",
+		DeadLetterTopic: "dlq.events",
+		Retry: RetryPolicy{
+			Attempts:       3,
+			InitialBackoff: 100 * time.Millisecond,
+			MaxBackoff:     2 * time.Second,
+		},
+	}
 }
 
 // Dispatch a event
 func (d *BugsChannelEventsDispatcher) Dispatch(event event.Event) error {
+	fingerprint.Attach(&event)
+
+	topic := d.topic(event)
+
 	scrub.ScrubSensitiveEvent(&event, config.ScrubSensitiveKeys())
 
-	fmt.Println(event.Tags)
 	body, err := event.Json()
 
 	if err != nil {
 		return err
 	}
 
-	err = d.queue.Publish(context.TODO(), "events", body)
+	ctx := storage.WithMessageKey(context.TODO(), event.ServiceId)
+
+	attempts, err := d.settings.Retry.Do(ctx, func() error {
+		return d.queue.Publish(ctx, topic, body)
+	})
 
 	if err != nil {
-		return err
+		return d.deadLetter(ctx, event.ID, event.ServiceId, topic, body, attempts, err)
 	}
 
 	log.Infof("🐞 Ingest Event: %v", event.ID)
@@ -39,6 +80,44 @@ func (d *BugsChannelEventsDispatcher) Dispatch(event event.Event) error {
 	return nil
 }
 
+// Publish the event envelope to the dead letter topic, the event counts as accepted once it is dead-lettered
+func (d *BugsChannelEventsDispatcher) deadLetter(ctx context.Context, id string, key string, topic string, body string, attempts int, reason error) error {
+	if d.settings.DeadLetterTopic == "" {
+		return reason
+	}
+
+	envelope, err := json.Marshal(DeadLetter{
+		ID:       id,
+		Key:      key,
+		Topic:    topic,
+		Reason:   reason.Error(),
+		Attempts: attempts,
+		FailedAt: time.Now().UTC(),
+		Message:  json.RawMessage(body),
+	})
+
+	if err != nil {
+		return errors.Join(reason, err)
+	}
+
+	if err := d.queue.Publish(ctx, d.settings.DeadLetterTopic, string(envelope)); err != nil {
+		return errors.Join(reason, err)
+	}
+
+	log.Warnf("💀 Dead Letter Event: %v after %v attempts: %v", id, attempts, reason)
+
+	return nil
+}
+
+// Returns the topic of the event, routed before scrubbing so rules see the original tags
+func (d *BugsChannelEventsDispatcher) topic(e event.Event) string {
+	if d.settings.Router != nil {
+		return d.settings.Router.Route(e)
+	}
+
+	return d.settings.Topic
+}
+
 // Dispatch many events to stdout
 func (d *BugsChannelEventsDispatcher) DispatchMany(events []event.Event) error {
 	for _, e := range events {
@@ -54,5 +133,10 @@ func (d *BugsChannelEventsDispatcher) DispatchMany(events []event.Event) error {
 
 // Creates a new event dispatcher
 func NewDispatcher(queue storage.Queue) *BugsChannelEventsDispatcher {
-	return &BugsChannelEventsDispatcher{queue}
+	return NewDispatcherWithSettings(queue, DefaultDispatcherSettings())
+}
+
+// Creates a new event dispatcher with custom topics and retry policy
+func NewDispatcherWithSettings(queue storage.Queue, settings DispatcherSettings) *BugsChannelEventsDispatcher {
+	return &BugsChannelEventsDispatcher{queue, settings}
 }
@@ -2,19 +2,27 @@ package event
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/williampsena/bugs-channel-plugins/pkg/event"
 	"github.com/williampsena/bugs-channel-plugins/pkg/test"
+	"github.com/williampsena/bugs-channel/pkg/fingerprint"
 	"github.com/williampsena/bugs-channel/pkg/storage"
+	bctest "github.com/williampsena/bugs-channel/pkg/test"
 )
 
 func TestDispatchSuccess(t *testing.T) {
 	buf := test.CaptureLog()
 
-	dispatcher := NewDispatcher(buildTestQueue())
+	queue := bctest.BuildMemoryQueue(t)
+	messages := bctest.SubscribeMemoryQueue(t, queue, "events")
+
+	dispatcher := NewDispatcher(queue)
 
 	err := dispatcher.Dispatch(event.Event{
 		ID:        "foo",
@@ -24,33 +32,130 @@ func TestDispatchSuccess(t *testing.T) {
 
 	require.Nil(t, err)
 
+	assert.Contains(t, <-messages, `"id":"foo"`)
 	assert.Contains(t, buf.String(), "🐞 Ingest Event: foo")
 
 	test.ResetCaptureLog()
 }
 
-type mockNats struct {
-	lastMessage string
+func TestDispatchDeadLetter(t *testing.T) {
+	queue := &failingQueue{MemoryQueue: bctest.BuildMemoryQueue(t), failures: map[string]int{"events": -1}}
+	deadLetters := bctest.SubscribeMemoryQueue(t, queue.MemoryQueue, "dlq.events")
+
+	dispatcher := NewDispatcherWithSettings(queue, buildTestDispatcherSettings())
+
+	err := dispatcher.Dispatch(event.Event{ID: "foo", ServiceId: "bar"})
+
+	require.Nil(t, err)
+	assert.Equal(t, 2, queue.attempts["events"])
+
+	var deadLetter DeadLetter
+
+	require.Nil(t, json.Unmarshal([]byte(<-deadLetters), &deadLetter))
+
+	assert.Equal(t, "foo", deadLetter.ID)
+	assert.Equal(t, "bar", deadLetter.Key)
+	assert.Equal(t, "events", deadLetter.Topic)
+	assert.Equal(t, 2, deadLetter.Attempts)
+	assert.Equal(t, "queue is down", deadLetter.Reason)
+	assert.Contains(t, string(deadLetter.Message), `"id":"foo"`)
+}
+
+func TestDispatchDeadLetterFailure(t *testing.T) {
+	queue := bctest.BuildMemoryQueue(t)
+
+	// a closed queue refuses the event and its dead letter
+	queue.Close()
+
+	dispatcher := NewDispatcherWithSettings(queue, buildTestDispatcherSettings())
+
+	err := dispatcher.Dispatch(event.Event{ID: "foo", ServiceId: "bar"})
+
+	assert.ErrorIs(t, err, storage.ErrMemoryQueueClosed)
+}
+
+func TestDispatchRetrySuccess(t *testing.T) {
+	queue := &failingQueue{MemoryQueue: bctest.BuildMemoryQueue(t), failures: map[string]int{"events": 1}}
+	messages := bctest.SubscribeMemoryQueue(t, queue.MemoryQueue, "events")
+
+	dispatcher := NewDispatcherWithSettings(queue, buildTestDispatcherSettings())
+
+	err := dispatcher.Dispatch(event.Event{ID: "foo", ServiceId: "bar"})
+
+	require.Nil(t, err)
+	assert.Equal(t, 2, queue.attempts["events"])
+	assert.Contains(t, <-messages, `"id":"foo"`)
+}
+
+func TestDispatchFingerprint(t *testing.T) {
+	queue := bctest.BuildMemoryQueue(t)
+	messages := bctest.SubscribeMemoryQueue(t, queue, "events")
+
+	dispatcher := NewDispatcherWithSettings(queue, buildTestDispatcherSettings())
+
+	err := dispatcher.Dispatch(event.Event{ID: "foo", ServiceId: "bar", Title: "ValueError", Tags: []string{"app:foo"}})
+
+	require.Nil(t, err)
+
+	var e event.Event
+
+	require.Nil(t, json.Unmarshal([]byte(<-messages), &e))
+
+	require.Len(t, e.Tags, 2)
+	assert.Equal(t, "app:foo", e.Tags[0])
+	assert.Equal(t, "fingerprint:"+fingerprint.Compute(event.Event{Title: "ValueError"}), e.Tags[1])
 }
 
-func buildTestQueue() storage.Queue {
-	return &mockNats{lastMessage: ""}
+func TestDispatchRouter(t *testing.T) {
+	queue := bctest.BuildMemoryQueue(t)
+	messages := bctest.SubscribeMemoryQueue(t, queue, "events.bar.python")
+
+	settings := buildTestDispatcherSettings()
+	settings.Router = mockRouter{}
+
+	dispatcher := NewDispatcherWithSettings(queue, settings)
+
+	err := dispatcher.Dispatch(event.Event{ID: "foo", ServiceId: "bar", Platform: "python"})
+
+	require.Nil(t, err)
+	assert.Contains(t, <-messages, `"id":"foo"`)
+}
+
+type mockRouter struct{}
+
+// Route a event
+func (mockRouter) Route(e event.Event) string {
+	return "events." + e.ServiceId + "." + e.Platform
 }
 
-// Publish a message
-func (n *mockNats) Publish(ctx context.Context, topic string, message string) error {
-	n.lastMessage = message
-	return nil
+func buildTestDispatcherSettings() DispatcherSettings {
+	settings := DefaultDispatcherSettings()
+	settings.Retry = RetryPolicy{Attempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	return settings
 }
 
-// Subscribe to a Nats channel
-func (n *mockNats) Subscribe(ctx context.Context, channel string, handler storage.SubscribeHandler) error {
-	var header = map[string][]string{"foo": []string{"bar"}}
-	handler(header, "foo")
-	return nil
+var errQueueDown = errors.New("queue is down")
+
+// Fails the publishes of a topic before handing them to the memory queue
+type failingQueue struct {
+	*storage.MemoryQueue
+	// how many publishes of each topic fail, every publish when negative
+	failures map[string]int
+	attempts map[string]int
 }
 
-// Close Nats connection
-func (n *mockNats) Close() {
-	n.lastMessage = ""
+// Publish a message unless the topic still fails
+func (q *failingQueue) Publish(ctx context.Context, topic string, message string) error {
+	if q.attempts == nil {
+		q.attempts = make(map[string]int)
+	}
+
+	q.attempts[topic]++
+
+	if failures := q.failures[topic]; failures < 0 || q.attempts[topic] <= failures {
+		return errQueueDown
+	}
+
+	return q.MemoryQueue.Publish(ctx, topic, message)
 }
@@ -0,0 +1,68 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/williampsena/bugs-channel/pkg/storage"
+)
+
+// Represents an event that could not be published after every retry, or that a consumer gave up on
+type DeadLetter = storage.DeadLetter
+
+// Lists the dead letters of a topic without consuming them, queues that cannot peek are refused
+func ListDeadLetters(ctx context.Context, queue storage.Queue, topic string, fn func(DeadLetter)) error {
+	peeker, ok := queue.(storage.Peeker)
+
+	if !ok {
+		return storage.ErrPeekUnsupported
+	}
+
+	return peeker.Peek(ctx, topic, func(header map[string][]string, body string) error {
+		var deadLetter DeadLetter
+
+		if err := json.Unmarshal([]byte(body), &deadLetter); err != nil {
+			return err
+		}
+
+		fn(deadLetter)
+
+		return nil
+	})
+}
+
+// Publishes the dead letters of a topic back to their original topic until the context is done
+func RedriveDeadLetters(ctx context.Context, queue storage.Queue, topic string) (int, error) {
+	redriven := 0
+
+	err := queue.Subscribe(ctx, topic, func(header map[string][]string, body string) error {
+		if err := redriveDeadLetter(ctx, queue, body); err != nil {
+			return err
+		}
+
+		redriven++
+
+		return nil
+	})
+
+	return redriven, err
+}
+
+func redriveDeadLetter(ctx context.Context, queue storage.Queue, body string) error {
+	var deadLetter DeadLetter
+
+	if err := json.Unmarshal([]byte(body), &deadLetter); err != nil {
+		return err
+	}
+
+	err := queue.Publish(storage.WithMessageKey(context.WithoutCancel(ctx), deadLetter.Key), deadLetter.Topic, string(deadLetter.Message))
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("♻️ Redrive Event: %v to %v", deadLetter.ID, deadLetter.Topic)
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package event
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel/pkg/storage"
+	"github.com/williampsena/bugs-channel/pkg/test"
+)
+
+func TestRedriveDeadLetter(t *testing.T) {
+	queue := test.BuildMemoryQueue(t)
+	messages := test.SubscribeMemoryQueue(t, queue, "events")
+	body := `{"id":"foo","key":"bar","topic":"events","reason":"queue is down","attempts":3,"message":{"id":"foo"}}`
+
+	err := redriveDeadLetter(context.Background(), queue, body)
+
+	require.Nil(t, err)
+	assert.Equal(t, `{"id":"foo"}`, <-messages)
+}
+
+func TestListDeadLetters(t *testing.T) {
+	srv := miniredis.RunT(t)
+	queue, err := storage.NewRedisStreamsConnection("redis://"+srv.Addr(), storage.RedisStreamsSettings{})
+
+	require.Nil(t, err)
+
+	deadLetter := `{"id":"foo","topic":"events","reason":"queue is down","message":{"id":"foo"}}`
+
+	require.Nil(t, queue.Publish(context.Background(), "dlq.events", deadLetter))
+
+	// listing leaves the dead letters queued, so they are listed again
+	for i := 0; i < 2; i++ {
+		var listed []DeadLetter
+
+		err = ListDeadLetters(context.Background(), queue, "dlq.events", func(d DeadLetter) {
+			listed = append(listed, d)
+		})
+
+		require.Nil(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, "foo", listed[0].ID)
+		assert.Equal(t, "queue is down", listed[0].Reason)
+	}
+}
+
+func TestListDeadLettersUnsupported(t *testing.T) {
+	queue := test.BuildMemoryQueue(t)
+
+	err := ListDeadLetters(context.Background(), queue, "dlq.events", func(d DeadLetter) {
+		t.Fatal("the dead letters must not be consumed")
+	})
+
+	assert.ErrorIs(t, err, storage.ErrPeekUnsupported)
+}
+
+func TestRedriveDeadLetters(t *testing.T) {
+	queue := test.BuildMemoryQueue(t)
+	messages := test.SubscribeMemoryQueue(t, queue, "events")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan int, 1)
+
+	go func() {
+		redriven, err := RedriveDeadLetters(ctx, queue, "dlq.events")
+
+		assert.Nil(t, err)
+
+		result <- redriven
+	}()
+
+	require.Eventually(t, func() bool {
+		return queue.SubscriberCount("dlq.events") == 1
+	}, time.Second, time.Millisecond)
+
+	require.Nil(t, queue.Publish(context.Background(), "dlq.events", `{"id":"foo","topic":"events","message":{"id":"foo"}}`))
+
+	assert.Equal(t, `{"id":"foo"}`, <-messages)
+
+	// the redrive runs until its context is done
+	cancel()
+
+	assert.Equal(t, 1, <-result)
+}
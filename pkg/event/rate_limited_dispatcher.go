@@ -0,0 +1,55 @@
+package event
+
+import (
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/ratelimit"
+)
+
+// The dispatcher contract wrapped by the rate limited dispatcher
+type EventsDispatcher interface {
+	// Dispatch a event
+	Dispatch(event event.Event) error
+
+	// Dispatch many events
+	DispatchMany(events []event.Event) error
+}
+
+// A dispatcher that enforces the service rate limit before dispatching
+type RateLimitedEventsDispatcher struct {
+	dispatcher EventsDispatcher
+	limiter    *ratelimit.ServiceLimiter
+}
+
+// Dispatch a event when the service is within its rate limit
+func (d *RateLimitedEventsDispatcher) Dispatch(e event.Event) error {
+	if err := d.allow(e); err != nil {
+		return err
+	}
+
+	return d.dispatcher.Dispatch(e)
+}
+
+// Dispatch many events when the services are within their rate limits, each event counts against the limit
+// and none is dispatched once one is rate limited
+func (d *RateLimitedEventsDispatcher) DispatchMany(events []event.Event) error {
+	for _, e := range events {
+		if err := d.allow(e); err != nil {
+			return err
+		}
+	}
+
+	return d.dispatcher.DispatchMany(events)
+}
+
+func (d *RateLimitedEventsDispatcher) allow(e event.Event) error {
+	if result := d.limiter.Allow(e.ServiceId); !result.Allowed {
+		return &ratelimit.LimitError{ServiceId: e.ServiceId, Result: result}
+	}
+
+	return nil
+}
+
+// Creates a new rate limited dispatcher
+func NewRateLimitedDispatcher(dispatcher EventsDispatcher, limiter *ratelimit.ServiceLimiter) *RateLimitedEventsDispatcher {
+	return &RateLimitedEventsDispatcher{dispatcher, limiter}
+}
@@ -0,0 +1,56 @@
+package event
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/ratelimit"
+	"github.com/williampsena/bugs-channel/pkg/settings"
+	"github.com/williampsena/bugs-channel/pkg/test"
+)
+
+func TestRateLimitedDispatch(t *testing.T) {
+	queue := test.BuildMemoryQueue(t)
+	messages := test.SubscribeMemoryQueue(t, queue, "events")
+	limiter := ratelimit.NewServiceLimiter([]settings.ConfigFileService{
+		{Id: "bar", Settings: settings.ConfigFileServiceSettings{RateLimit: 1}},
+	})
+
+	dispatcher := NewRateLimitedDispatcher(NewDispatcher(queue), limiter)
+
+	err := dispatcher.Dispatch(event.Event{ID: "foo", ServiceId: "bar"})
+
+	require.Nil(t, err)
+	assert.Contains(t, <-messages, "foo")
+
+	err = dispatcher.Dispatch(event.Event{ID: "baz", ServiceId: "bar"})
+
+	assert.True(t, errors.Is(err, ratelimit.ErrRateLimitExceeded))
+
+	err = dispatcher.DispatchMany([]event.Event{{ID: "qux", ServiceId: "other"}})
+
+	assert.Nil(t, err)
+}
+
+func TestRateLimitedDispatchMany(t *testing.T) {
+	queue := test.BuildMemoryQueue(t)
+	messages := test.SubscribeMemoryQueue(t, queue, "events")
+	limiter := ratelimit.NewServiceLimiter([]settings.ConfigFileService{
+		{Id: "bar", Settings: settings.ConfigFileServiceSettings{RateLimit: 2}},
+	})
+
+	dispatcher := NewRateLimitedDispatcher(NewDispatcher(queue), limiter)
+
+	// the third event exceeds the limit, so none of the batch is dispatched
+	err := dispatcher.DispatchMany([]event.Event{{ID: "foo", ServiceId: "bar"}, {ID: "baz", ServiceId: "bar"}, {ID: "qux", ServiceId: "bar"}})
+
+	var limitErr *ratelimit.LimitError
+
+	require.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, "bar", limitErr.ServiceId)
+	assert.Equal(t, 2, limitErr.Result.Limit)
+	assert.Empty(t, messages)
+}
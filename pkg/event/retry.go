@@ -0,0 +1,62 @@
+package event
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// Represents how many times and how long to wait before retrying a publish
+type RetryPolicy struct {
+	// The total publish attempts, including the first one
+	Attempts int
+	// The wait before the first retry, doubled on each retry
+	InitialBackoff time.Duration
+	// The wait cap
+	MaxBackoff time.Duration
+}
+
+// Calls fn until it succeeds, the attempts are exhausted or the context is done.
+// It returns the attempts made and the last error.
+func (p RetryPolicy) Do(ctx context.Context, fn func() error) (int, error) {
+	var err error
+
+	attempt := 0
+
+	for attempt < max(p.Attempts, 1) {
+		attempt++
+
+		if err = fn(); err == nil {
+			return attempt, nil
+		}
+
+		if attempt >= p.Attempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return attempt, err
+		case <-time.After(p.Backoff(attempt)):
+		}
+	}
+
+	return attempt, err
+}
+
+// Returns the wait after the given attempt, an exponential backoff with equal jitter
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	backoff := p.InitialBackoff << (attempt - 1)
+
+	if backoff <= 0 || backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+
+	half := backoff / 2
+
+	if half <= 0 {
+		return backoff
+	}
+
+	return half + rand.N(half)
+}
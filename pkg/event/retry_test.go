@@ -0,0 +1,45 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyDo(t *testing.T) {
+	policy := RetryPolicy{Attempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+
+	calls := 0
+	attempts, err := policy.Do(context.Background(), func() error {
+		calls++
+
+		if calls < 2 {
+			return errors.New("failed")
+		}
+
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, attempts)
+
+	attempts, err = policy.Do(context.Background(), func() error { return errors.New("failed") })
+
+	assert.NotNil(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{Attempts: 5, InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+
+	for attempt, expected := range map[int]time.Duration{1: 100, 2: 200, 3: 300, 10: 300} {
+		backoff := policy.Backoff(attempt)
+		limit := expected * time.Millisecond
+
+		assert.GreaterOrEqual(t, backoff, limit/2)
+		assert.LessOrEqual(t, backoff, limit)
+	}
+}
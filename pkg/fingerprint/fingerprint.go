@@ -0,0 +1,163 @@
+// This package groups events into issues by deriving a stable fingerprint from the exception
+package fingerprint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+)
+
+// The tag prefix carrying the event fingerprint
+const TagPrefix = "fingerprint:"
+
+// The extra key a client may set to group its events, e.g. ["{{ default }}", "checkout"]
+const ExtraKey = "fingerprint"
+
+// The client fingerprint placeholder replaced by the computed fingerprint
+const DefaultPlaceholder = "{{ default }}"
+
+var (
+	uuidPattern   = regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`)
+	hexPattern    = regexp.MustCompile(`(?i)\b0x[0-9a-f]+\b`)
+	quotedPattern = regexp.MustCompile(`'[^']*'|"[^"]*"`)
+	numberPattern = regexp.MustCompile(`\d+(\.\d+)?`)
+	spacePattern  = regexp.MustCompile(`\s+`)
+)
+
+// Attaches the fingerprint tag to the event, keeping a fingerprint tag sent by the client
+func Attach(e *event.Event) string {
+	if fingerprint := FromTags(e.Tags); fingerprint != "" {
+		return fingerprint
+	}
+
+	fingerprint := Compute(*e)
+
+	e.Tags = append(e.Tags, TagPrefix+fingerprint)
+
+	return fingerprint
+}
+
+// Returns the fingerprint attached to the tags
+func FromTags(tags []string) string {
+	for _, tag := range tags {
+		if fingerprint, ok := strings.CutPrefix(tag, TagPrefix); ok {
+			return fingerprint
+		}
+	}
+
+	return ""
+}
+
+// Returns the event fingerprint, the client fingerprint wins over the computed one
+func Compute(e event.Event) string {
+	components := clientComponents(e)
+
+	if len(components) == 0 {
+		return hash(defaultComponents(e))
+	}
+
+	for i, component := range components {
+		if component == DefaultPlaceholder {
+			components[i] = hash(defaultComponents(e))
+		}
+	}
+
+	return hash(components)
+}
+
+// Returns the exception type, the in-app frames or, without frames, the normalised message
+func defaultComponents(e event.Event) []string {
+	components := []string{e.Title}
+
+	frames := inAppFrames(e.StackTrace)
+
+	if len(frames) > 0 {
+		return append(components, frames...)
+	}
+
+	return append(components, NormaliseMessage(e.Body))
+}
+
+// Returns the module or file and function of the in-app frames, every frame when none is flagged
+func inAppFrames(stackTrace event.StackTrace) []string {
+	var flagged, all []string
+
+	for _, frame := range stackTrace {
+		location := frameValue(frame, "module")
+
+		if location == "" {
+			location = frameValue(frame, "filename")
+		}
+
+		name := location + ":" + frameValue(frame, "function")
+
+		if name == ":" {
+			continue
+		}
+
+		all = append(all, name)
+
+		if inApp, ok := frame["in_app"].(bool); ok && inApp {
+			flagged = append(flagged, name)
+		}
+	}
+
+	if len(flagged) > 0 {
+		return flagged
+	}
+
+	return all
+}
+
+func frameValue(frame map[string]interface{}, key string) string {
+	value, ok := frame[key]
+
+	if !ok || value == nil {
+		return ""
+	}
+
+	return fmt.Sprint(value)
+}
+
+// Replaces the values that change between occurrences, e.g. ids, addresses and numbers
+func NormaliseMessage(message string) string {
+	message = uuidPattern.ReplaceAllString(message, "<uuid>")
+	message = hexPattern.ReplaceAllString(message, "<hex>")
+	message = quotedPattern.ReplaceAllString(message, "<str>")
+	message = numberPattern.ReplaceAllString(message, "<num>")
+	message = spacePattern.ReplaceAllString(message, " ")
+
+	return strings.TrimSpace(message)
+}
+
+// Returns the fingerprint components sent by the client on the event extra
+func clientComponents(e event.Event) []string {
+	switch value := e.Extra[ExtraKey].(type) {
+	case string:
+		if value != "" {
+			return []string{value}
+		}
+	case []string:
+		return append([]string(nil), value...)
+	case []interface{}:
+		components := make([]string, 0, len(value))
+
+		for _, v := range value {
+			components = append(components, fmt.Sprint(v))
+		}
+
+		return components
+	}
+
+	return nil
+}
+
+func hash(components []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(components, "\x00")))
+
+	return hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,83 @@
+package fingerprint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+)
+
+func TestComputeStackTrace(t *testing.T) {
+	e := event.Event{
+		Title: "ValueError",
+		Body:  "invalid literal for int() with base 10: 'abc'",
+		StackTrace: event.StackTrace{
+			{"module": "django.core", "function": "handle", "lineno": 10, "in_app": false},
+			{"filename": "app/views.py", "function": "checkout", "lineno": 42, "in_app": true},
+		},
+	}
+
+	other := e
+	other.Body = "invalid literal for int() with base 10: 'xyz'"
+	other.StackTrace = event.StackTrace{
+		{"module": "django.core", "function": "dispatch", "lineno": 20, "in_app": false},
+		{"filename": "app/views.py", "function": "checkout", "lineno": 43, "in_app": true},
+	}
+
+	assert.Len(t, Compute(e), 32)
+	assert.Equal(t, Compute(e), Compute(other))
+
+	other.Title = "KeyError"
+
+	assert.NotEqual(t, Compute(e), Compute(other))
+}
+
+func TestComputeMessage(t *testing.T) {
+	foo := event.Event{Title: "TimeoutError", Body: "request 7f1c0b6e-8a55-4f57-9b9a-2f7c7f5c1d2e timed out after 30s at 0x7ffe"}
+	bar := event.Event{Title: "TimeoutError", Body: "request 0c5a1bd2-1f53-4b6c-8d0a-7f0c1f2a3b4c timed out after 45s at 0x1aab"}
+	baz := event.Event{Title: "TimeoutError", Body: "connection refused"}
+
+	assert.Equal(t, Compute(foo), Compute(bar))
+	assert.NotEqual(t, Compute(foo), Compute(baz))
+}
+
+func TestComputeClientFingerprint(t *testing.T) {
+	e := event.Event{Title: "ValueError", Body: "foo"}
+
+	withClient := e
+	withClient.Extra = event.EventExtra{"fingerprint": []interface{}{"checkout"}}
+
+	assert.Equal(t, hash([]string{"checkout"}), Compute(withClient))
+
+	withDefault := e
+	withDefault.Extra = event.EventExtra{"fingerprint": []interface{}{"{{ default }}", "checkout"}}
+
+	assert.Equal(t, hash([]string{Compute(e), "checkout"}), Compute(withDefault))
+
+	withString := e
+	withString.Extra = event.EventExtra{"fingerprint": "checkout"}
+
+	assert.Equal(t, Compute(withClient), Compute(withString))
+}
+
+func TestAttach(t *testing.T) {
+	e := event.Event{Title: "ValueError", Tags: []string{"env:production"}}
+
+	fingerprint := Attach(&e)
+
+	assert.Equal(t, []string{"env:production", "fingerprint:" + fingerprint}, e.Tags)
+
+	// attaching again keeps the tag
+	assert.Equal(t, fingerprint, Attach(&e))
+	assert.Len(t, e.Tags, 2)
+
+	client := event.Event{Tags: []string{"fingerprint:abc"}}
+
+	assert.Equal(t, "abc", Attach(&client))
+	assert.Equal(t, []string{"fingerprint:abc"}, client.Tags)
+}
+
+func TestNormaliseMessage(t *testing.T) {
+	assert.Equal(t, "user <num> not found in <str>", NormaliseMessage("user  42 not found in 'users'"))
+	assert.Equal(t, "id <uuid> at <hex>", NormaliseMessage("id 7f1c0b6e-8a55-4f57-9b9a-2f7c7f5c1d2e at 0xdeadbeef"))
+}
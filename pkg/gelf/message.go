@@ -0,0 +1,166 @@
+// This package converts events into Graylog GELF 1.1 messages and sends them over UDP or TCP
+package gelf
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+)
+
+// The GELF specification version
+const Version = "1.1"
+
+// The host used when the event has no server name
+const DefaultHost = "bugs-channel"
+
+// The syslog severities of the event levels
+var levels = map[string]int{
+	"fatal":    2,
+	"critical": 2,
+	"error":    3,
+	"warning":  4,
+	"warn":     4,
+	"info":     6,
+	"debug":    7,
+}
+
+var invalidFieldPattern = regexp.MustCompile(`[^\w.\-]`)
+
+// Represents a GELF 1.1 message, additional fields are prefixed with an underscore
+type Message map[string]any
+
+// Build the GELF message of the event: the exception is the short and full message,
+// the tags and extra become additional fields
+func NewMessage(e event.Event, timestamp time.Time) Message {
+	host := e.ServerName
+
+	if host == "" {
+		host = DefaultHost
+	}
+
+	m := Message{
+		"version":       Version,
+		"host":          host,
+		"short_message": shortMessage(e),
+		"timestamp":     float64(timestamp.UnixMilli()) / 1000,
+		"level":         level(e.Level),
+		"_event_id":     e.ID,
+		"_service_id":   e.ServiceId,
+		"_platform":     e.Platform,
+	}
+
+	if full := fullMessage(e); full != "" {
+		m["full_message"] = full
+	}
+
+	m.add("_environment", e.Environment)
+	m.add("_release", e.Release)
+	m.add("_kind", e.Kind)
+
+	for _, tag := range e.Tags {
+		key, value, _ := strings.Cut(tag, ":")
+		m.add("_tag_"+key, value)
+	}
+
+	m.flatten("_extra", e.Extra)
+
+	return m
+}
+
+// Adds an additional field, a value that is neither string nor number becomes a string
+func (m Message) add(key string, value any) {
+	key = invalidFieldPattern.ReplaceAllString(key, "_")
+
+	if _, ok := m[key]; ok || key == "_id" {
+		return
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return
+	case string:
+		if v == "" {
+			return
+		}
+
+		m[key] = v
+	case int, int32, int64, float32, float64, uint, uint32, uint64:
+		m[key] = v
+	default:
+		m[key] = fmt.Sprint(v)
+	}
+}
+
+// Adds the nested values as fields joined by underscores, e.g. _extra_user_id
+func (m Message) flatten(prefix string, value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+
+		for key := range v {
+			keys = append(keys, key)
+		}
+
+		// sorted so colliding keys resolve the same way every time
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			m.flatten(prefix+"_"+key, v[key])
+		}
+	case []any:
+		for i, item := range v {
+			m.flatten(fmt.Sprintf("%v_%d", prefix, i), item)
+		}
+	default:
+		m.add(prefix, v)
+	}
+}
+
+func level(value string) int {
+	if severity, ok := levels[strings.ToLower(value)]; ok {
+		return severity
+	}
+
+	return levels["error"]
+}
+
+// Returns the exception type and the first message line
+func shortMessage(e event.Event) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(e.Body), "\n")
+
+	switch {
+	case e.Title != "" && line != "":
+		return e.Title + ": " + line
+	case e.Title != "":
+		return e.Title
+	case line != "":
+		return line
+	}
+
+	return "unknown error"
+}
+
+// Returns the message followed by the stack trace frames
+func fullMessage(e event.Event) string {
+	var sb strings.Builder
+
+	sb.WriteString(strings.TrimSpace(e.Body))
+
+	for _, frame := range e.StackTrace {
+		sb.WriteString(fmt.Sprintf("\n  at %v (%v:%v)", frameValue(frame, "function"), frameValue(frame, "filename"), frameValue(frame, "lineno")))
+	}
+
+	return strings.TrimSpace(sb.String())
+}
+
+func frameValue(frame map[string]any, key string) any {
+	if value, ok := frame[key]; ok && value != nil {
+		return value
+	}
+
+	return "?"
+}
@@ -0,0 +1,74 @@
+package gelf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+)
+
+var now = time.Date(2024, 1, 2, 3, 4, 5, 500_000_000, time.UTC)
+
+func TestNewMessage(t *testing.T) {
+	e := event.Event{
+		ID:         "foo",
+		ServiceId:  "1",
+		Platform:   "python",
+		ServerName: "web-1",
+		Release:    "1.0.0",
+		Title:      "ValueError",
+		Body:       "invalid literal for int()\nwith base 10",
+		Level:      "warning",
+		StackTrace: event.StackTrace{
+			{"filename": "app.py", "function": "main", "lineno": 10},
+		},
+		Tags: []string{"env:production", "fingerprint:abc", "canary"},
+		Extra: event.EventExtra{
+			"user":  map[string]any{"id": 42, "email": "foo@bar.com"},
+			"items": []any{"a", "b"},
+			"ok":    true,
+			"id":    "reserved",
+		},
+	}
+
+	assert.Equal(t, Message{
+		"version":           "1.1",
+		"host":              "web-1",
+		"short_message":     "ValueError: invalid literal for int()",
+		"full_message":      "invalid literal for int()\nwith base 10\n  at main (app.py:10)",
+		"timestamp":         1704164645.5,
+		"level":             4,
+		"_event_id":         "foo",
+		"_service_id":       "1",
+		"_platform":         "python",
+		"_release":          "1.0.0",
+		"_tag_env":          "production",
+		"_tag_fingerprint":  "abc",
+		"_extra_user_id":    42,
+		"_extra_user_email": "foo@bar.com",
+		"_extra_items_0":    "a",
+		"_extra_items_1":    "b",
+		"_extra_ok":         "true",
+		"_extra_id":         "reserved",
+	}, NewMessage(e, now))
+}
+
+func TestNewMessageDefaults(t *testing.T) {
+	m := NewMessage(event.Event{ServiceId: "1", Level: "unknown"}, now)
+
+	assert.Equal(t, DefaultHost, m["host"])
+	assert.Equal(t, "unknown error", m["short_message"])
+	assert.Equal(t, 3, m["level"])
+	assert.NotContains(t, m, "full_message")
+}
+
+func TestMessageFieldNames(t *testing.T) {
+	m := Message{}
+
+	m.add("_tag_my key", "foo")
+	m.add("_id", "foo")
+	m.add("_nil", nil)
+
+	assert.Equal(t, Message{"_tag_my_key": "foo"}, m)
+}
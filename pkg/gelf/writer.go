@@ -0,0 +1,223 @@
+package gelf
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// Represents an error when the GELF transport is unknown
+var ErrUnsupportedProtocol = errors.New("the GELF protocol is not supported")
+
+// Represents an error when a message needs more than the maximum chunks
+var ErrMessageTooLarge = errors.New("the GELF message is too large")
+
+const (
+	// Sends each message as UDP datagrams, compressed and chunked
+	ProtocolUDP = "udp"
+	// Sends null terminated messages over a TCP connection
+	ProtocolTCP = "tcp"
+	// Compresses the UDP messages with gzip
+	CompressionGzip = "gzip"
+	// Sends the UDP messages uncompressed
+	CompressionNone = "none"
+	// The datagram size fitting the common ethernet MTU
+	DefaultChunkSize = 1420
+	// The most chunks a message can be split into
+	maxChunks = 128
+	// The chunk magic bytes, message id, sequence number and count
+	chunkHeaderSize = 12
+	dialTimeout     = 5 * time.Second
+)
+
+// Represents the GELF destination settings
+type Settings struct {
+	// The Graylog input address, e.g. localhost:12201
+	Address string
+	// The udp or tcp transport, udp by default
+	Protocol string
+	// The gzip or none UDP compression, gzip by default
+	Compression string
+	// The UDP datagram size messages are chunked by
+	ChunkSize int
+}
+
+// The GELF writer interface
+type Writer interface {
+	// Send a message
+	Write(m Message) error
+
+	// Close the connection
+	Close() error
+}
+
+// Build the writer of the settings protocol
+func NewWriter(settings Settings) (Writer, error) {
+	switch settings.Protocol {
+	case "", ProtocolUDP:
+		return NewUDPWriter(settings.Address, settings.Compression, settings.ChunkSize)
+	case ProtocolTCP:
+		return NewTCPWriter(settings.Address), nil
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrUnsupportedProtocol, settings.Protocol)
+}
+
+// Sends messages as UDP datagrams
+type UDPWriter struct {
+	conn      net.Conn
+	compress  bool
+	chunkSize int
+}
+
+// Send the message, chunking it when it does not fit a datagram
+func (w *UDPWriter) Write(m Message) error {
+	payload, err := w.encode(m)
+
+	if err != nil {
+		return err
+	}
+
+	if len(payload) <= w.chunkSize {
+		_, err := w.conn.Write(payload)
+		return err
+	}
+
+	return w.writeChunks(payload)
+}
+
+func (w *UDPWriter) encode(m Message) ([]byte, error) {
+	payload, err := json.Marshal(m)
+
+	if err != nil || !w.compress {
+		return payload, err
+	}
+
+	var buf bytes.Buffer
+
+	zw := gzip.NewWriter(&buf)
+
+	if _, err := zw.Write(payload); err != nil {
+		return nil, err
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Sends the payload in chunks sharing a random message id
+func (w *UDPWriter) writeChunks(payload []byte) error {
+	size := w.chunkSize - chunkHeaderSize
+	count := (len(payload) + size - 1) / size
+
+	if count > maxChunks {
+		return fmt.Errorf("%w: %v bytes", ErrMessageTooLarge, len(payload))
+	}
+
+	id := make([]byte, 8)
+
+	if _, err := rand.Read(id); err != nil {
+		return err
+	}
+
+	chunk := make([]byte, 0, w.chunkSize)
+
+	for i := 0; i < count; i++ {
+		end := min((i+1)*size, len(payload))
+
+		chunk = append(chunk[:0], 0x1e, 0x0f)
+		chunk = append(chunk, id...)
+		chunk = append(chunk, byte(i), byte(count))
+		chunk = append(chunk, payload[i*size:end]...)
+
+		if _, err := w.conn.Write(chunk); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close the UDP socket
+func (w *UDPWriter) Close() error {
+	return w.conn.Close()
+}
+
+// Build a new UDP writer, the compression is gzip and the chunk size 1420 by default
+func NewUDPWriter(address string, compression string, chunkSize int) (*UDPWriter, error) {
+	if chunkSize <= chunkHeaderSize {
+		chunkSize = DefaultChunkSize
+	}
+
+	conn, err := net.Dial(ProtocolUDP, address)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &UDPWriter{conn: conn, compress: compression != CompressionNone, chunkSize: chunkSize}, nil
+}
+
+// Sends null terminated messages over a TCP connection, reconnecting after a failure
+type TCPWriter struct {
+	address string
+	mu      sync.Mutex
+	conn    net.Conn
+}
+
+// Send the message, dialing the connection when there is none
+func (w *TCPWriter) Write(m Message) error {
+	payload, err := json.Marshal(m)
+
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.conn == nil {
+		if w.conn, err = net.DialTimeout(ProtocolTCP, w.address, dialTimeout); err != nil {
+			return err
+		}
+	}
+
+	if _, err := w.conn.Write(append(payload, 0)); err != nil {
+		// a partial write leaves the stream unusable
+		w.conn.Close()
+		w.conn = nil
+
+		return err
+	}
+
+	return nil
+}
+
+// Close the TCP connection
+func (w *TCPWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.conn == nil {
+		return nil
+	}
+
+	err := w.conn.Close()
+	w.conn = nil
+
+	return err
+}
+
+// Build a new TCP writer, the connection is dialed on the first message
+func NewTCPWriter(address string) *TCPWriter {
+	return &TCPWriter{address: address}
+}
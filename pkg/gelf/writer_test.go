@@ -0,0 +1,163 @@
+package gelf
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUDPWriter(t *testing.T) {
+	conn := listenUDP(t)
+
+	w, err := NewWriter(Settings{Address: conn.LocalAddr().String()})
+
+	require.Nil(t, err)
+
+	defer w.Close()
+
+	require.Nil(t, w.Write(Message{"short_message": "foo"}))
+
+	assert.Equal(t, Message{"short_message": "foo"}, decodeGzip(t, readDatagram(t, conn)))
+}
+
+func TestUDPWriterChunks(t *testing.T) {
+	conn := listenUDP(t)
+
+	w, err := NewUDPWriter(conn.LocalAddr().String(), CompressionNone, 100)
+
+	require.Nil(t, err)
+
+	defer w.Close()
+
+	message := Message{"short_message": strings.Repeat("a", 250)}
+
+	require.Nil(t, w.Write(message))
+
+	first := readDatagram(t, conn)
+	count := int(first[11])
+
+	// 272 bytes of JSON in chunks of 88 bytes
+	require.Equal(t, 4, count)
+
+	payload := first[12:]
+
+	for i := 1; i < count; i++ {
+		chunk := readDatagram(t, conn)
+
+		require.LessOrEqual(t, len(chunk), 100)
+		require.Equal(t, []byte{0x1e, 0x0f}, chunk[:2])
+		assert.Equal(t, first[2:10], chunk[2:10])
+		assert.Equal(t, byte(i), chunk[10])
+		assert.Equal(t, byte(count), chunk[11])
+
+		payload = append(payload, chunk[12:]...)
+	}
+
+	var decoded Message
+
+	require.Nil(t, json.Unmarshal(payload, &decoded))
+	assert.Equal(t, message, decoded)
+}
+
+func TestUDPWriterTooLarge(t *testing.T) {
+	conn := listenUDP(t)
+
+	w, err := NewUDPWriter(conn.LocalAddr().String(), CompressionNone, 20)
+
+	require.Nil(t, err)
+
+	defer w.Close()
+
+	err = w.Write(Message{"short_message": strings.Repeat("a", 2000)})
+
+	require.ErrorIs(t, err, ErrMessageTooLarge)
+}
+
+func TestTCPWriter(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	require.Nil(t, err)
+
+	defer listener.Close()
+
+	w, err := NewWriter(Settings{Address: listener.Addr().String(), Protocol: ProtocolTCP})
+
+	require.Nil(t, err)
+
+	defer w.Close()
+
+	require.Nil(t, w.Write(Message{"short_message": "foo"}))
+	require.Nil(t, w.Write(Message{"short_message": "bar"}))
+
+	conn, err := listener.Accept()
+
+	require.Nil(t, err)
+
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+
+	for _, expected := range []string{"foo", "bar"} {
+		frame, err := reader.ReadBytes(0)
+
+		require.Nil(t, err)
+
+		var decoded Message
+
+		require.Nil(t, json.Unmarshal(frame[:len(frame)-1], &decoded))
+		assert.Equal(t, expected, decoded["short_message"])
+	}
+}
+
+func TestNewWriterUnsupported(t *testing.T) {
+	_, err := NewWriter(Settings{Address: "localhost:12201", Protocol: "http"})
+
+	require.ErrorIs(t, err, ErrUnsupportedProtocol)
+}
+
+func listenUDP(t *testing.T) *net.UDPConn {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+
+	require.Nil(t, err)
+
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func readDatagram(t *testing.T, conn *net.UDPConn) []byte {
+	buf := make([]byte, 65535)
+
+	require.Nil(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	n, err := conn.Read(buf)
+
+	require.Nil(t, err)
+
+	return buf[:n]
+}
+
+func decodeGzip(t *testing.T, payload []byte) Message {
+	zr, err := gzip.NewReader(bytes.NewReader(payload))
+
+	require.Nil(t, err)
+
+	body, err := io.ReadAll(zr)
+
+	require.Nil(t, err)
+
+	var m Message
+
+	require.Nil(t, json.Unmarshal(body, &m))
+
+	return m
+}
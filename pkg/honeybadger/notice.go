@@ -0,0 +1,148 @@
+// This package accepts the Honeybadger notice API, so Honeybadger SDKs report to BugsChannel
+package honeybadger
+
+import (
+	"strings"
+
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/fingerprint"
+	"github.com/williampsena/bugs-channel/pkg/ingest"
+)
+
+// Represents a Honeybadger notice
+type Notice struct {
+	Notifier    Notifier       `json:"notifier"`
+	Error       NoticeError    `json:"error"`
+	Request     Request        `json:"request"`
+	Server      Server         `json:"server"`
+	Breadcrumbs map[string]any `json:"breadcrumbs"`
+}
+
+// Represents the notifier library of the notice
+type Notifier struct {
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	Language string `json:"language"`
+}
+
+// Represents the notice error
+type NoticeError struct {
+	Class       string          `json:"class"`
+	Message     string          `json:"message"`
+	Tags        []string        `json:"tags"`
+	Fingerprint string          `json:"fingerprint"`
+	Backtrace   []BacktraceLine `json:"backtrace"`
+	Causes      []any           `json:"causes"`
+}
+
+// Represents a backtrace line, the context is app for the project lines
+type BacktraceLine struct {
+	Number  any    `json:"number"`
+	Column  any    `json:"column"`
+	File    string `json:"file"`
+	Method  string `json:"method"`
+	Context string `json:"context"`
+}
+
+// Represents the request the error happened in
+type Request struct {
+	Url       string         `json:"url"`
+	Component string         `json:"component"`
+	Action    string         `json:"action"`
+	Params    map[string]any `json:"params"`
+	Session   map[string]any `json:"session"`
+	CgiData   map[string]any `json:"cgi_data"`
+	Context   map[string]any `json:"context"`
+}
+
+// Represents the server the error happened on
+type Server struct {
+	ProjectRoot     string `json:"project_root"`
+	EnvironmentName string `json:"environment_name"`
+	Hostname        string `json:"hostname"`
+	Revision        string `json:"revision"`
+	Pid             any    `json:"pid"`
+}
+
+// Maps the notice onto a BugsChannel event of the service
+func (n Notice) Event(serviceId string) (event.Event, error) {
+	extra := event.EventExtra{}
+
+	ingest.SetExtra(extra, "context", n.Request.Context)
+	ingest.SetExtra(extra, "params", n.Request.Params)
+	ingest.SetExtra(extra, "session", n.Request.Session)
+	ingest.SetExtra(extra, "cgi_data", n.Request.CgiData)
+	ingest.SetExtra(extra, "url", n.Request.Url)
+	ingest.SetExtra(extra, "component", n.Request.Component)
+	ingest.SetExtra(extra, "action", n.Request.Action)
+	ingest.SetExtra(extra, "project_root", n.Server.ProjectRoot)
+	ingest.SetExtra(extra, "pid", n.Server.Pid)
+	ingest.SetExtra(extra, "causes", n.Error.Causes)
+	ingest.SetExtra(extra, "breadcrumbs", n.Breadcrumbs)
+	ingest.SetExtra(extra, "notifier", n.Notifier.Name+" "+n.Notifier.Version)
+	ingest.SetExtra(extra, fingerprint.ExtraKey, n.Error.Fingerprint)
+
+	tags := ingest.Tags(
+		ingest.Tag("environment", n.Server.EnvironmentName),
+		ingest.Tag("release", n.Server.Revision),
+		ingest.Tag("server_name", n.Server.Hostname),
+		ingest.Tag("component", n.Request.Component),
+		ingest.Tag("action", n.Request.Action),
+	)
+
+	for _, tag := range n.Error.Tags {
+		tags = append(tags, ingest.Tags(ingest.Tag("tag", tag))...)
+	}
+
+	id, err := ingest.NewEventId()
+
+	if err != nil {
+		return event.Event{}, err
+	}
+
+	return event.Event{
+		ID:          id,
+		ServiceId:   serviceId,
+		Platform:    n.platform(),
+		Environment: n.Server.EnvironmentName,
+		Release:     n.Server.Revision,
+		ServerName:  n.Server.Hostname,
+		Title:       n.Error.Class,
+		Body:        n.Error.Message,
+		StackTrace:  n.stackTrace(),
+		Kind:        "error",
+		Level:       "error",
+		Tags:        tags,
+		Extra:       extra,
+	}, nil
+}
+
+// Returns the notifier language, e.g. ruby for honeybadger-ruby
+func (n Notice) platform() string {
+	if n.Notifier.Language != "" {
+		return n.Notifier.Language
+	}
+
+	if language, ok := strings.CutPrefix(n.Notifier.Name, "honeybadger-"); ok {
+		return language
+	}
+
+	return "honeybadger"
+}
+
+// Returns the backtrace as frames, the project lines are in app
+func (n Notice) stackTrace() event.StackTrace {
+	frames := make(event.StackTrace, 0, len(n.Error.Backtrace))
+
+	for _, line := range n.Error.Backtrace {
+		frames = append(frames, map[string]interface{}{
+			"filename": line.File,
+			"function": line.Method,
+			"lineno":   ingest.Number(line.Number),
+			"colno":    ingest.Number(line.Column),
+			"in_app":   line.Context == "app",
+		})
+	}
+
+	return frames
+}
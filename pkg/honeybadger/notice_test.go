@@ -0,0 +1,78 @@
+package honeybadger
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+)
+
+func TestNoticeEvent(t *testing.T) {
+	notice := readNotice(t)
+
+	e, err := notice.Event("1")
+
+	require.Nil(t, err)
+
+	assert.Len(t, e.ID, 32)
+	assert.Equal(t, "1", e.ServiceId)
+	assert.Equal(t, "ruby", e.Platform)
+	assert.Equal(t, "production", e.Environment)
+	assert.Equal(t, "a1b2c3d", e.Release)
+	assert.Equal(t, "web-1", e.ServerName)
+	assert.Equal(t, "RuntimeError", e.Title)
+	assert.Equal(t, "RuntimeError: oops for user 42", e.Body)
+	assert.Equal(t, "error", e.Level)
+
+	assert.Equal(t, []string{
+		"environment:production",
+		"release:a1b2c3d",
+		"server_name:web-1",
+		"component:orders",
+		"action:create",
+		"tag:checkout",
+		"tag:critical",
+	}, e.Tags)
+
+	assert.Equal(t, event.StackTrace{
+		{"filename": "[PROJECT_ROOT]/app/controllers/orders_controller.rb", "function": "create", "lineno": 21, "colno": nil, "in_app": true},
+		{"filename": "[GEM_ROOT]/gems/actionpack-7.1.3/lib/action_controller/metal/basic_implicit_render.rb", "function": "send_action", "lineno": 1034, "colno": nil, "in_app": false},
+	}, e.StackTrace)
+
+	assert.Equal(t, map[string]any{"user_id": float64(42)}, e.Extra["context"])
+	assert.Equal(t, map[string]any{"order_id": "123", "password": "secret"}, e.Extra["params"])
+	assert.Equal(t, "https://shop.example.com/orders", e.Extra["url"])
+	assert.Equal(t, "honeybadger-ruby 5.4.1", e.Extra["notifier"])
+	assert.NotContains(t, e.Extra, "session")
+	assert.NotContains(t, e.Extra, "causes")
+	assert.NotContains(t, e.Extra, "fingerprint")
+}
+
+func TestNoticePlatform(t *testing.T) {
+	assert.Equal(t, "python", Notice{Notifier: Notifier{Name: "honeybadger-python"}}.platform())
+	assert.Equal(t, "honeybadger", Notice{}.platform())
+}
+
+func TestNoticeFingerprint(t *testing.T) {
+	notice := Notice{Error: NoticeError{Class: "RuntimeError", Fingerprint: "checkout"}}
+
+	e, err := notice.Event("1")
+
+	require.Nil(t, err)
+	assert.Equal(t, "checkout", e.Extra["fingerprint"])
+}
+
+func readNotice(t *testing.T) Notice {
+	body, err := os.ReadFile("../../fixtures/honeybadger/notice.json")
+
+	require.Nil(t, err)
+
+	var notice Notice
+
+	require.Nil(t, json.Unmarshal(body, &notice))
+
+	return notice
+}
@@ -0,0 +1,85 @@
+package honeybadger
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	plugin "github.com/williampsena/bugs-channel-plugins/pkg/service"
+	"github.com/williampsena/bugs-channel/pkg/config"
+	"github.com/williampsena/bugs-channel/pkg/ingest"
+)
+
+// The header carrying the project API key, one of the service auth keys
+const ApiKeyHeader = "X-API-Key"
+
+// The Honeybadger server context
+type ServerContext struct {
+	context.Context
+	ServiceFetcher   plugin.ServiceFetcher
+	EventsDispatcher event.EventsDispatcher
+}
+
+// Represents the notice response
+type NoticeResponse struct {
+	Id string `json:"id"`
+}
+
+// Receives a notice, answering with its event id like the Honeybadger API
+func NoticeEndpoint(c *ServerContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		service, err := c.ServiceFetcher.GetServiceByAuthKey(req.Header.Get(ApiKeyHeader))
+
+		if err != nil {
+			ingest.WriteError(w, http.StatusForbidden, errors.Join(ingest.ErrUnauthorized, err))
+			return
+		}
+
+		var notice Notice
+
+		if err := ingest.DecodeJson(w, req, &notice); err != nil {
+			ingest.WriteError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		if notice.Error.Class == "" && notice.Error.Message == "" {
+			ingest.WriteError(w, http.StatusUnprocessableEntity, ingest.ErrInvalidPayload)
+			return
+		}
+
+		e, err := notice.Event(service.Id)
+
+		if err != nil {
+			ingest.WriteError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		if err := c.EventsDispatcher.Dispatch(e); err != nil {
+			ingest.WriteError(w, ingest.DispatchStatus(err), err)
+			return
+		}
+
+		ingest.WriteJson(w, http.StatusCreated, NoticeResponse{Id: e.ID})
+	}
+}
+
+// Build the Honeybadger router
+func BuildRouter(c *ServerContext) *mux.Router {
+	r := mux.NewRouter()
+
+	r.HandleFunc("/v1/notices", NoticeEndpoint(c)).Methods("POST")
+
+	return r
+}
+
+// Build the Honeybadger server listening at HONEYBADGER_PORT
+func BuildServer(c *ServerContext) *http.Server {
+	return ingest.NewServer(config.HoneybadgerPort(), BuildRouter(c))
+}
+
+// Listens the Honeybadger server
+func SetupServer(srv *http.Server) {
+	ingest.ListenAndServe("Honeybadger", srv)
+}
@@ -0,0 +1,103 @@
+package honeybadger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel/pkg/ratelimit"
+	"github.com/williampsena/bugs-channel/pkg/test"
+)
+
+func TestNoticeEndpoint(t *testing.T) {
+	dispatcher := &test.MockDispatcher{}
+	svr := buildTestServer(t, dispatcher)
+
+	res := postNotice(t, svr.URL, "key", readFixture(t))
+
+	require.Equal(t, http.StatusCreated, res.StatusCode)
+
+	var body NoticeResponse
+
+	require.Nil(t, json.NewDecoder(res.Body).Decode(&body))
+	require.Len(t, dispatcher.Events, 1)
+
+	assert.Equal(t, dispatcher.Events[0].ID, body.Id)
+	assert.Equal(t, "1", dispatcher.Events[0].ServiceId)
+	assert.Equal(t, "RuntimeError", dispatcher.Events[0].Title)
+}
+
+func TestNoticeEndpointUnauthorized(t *testing.T) {
+	dispatcher := &test.MockDispatcher{}
+	svr := buildTestServer(t, dispatcher)
+
+	res := postNotice(t, svr.URL, "invalid", readFixture(t))
+
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	assert.Empty(t, dispatcher.Events)
+}
+
+func TestNoticeEndpointInvalid(t *testing.T) {
+	svr := buildTestServer(t, &test.MockDispatcher{})
+
+	for _, body := range []string{`foo`, `{"error": {}}`} {
+		res := postNotice(t, svr.URL, "key", []byte(body))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, res.StatusCode, body)
+	}
+}
+
+func TestNoticeEndpointRateLimited(t *testing.T) {
+	svr := buildTestServer(t, &test.MockDispatcher{Err: fmt.Errorf("%w: service 1", ratelimit.ErrRateLimitExceeded)})
+
+	res := postNotice(t, svr.URL, "key", readFixture(t))
+
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+
+	svr = buildTestServer(t, &test.MockDispatcher{Err: errors.New("queue is down")})
+
+	res = postNotice(t, svr.URL, "key", readFixture(t))
+
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+}
+
+func buildTestServer(t *testing.T, dispatcher *test.MockDispatcher) *httptest.Server {
+	return test.BuildServer(t, BuildRouter(&ServerContext{
+		Context:          context.Background(),
+		ServiceFetcher:   test.BuildServiceFetcher(t),
+		EventsDispatcher: dispatcher,
+	}))
+}
+
+func postNotice(t *testing.T, url string, apiKey string, body []byte) *http.Response {
+	req, err := http.NewRequest(http.MethodPost, url+"/v1/notices", bytes.NewReader(body))
+
+	require.Nil(t, err)
+
+	req.Header.Set(ApiKeyHeader, apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+
+	require.Nil(t, err)
+
+	t.Cleanup(func() { res.Body.Close() })
+
+	return res
+}
+
+func readFixture(t *testing.T) []byte {
+	body, err := os.ReadFile("../../fixtures/honeybadger/notice.json")
+
+	require.Nil(t, err)
+
+	return body
+}
@@ -0,0 +1,75 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/williampsena/bugs-channel/pkg/ratelimit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// The metadata carrying the service auth key
+const AuthKeyMetadata = "x-auth-key"
+
+// Returns the auth key metadata, or the bearer token of the authorization metadata
+func MetadataAuthKey(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if values := md.Get(AuthKeyMetadata); len(values) > 0 && values[0] != "" {
+		return values[0]
+	}
+
+	if values := md.Get("authorization"); len(values) > 0 {
+		return BearerToken(values[0])
+	}
+
+	return ""
+}
+
+// Returns the gRPC code of an ingestion error, clients retry the unavailable and exhausted ones
+func GrpcCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, ErrUnauthorized):
+		return codes.Unauthenticated
+	case errors.Is(err, ErrForbidden):
+		return codes.PermissionDenied
+	case errors.Is(err, ErrInvalidPayload):
+		return codes.InvalidArgument
+	case errors.Is(err, ratelimit.ErrRateLimitExceeded):
+		return codes.ResourceExhausted
+	case errors.Is(err, ErrEventId):
+		return codes.Internal
+	}
+
+	return codes.Unavailable
+}
+
+// Logs and returns the error as a gRPC status
+func GrpcError(err error) error {
+	log.Errorf("⛔ %v", err)
+
+	return status.Error(GrpcCode(err), err.Error())
+}
+
+// Listens the gRPC server at the port until it is stopped
+func ServeGrpc(name string, port int, srv *grpc.Server) {
+	addr := fmt.Sprintf(":%v", port)
+
+	lis, err := net.Listen("tcp", addr)
+
+	if err != nil {
+		log.Fatalf("❌ Unexpected interruption to the %v server's listening: %v", name, err)
+	}
+
+	log.Infof("🐛 %v server listening at %v...", name, addr)
+
+	if err := srv.Serve(lis); err != nil {
+		log.Fatalf("❌ Unexpected interruption to the %v server's listening: %v", name, err)
+	}
+}
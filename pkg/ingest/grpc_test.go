@@ -0,0 +1,42 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/williampsena/bugs-channel/pkg/ratelimit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestMetadataAuthKey(t *testing.T) {
+	cases := []struct {
+		md  metadata.MD
+		key string
+	}{
+		{metadata.Pairs("x-auth-key", "key"), "key"},
+		{metadata.Pairs("authorization", "Bearer key"), "key"},
+		{metadata.Pairs("authorization", "key"), ""},
+		{metadata.MD{}, ""},
+	}
+
+	for _, c := range cases {
+		ctx := metadata.NewIncomingContext(context.Background(), c.md)
+
+		assert.Equal(t, c.key, MetadataAuthKey(ctx), c.md)
+	}
+
+	assert.Equal(t, "", MetadataAuthKey(context.Background()))
+}
+
+func TestGrpcCode(t *testing.T) {
+	assert.Equal(t, codes.Unauthenticated, GrpcCode(errors.Join(ErrUnauthorized, errors.New("foo"))))
+	assert.Equal(t, codes.PermissionDenied, GrpcCode(fmt.Errorf("%w: checkout", ErrForbidden)))
+	assert.Equal(t, codes.InvalidArgument, GrpcCode(fmt.Errorf("%w: foo", ErrInvalidPayload)))
+	assert.Equal(t, codes.ResourceExhausted, GrpcCode(fmt.Errorf("%w: service 1", ratelimit.ErrRateLimitExceeded)))
+	assert.Equal(t, codes.Internal, GrpcCode(errors.Join(ErrEventId, errors.New("foo"))))
+	assert.Equal(t, codes.Unavailable, GrpcCode(errors.New("foo")))
+}
@@ -0,0 +1,239 @@
+// This package contains the helpers shared by the SDK ingestion servers
+package ingest
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/ratelimit"
+)
+
+// Represents an error when the request body is not a valid SDK payload
+var ErrInvalidPayload = errors.New("the payload is invalid")
+
+// Represents an error when the request does not carry a valid service key
+var ErrUnauthorized = errors.New("the authentication key is missing or invalid")
+
+// Represents an error when the service key is not allowed to report as a service
+var ErrForbidden = errors.New("the authentication key is not allowed to report as the service")
+
+// Represents an error when the request body is larger than the accepted size
+var ErrBodyTooLarge = errors.New("the body is too large")
+
+// The maximum size accepted for a decompressed request body
+const MaxBodyBytes = 1 << 20
+
+// Represents an error when the random source could not generate an event id
+var ErrEventId = errors.New("an error occurred while attempting to generate an event id")
+
+// The random source of the event ids
+var randRead = rand.Read
+
+// Returns a new random event id
+func NewEventId() (string, error) {
+	b := make([]byte, 16)
+
+	if _, err := randRead(b); err != nil {
+		return "", errors.Join(ErrEventId, err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// Decodes the JSON body, inflating it when it is gzip or deflate encoded
+func DecodeJson(w http.ResponseWriter, req *http.Request, v any) error {
+	body, err := ReadBody(w, req)
+
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return errors.Join(ErrInvalidPayload, err)
+	}
+
+	return nil
+}
+
+// Reads the body up to the maximum size, inflating it when it is gzip or deflate encoded
+func ReadBody(w http.ResponseWriter, req *http.Request) ([]byte, error) {
+	return ReadBodyLimit(w, req, MaxBodyBytes)
+}
+
+// Reads the body up to the limit, inflating it when it is gzip or deflate encoded
+func ReadBodyLimit(w http.ResponseWriter, req *http.Request, limit int64) ([]byte, error) {
+	var reader io.Reader = http.MaxBytesReader(w, req.Body, limit)
+
+	switch req.Header.Get("Content-Encoding") {
+	case "gzip":
+		zr, err := gzip.NewReader(reader)
+
+		if err != nil {
+			return nil, errors.Join(ErrInvalidPayload, err)
+		}
+
+		defer zr.Close()
+		reader = zr
+	case "deflate":
+		zr, err := zlib.NewReader(reader)
+
+		if err != nil {
+			return nil, errors.Join(ErrInvalidPayload, err)
+		}
+
+		defer zr.Close()
+		reader = zr
+	}
+
+	// a decompressed body is limited as well
+	body, err := io.ReadAll(io.LimitReader(reader, limit+1))
+
+	var maxBytesErr *http.MaxBytesError
+
+	if errors.As(err, &maxBytesErr) || int64(len(body)) > limit {
+		return nil, fmt.Errorf("%w: %w, the limit is %v bytes", ErrInvalidPayload, ErrBodyTooLarge, limit)
+	}
+
+	if err != nil {
+		return nil, errors.Join(ErrInvalidPayload, err)
+	}
+
+	return body, nil
+}
+
+// Returns the status of a body read error, 413 when the body is too large
+func BodyStatus(err error) int {
+	if errors.Is(err, ErrBodyTooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+
+	return http.StatusBadRequest
+}
+
+// Returns the status of a dispatch error, 429 when the service rate limit is exceeded
+func DispatchStatus(err error) int {
+	if errors.Is(err, ratelimit.ErrRateLimitExceeded) {
+		return http.StatusTooManyRequests
+	}
+
+	return http.StatusInternalServerError
+}
+
+// Writes the value as a JSON response with the status
+func WriteJson(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// Logs and writes the error as a JSON response with the status
+func WriteError(w http.ResponseWriter, status int, err error) {
+	log.Errorf("⛔ %v", err)
+	WriteJson(w, status, map[string]string{"error": err.Error()})
+}
+
+// Returns the token of a bearer authorization, empty for other schemes
+func BearerToken(value string) string {
+	if token, ok := strings.CutPrefix(value, "Bearer "); ok {
+		return token
+	}
+
+	return ""
+}
+
+// Returns the key:value tag, empty when the value is empty
+func Tag(key string, value any) string {
+	s := fmt.Sprint(value)
+
+	if value == nil || s == "" {
+		return ""
+	}
+
+	return key + ":" + s
+}
+
+// Returns the non empty tags
+func Tags(tags ...string) []string {
+	result := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		if tag != "" {
+			result = append(result, tag)
+		}
+	}
+
+	return result
+}
+
+// Returns the line or column number, SDKs send it as a number or a string
+func Number(value any) any {
+	switch v := value.(type) {
+	case float64:
+		return int(v)
+	case string:
+		if n, err := strconv.Atoi(v); err == nil {
+			return n
+		}
+
+		return v
+	case nil:
+		return nil
+	}
+
+	return fmt.Sprint(value)
+}
+
+// Returns true when the value is nil, a blank string or an empty map or slice
+func Empty(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case map[string]any:
+		return len(v) == 0
+	case []any:
+		return len(v) == 0
+	}
+
+	return false
+}
+
+// Sets the extra key unless the value is empty
+func SetExtra(extra event.EventExtra, key string, value any) {
+	if !Empty(value) {
+		extra[key] = value
+	}
+}
+
+// Build a new HTTP server of the handler listening at the port
+func NewServer(port int, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:         fmt.Sprintf(":%v", port),
+		Handler:      handler,
+		IdleTimeout:  time.Second * 5,
+		ReadTimeout:  time.Second * 5,
+		WriteTimeout: time.Second * 5,
+	}
+}
+
+// Listens until the server is shut down
+func ListenAndServe(name string, srv *http.Server) {
+	log.Infof("🐛 %v server listening at %v...", name, srv.Addr)
+
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("❌ Unexpected interruption to the %v server's listening: %v", name, err)
+	}
+}
@@ -0,0 +1,150 @@
+package ingest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/ratelimit"
+)
+
+func TestDecodeJson(t *testing.T) {
+	var gz, deflate bytes.Buffer
+
+	gw := gzip.NewWriter(&gz)
+	gw.Write([]byte(`{"foo": "bar"}`))
+	gw.Close()
+
+	zw := zlib.NewWriter(&deflate)
+	zw.Write([]byte(`{"foo": "bar"}`))
+	zw.Close()
+
+	cases := []struct {
+		encoding string
+		body     io.Reader
+	}{
+		{"", strings.NewReader(`{"foo": "bar"}`)},
+		{"gzip", &gz},
+		{"deflate", &deflate},
+	}
+
+	for _, c := range cases {
+		req := httptest.NewRequest(http.MethodPost, "/", c.body)
+		req.Header.Set("Content-Encoding", c.encoding)
+
+		var v map[string]string
+
+		require.Nil(t, DecodeJson(httptest.NewRecorder(), req, &v), c.encoding)
+		assert.Equal(t, map[string]string{"foo": "bar"}, v)
+	}
+}
+
+func TestDecodeJsonInvalid(t *testing.T) {
+	var v map[string]string
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`foo`))
+
+	require.ErrorIs(t, DecodeJson(httptest.NewRecorder(), req, &v), ErrInvalidPayload)
+
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`foo`))
+	req.Header.Set("Content-Encoding", "gzip")
+
+	require.ErrorIs(t, DecodeJson(httptest.NewRecorder(), req, &v), ErrInvalidPayload)
+
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(strings.Repeat("a", MaxBodyBytes+1)))
+
+	require.ErrorIs(t, DecodeJson(httptest.NewRecorder(), req, &v), ErrInvalidPayload)
+}
+
+func TestReadBodyLimit(t *testing.T) {
+	var gz bytes.Buffer
+
+	gw := gzip.NewWriter(&gz)
+	gw.Write([]byte(strings.Repeat("a", 11)))
+	gw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(strings.Repeat("a", 10)))
+	body, err := ReadBodyLimit(httptest.NewRecorder(), req, 10)
+
+	require.Nil(t, err)
+	assert.Len(t, body, 10)
+
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(strings.Repeat("a", 11)))
+	_, err = ReadBodyLimit(httptest.NewRecorder(), req, 10)
+
+	require.ErrorIs(t, err, ErrBodyTooLarge)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, BodyStatus(err))
+
+	// the decompressed body is limited as well
+	req = httptest.NewRequest(http.MethodPost, "/", &gz)
+	req.Header.Set("Content-Encoding", "gzip")
+	_, err = ReadBodyLimit(httptest.NewRecorder(), req, 10)
+
+	require.ErrorIs(t, err, ErrBodyTooLarge)
+	assert.Equal(t, http.StatusBadRequest, BodyStatus(ErrInvalidPayload))
+}
+
+func TestDispatchStatus(t *testing.T) {
+	assert.Equal(t, http.StatusTooManyRequests, DispatchStatus(fmt.Errorf("%w: service 1", ratelimit.ErrRateLimitExceeded)))
+	assert.Equal(t, http.StatusInternalServerError, DispatchStatus(errors.New("foo")))
+}
+
+func TestTags(t *testing.T) {
+	assert.Equal(t, []string{"env:production", "number:1"}, Tags(Tag("env", "production"), Tag("release", ""), Tag("number", 1), Tag("nil", nil)))
+}
+
+func TestNewEventId(t *testing.T) {
+	id, err := NewEventId()
+
+	require.Nil(t, err)
+	assert.Len(t, id, 32)
+
+	other, err := NewEventId()
+
+	require.Nil(t, err)
+	assert.NotEqual(t, id, other)
+
+	randRead = func(b []byte) (int, error) { return 0, io.ErrUnexpectedEOF }
+	t.Cleanup(func() { randRead = rand.Read })
+
+	_, err = NewEventId()
+
+	assert.ErrorIs(t, err, ErrEventId)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestNumber(t *testing.T) {
+	assert.Equal(t, 21, Number(float64(21)))
+	assert.Equal(t, 21, Number("21"))
+	assert.Equal(t, "native", Number("native"))
+	assert.Nil(t, Number(nil))
+}
+
+func TestEmpty(t *testing.T) {
+	assert.True(t, Empty(nil))
+	assert.True(t, Empty(" "))
+	assert.True(t, Empty(map[string]any{}))
+	assert.True(t, Empty([]any{}))
+	assert.False(t, Empty("foo"))
+	assert.False(t, Empty(0))
+}
+
+func TestSetExtra(t *testing.T) {
+	extra := event.EventExtra{}
+
+	SetExtra(extra, "foo", "bar")
+	SetExtra(extra, "baz", map[string]any{})
+
+	assert.Equal(t, event.EventExtra{"foo": "bar"}, extra)
+}
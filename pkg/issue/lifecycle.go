@@ -0,0 +1,163 @@
+// This package tracks the issue lifecycle: occurrences, resolutions, ignores and regressions
+package issue
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/store"
+)
+
+// Represents an error when the issue status change is invalid
+var ErrInvalidStatusChange = errors.New("the issue status change is invalid")
+
+// The tag prefix carrying the event release
+const ReleaseTagPrefix = "release:"
+
+// Represents an event occurrence of an issue
+type Occurrence struct {
+	Title     string
+	Level     string
+	Release   string
+	Timestamp time.Time
+}
+
+// Represents how long an issue is ignored, both zero ignores it forever
+type IgnoreOptions struct {
+	// Ignores the next occurrences
+	Occurrences int64
+	// Ignores the occurrences until the time
+	Until *time.Time
+}
+
+// Build the occurrence of a persisted event
+func NewOccurrence(record store.EventRecord) Occurrence {
+	return Occurrence{
+		Title:     record.Event.Title,
+		Level:     record.Level,
+		Release:   Release(record.Event),
+		Timestamp: record.Timestamp,
+	}
+}
+
+// Returns the event release, falling back to the release attached to the event tags
+func Release(e event.Event) string {
+	if e.Release != "" {
+		return e.Release
+	}
+
+	for _, tag := range e.Tags {
+		if release, ok := strings.CutPrefix(tag, ReleaseTagPrefix); ok {
+			return release
+		}
+	}
+
+	return ""
+}
+
+// Counts the occurrence on the issue, returning true when a resolved issue regressed.
+// An ignored issue becomes unresolved once its ignore expires.
+func Apply(issue *store.Issue, o Occurrence) bool {
+	if issue.Status == "" {
+		issue.Status = store.IssueUnresolved
+		issue.FirstSeen = o.Timestamp
+	}
+
+	issue.Count++
+
+	if o.Timestamp.After(issue.LastSeen) {
+		issue.LastSeen = o.Timestamp
+	}
+
+	if o.Title != "" {
+		issue.Title = o.Title
+	}
+
+	if o.Level != "" {
+		issue.Level = o.Level
+	}
+
+	regressed := false
+
+	switch issue.Status {
+	case store.IssueResolved:
+		regressed = isRegression(issue, o)
+
+		if regressed {
+			Unresolve(issue)
+			issue.RegressedAt = &o.Timestamp
+		}
+	case store.IssueIgnored:
+		if ignoreExpired(issue, o) {
+			Unresolve(issue)
+		}
+	}
+
+	if o.Release != "" {
+		issue.LastRelease = o.Release
+	}
+
+	return regressed
+}
+
+// An occurrence sent before the resolution or by the release resolved in the next one is expected
+func isRegression(issue *store.Issue, o Occurrence) bool {
+	if issue.ResolvedAt != nil && o.Timestamp.Before(*issue.ResolvedAt) {
+		return false
+	}
+
+	if issue.ResolvedInRelease != "" && o.Release == issue.ResolvedInRelease {
+		return false
+	}
+
+	return true
+}
+
+func ignoreExpired(issue *store.Issue, o Occurrence) bool {
+	if issue.IgnoreUntilCount > 0 && issue.Count > issue.IgnoreUntilCount {
+		return true
+	}
+
+	return issue.IgnoreUntil != nil && o.Timestamp.After(*issue.IgnoreUntil)
+}
+
+// Resolves the issue, in the next release the occurrences of the last seen release are still expected
+func Resolve(issue *store.Issue, inNextRelease bool, now time.Time) {
+	Unresolve(issue)
+
+	issue.Status = store.IssueResolved
+	issue.ResolvedAt = &now
+
+	if inNextRelease {
+		issue.ResolvedInRelease = issue.LastRelease
+	}
+}
+
+// Ignores the issue until the next occurrences, a time or forever
+func Ignore(issue *store.Issue, options IgnoreOptions) error {
+	if options.Occurrences < 0 {
+		return ErrInvalidStatusChange
+	}
+
+	Unresolve(issue)
+
+	issue.Status = store.IssueIgnored
+	issue.IgnoreUntil = options.Until
+
+	if options.Occurrences > 0 {
+		issue.IgnoreUntilCount = issue.Count + options.Occurrences
+	}
+
+	return nil
+}
+
+// Marks the issue as unresolved, clearing the resolution and ignore settings
+func Unresolve(issue *store.Issue) {
+	issue.Status = store.IssueUnresolved
+	issue.ResolvedAt = nil
+	issue.ResolvedInRelease = ""
+	issue.IgnoreUntil = nil
+	issue.IgnoreUntilCount = 0
+}
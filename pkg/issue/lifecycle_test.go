@@ -0,0 +1,126 @@
+package issue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/store"
+)
+
+var now = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func TestApplyNewIssue(t *testing.T) {
+	issue := store.Issue{ServiceId: "1", Fingerprint: "abc"}
+
+	regressed := Apply(&issue, Occurrence{Title: "ValueError", Level: "error", Release: "1.0.0", Timestamp: now})
+
+	assert.False(t, regressed)
+	assert.Equal(t, store.IssueUnresolved, issue.Status)
+	assert.Equal(t, int64(1), issue.Count)
+	assert.Equal(t, now, issue.FirstSeen)
+	assert.Equal(t, now, issue.LastSeen)
+	assert.Equal(t, "ValueError", issue.Title)
+	assert.Equal(t, "1.0.0", issue.LastRelease)
+
+	// a late occurrence keeps the last seen
+	Apply(&issue, Occurrence{Timestamp: now.Add(-time.Hour)})
+
+	assert.Equal(t, int64(2), issue.Count)
+	assert.Equal(t, now, issue.LastSeen)
+	assert.Equal(t, "ValueError", issue.Title)
+}
+
+func TestApplyRegression(t *testing.T) {
+	issue := buildTestIssue()
+
+	Resolve(&issue, false, now)
+
+	// sent before the resolution
+	assert.False(t, Apply(&issue, Occurrence{Timestamp: now.Add(-time.Second)}))
+	assert.Equal(t, store.IssueResolved, issue.Status)
+
+	assert.True(t, Apply(&issue, Occurrence{Timestamp: now.Add(time.Second)}))
+	assert.Equal(t, store.IssueUnresolved, issue.Status)
+	assert.Equal(t, now.Add(time.Second), *issue.RegressedAt)
+	assert.Nil(t, issue.ResolvedAt)
+
+	// an unresolved issue does not regress again
+	assert.False(t, Apply(&issue, Occurrence{Timestamp: now.Add(2 * time.Second)}))
+}
+
+func TestApplyResolvedInNextRelease(t *testing.T) {
+	issue := buildTestIssue()
+
+	Resolve(&issue, true, now)
+
+	assert.Equal(t, "1.0.0", issue.ResolvedInRelease)
+
+	assert.False(t, Apply(&issue, Occurrence{Release: "1.0.0", Timestamp: now.Add(time.Second)}))
+	assert.Equal(t, store.IssueResolved, issue.Status)
+
+	assert.True(t, Apply(&issue, Occurrence{Release: "1.1.0", Timestamp: now.Add(time.Second)}))
+	assert.Equal(t, store.IssueUnresolved, issue.Status)
+	assert.Equal(t, "1.1.0", issue.LastRelease)
+}
+
+func TestApplyIgnoreOccurrences(t *testing.T) {
+	issue := buildTestIssue()
+
+	require.Nil(t, Ignore(&issue, IgnoreOptions{Occurrences: 2}))
+
+	assert.Equal(t, int64(3), issue.IgnoreUntilCount)
+
+	assert.False(t, Apply(&issue, Occurrence{Timestamp: now}))
+	assert.False(t, Apply(&issue, Occurrence{Timestamp: now}))
+	assert.Equal(t, store.IssueIgnored, issue.Status)
+
+	assert.False(t, Apply(&issue, Occurrence{Timestamp: now}))
+	assert.Equal(t, store.IssueUnresolved, issue.Status)
+	assert.Zero(t, issue.IgnoreUntilCount)
+}
+
+func TestApplyIgnoreUntil(t *testing.T) {
+	issue := buildTestIssue()
+	until := now.Add(time.Hour)
+
+	require.Nil(t, Ignore(&issue, IgnoreOptions{Until: &until}))
+
+	Apply(&issue, Occurrence{Timestamp: now.Add(time.Minute)})
+
+	assert.Equal(t, store.IssueIgnored, issue.Status)
+
+	Apply(&issue, Occurrence{Timestamp: now.Add(2 * time.Hour)})
+
+	assert.Equal(t, store.IssueUnresolved, issue.Status)
+	assert.Nil(t, issue.IgnoreUntil)
+}
+
+func TestApplyIgnoreForever(t *testing.T) {
+	issue := buildTestIssue()
+
+	require.Nil(t, Ignore(&issue, IgnoreOptions{}))
+
+	for range 10 {
+		assert.False(t, Apply(&issue, Occurrence{Timestamp: now.Add(24 * time.Hour)}))
+	}
+
+	assert.Equal(t, store.IssueIgnored, issue.Status)
+	assert.ErrorIs(t, Ignore(&issue, IgnoreOptions{Occurrences: -1}), ErrInvalidStatusChange)
+}
+
+func TestRelease(t *testing.T) {
+	assert.Equal(t, "", Release(event.Event{Tags: []string{"env:production"}}))
+	assert.Equal(t, "1.0.0", Release(event.Event{Tags: []string{"release:1.0.0"}}))
+	assert.Equal(t, "1.1.0", Release(event.Event{Release: "1.1.0", Tags: []string{"release:1.0.0"}}))
+}
+
+func buildTestIssue() store.Issue {
+	issue := store.Issue{ServiceId: "1", Fingerprint: "abc"}
+
+	Apply(&issue, Occurrence{Title: "ValueError", Release: "1.0.0", Timestamp: now.Add(-time.Hour)})
+
+	return issue
+}
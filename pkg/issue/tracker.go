@@ -0,0 +1,73 @@
+package issue
+
+import (
+	"context"
+	"encoding/json"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/williampsena/bugs-channel/pkg/storage"
+	"github.com/williampsena/bugs-channel/pkg/store"
+)
+
+// The message type of a regressed issue
+const RegressedMessageType = "issue.regressed"
+
+// Represents the message published when a resolved issue occurs again
+type RegressedMessage struct {
+	Type    string      `json:"type"`
+	EventId string      `json:"event_id"`
+	Issue   store.Issue `json:"issue"`
+}
+
+// Counts the persisted events on their issues and publishes the regressions
+type Tracker struct {
+	repo           store.IssueRepository
+	queue          storage.Queue
+	regressedTopic string
+}
+
+// Counts the event on its issue, events without a fingerprint are skipped
+func (t *Tracker) Track(ctx context.Context, record store.EventRecord) error {
+	if record.Fingerprint == "" {
+		return nil
+	}
+
+	var regressed bool
+
+	issue, err := t.repo.UpdateIssue(ctx, record.ServiceId, record.Fingerprint, func(issue *store.Issue) error {
+		regressed = Apply(issue, NewOccurrence(record))
+		return nil
+	})
+
+	if err != nil || !regressed {
+		return err
+	}
+
+	log.Warnf("🔁 Issue %v of service %v regressed", issue.Fingerprint, issue.ServiceId)
+
+	// the occurrence is already counted, a failed publish must not count it again
+	if err := t.publishRegressed(ctx, record.ID, issue); err != nil {
+		log.Errorf("⛔ Something went wrong when publishing the issue %v regression: %v", issue.Fingerprint, err)
+	}
+
+	return nil
+}
+
+func (t *Tracker) publishRegressed(ctx context.Context, eventId string, issue store.Issue) error {
+	if t.queue == nil || t.regressedTopic == "" {
+		return nil
+	}
+
+	body, err := json.Marshal(RegressedMessage{Type: RegressedMessageType, EventId: eventId, Issue: issue})
+
+	if err != nil {
+		return err
+	}
+
+	return t.queue.Publish(storage.WithMessageKey(ctx, issue.ServiceId), t.regressedTopic, string(body))
+}
+
+// Build a new tracker, regressions are published to the topic when the queue is set
+func NewTracker(repo store.IssueRepository, queue storage.Queue, regressedTopic string) *Tracker {
+	return &Tracker{repo, queue, regressedTopic}
+}
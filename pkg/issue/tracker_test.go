@@ -0,0 +1,142 @@
+package issue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/storage"
+	"github.com/williampsena/bugs-channel/pkg/store"
+)
+
+func TestTrackerRegression(t *testing.T) {
+	repo := &mockIssueRepository{issues: map[string]store.Issue{}}
+	queue := &mockQueue{}
+	tracker := NewTracker(repo, queue, "issue.regressed")
+
+	ctx := context.Background()
+	e := event.Event{ID: "foo", ServiceId: "1", Title: "ValueError", Tags: []string{"fingerprint:abc"}}
+
+	require.Nil(t, tracker.Track(ctx, store.NewEventRecord(e, now)))
+	assert.Empty(t, queue.messages)
+
+	_, err := repo.UpdateIssue(ctx, "1", "abc", func(issue *store.Issue) error {
+		Resolve(issue, false, now)
+		return nil
+	})
+
+	require.Nil(t, err)
+
+	// publish failures do not fail the tracking
+	queue.err = errors.New("queue is down")
+
+	e.ID = "bar"
+	require.Nil(t, tracker.Track(ctx, store.NewEventRecord(e, now.Add(time.Minute))))
+
+	queue.err = nil
+
+	_, err = repo.UpdateIssue(ctx, "1", "abc", func(issue *store.Issue) error {
+		Resolve(issue, false, now.Add(time.Hour))
+		return nil
+	})
+
+	require.Nil(t, err)
+
+	e.ID = "baz"
+	require.Nil(t, tracker.Track(ctx, store.NewEventRecord(e, now.Add(2*time.Hour))))
+
+	require.Len(t, queue.messages, 1)
+	assert.Equal(t, "issue.regressed", queue.topic)
+	assert.Equal(t, "1", queue.key)
+
+	var message RegressedMessage
+
+	require.Nil(t, json.Unmarshal([]byte(queue.messages[0]), &message))
+
+	assert.Equal(t, RegressedMessageType, message.Type)
+	assert.Equal(t, "baz", message.EventId)
+	assert.Equal(t, int64(3), message.Issue.Count)
+	assert.Equal(t, store.IssueUnresolved, message.Issue.Status)
+}
+
+func TestTrackerWithoutFingerprint(t *testing.T) {
+	repo := &mockIssueRepository{issues: map[string]store.Issue{}}
+	tracker := NewTracker(repo, nil, "")
+
+	require.Nil(t, tracker.Track(context.Background(), store.NewEventRecord(event.Event{ID: "foo"}, now)))
+
+	assert.Empty(t, repo.issues)
+}
+
+type mockIssueRepository struct {
+	issues map[string]store.Issue
+}
+
+// Updates the issue
+func (m *mockIssueRepository) UpdateIssue(ctx context.Context, serviceId string, fingerprint string, fn func(issue *store.Issue) error) (store.Issue, error) {
+	issue, ok := m.issues[serviceId+fingerprint]
+
+	if !ok {
+		issue = store.Issue{ServiceId: serviceId, Fingerprint: fingerprint}
+	}
+
+	if err := fn(&issue); err != nil {
+		return store.Issue{}, err
+	}
+
+	m.issues[serviceId+fingerprint] = issue
+
+	return issue, nil
+}
+
+// Returns the issue
+func (m *mockIssueRepository) GetIssue(ctx context.Context, serviceId string, fingerprint string) (store.Issue, error) {
+	issue, ok := m.issues[serviceId+fingerprint]
+
+	if !ok {
+		return store.Issue{}, store.ErrIssueNotFound
+	}
+
+	return issue, nil
+}
+
+// Returns every issue
+func (m *mockIssueRepository) ListIssues(ctx context.Context, query store.IssueQuery) ([]store.Issue, error) {
+	var issues []store.Issue
+
+	for _, issue := range m.issues {
+		issues = append(issues, issue)
+	}
+
+	return issues, nil
+}
+
+type mockQueue struct {
+	topic    string
+	key      string
+	messages []string
+	err      error
+}
+
+// Publish a message
+func (m *mockQueue) Publish(ctx context.Context, topic string, message string) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	m.topic = topic
+	m.key = storage.MessageKey(ctx)
+	m.messages = append(m.messages, message)
+
+	return nil
+}
+
+// Subscribe to a topic
+func (m *mockQueue) Subscribe(ctx context.Context, topic string, handler storage.SubscribeHandler) error {
+	return nil
+}
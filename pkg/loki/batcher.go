@@ -0,0 +1,118 @@
+package loki
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/williampsena/bugs-channel/pkg/batch"
+	"github.com/williampsena/bugs-channel/pkg/event"
+)
+
+// Represents the batcher size, interval and retry policy
+type BatcherSettings struct {
+	// How many entries are sent per push
+	BatchSize int
+	// How long a push waits for more entries before being sent
+	FlushInterval time.Duration
+	// The retry policy of the pushes failing with 429, 5xx or a network error
+	Retry event.RetryPolicy
+}
+
+type batchEntry struct {
+	labels map[string]string
+	entry  Entry
+}
+
+// Batches entries into pushes grouped by label set
+type Batcher struct {
+	client   *Client
+	settings BatcherSettings
+	batcher  *batch.Batcher[batchEntry]
+}
+
+// Adds the entry to the next push, returning once it is sent with the error of the push
+func (b *Batcher) Add(ctx context.Context, labels map[string]string, entry Entry) error {
+	return b.batcher.Add(ctx, batchEntry{labels, entry})
+}
+
+// Pushes the entries as streams, a refused push is not retried
+func (b *Batcher) push(ctx context.Context, batch []batchEntry) error {
+	streams := buildStreams(batch)
+
+	var refused error
+
+	attempts, err := b.settings.Retry.Do(ctx, func() error {
+		err := b.client.Push(ctx, streams)
+
+		var pushErr *PushError
+
+		if errors.As(err, &pushErr) && !pushErr.Retryable() {
+			refused = err
+			return nil
+		}
+
+		return err
+	})
+
+	if err == nil {
+		err = refused
+	}
+
+	if err != nil {
+		log.Errorf("⛔ %v events could not be pushed to Loki after %v attempts: %v", len(batch), attempts, err)
+	}
+
+	return err
+}
+
+// Pushes the pending entries and stops
+func (b *Batcher) Close() error {
+	b.batcher.Close()
+	return nil
+}
+
+// Groups the entries by label set, each stream ordered by timestamp
+func buildStreams(batch []batchEntry) []Stream {
+	var streams []Stream
+
+	index := make(map[string]int)
+
+	for _, item := range batch {
+		key := labelsKey(item.labels)
+
+		i, ok := index[key]
+
+		if !ok {
+			i = len(streams)
+			index[key] = i
+			streams = append(streams, Stream{Labels: item.labels})
+		}
+
+		streams[i].Entries = append(streams[i].Entries, item.entry)
+	}
+
+	for _, s := range streams {
+		slices.SortStableFunc(s.Entries, func(a, b Entry) int {
+			return a.Timestamp.Compare(b.Timestamp)
+		})
+	}
+
+	return streams
+}
+
+// Build a new batcher, by default it pushes up to 500 entries at a time
+func NewBatcher(client *Client, settings BatcherSettings) *Batcher {
+	b := &Batcher{client: client, settings: settings}
+
+	// a push is refused or accepted as a whole, so its error is the error of every entry
+	push := func(ctx context.Context, entries []batchEntry) []error {
+		return batch.Errors(len(entries), b.push(ctx, entries))
+	}
+
+	b.batcher = batch.NewBatcher(push, batch.Settings{Size: settings.BatchSize, Linger: settings.FlushInterval})
+
+	return b
+}
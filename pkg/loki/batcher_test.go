@@ -0,0 +1,88 @@
+package loki
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel/pkg/batch"
+	"github.com/williampsena/bugs-channel/pkg/event"
+)
+
+var retry = event.RetryPolicy{Attempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+func TestBatcherStreams(t *testing.T) {
+	loki := newMockLoki(t)
+	batcher := NewBatcher(NewClient(loki.URL, ""), BatcherSettings{Retry: retry})
+
+	defer batcher.Close()
+
+	now := time.Unix(10, 0)
+
+	require.Nil(t, batcher.push(context.Background(), []batchEntry{
+		{map[string]string{"service_id": "1"}, Entry{Timestamp: now.Add(time.Second), Line: "b"}},
+		{map[string]string{"service_id": "2"}, Entry{Timestamp: now, Line: "c"}},
+		{map[string]string{"service_id": "1"}, Entry{Timestamp: now, Line: "a"}},
+	}))
+
+	require.Len(t, loki.pushes, 1)
+
+	var body struct {
+		Streams []struct {
+			Stream map[string]string `json:"stream"`
+			Values [][]string        `json:"values"`
+		} `json:"streams"`
+	}
+
+	require.Nil(t, json.Unmarshal([]byte(loki.pushes[0]), &body))
+	require.Len(t, body.Streams, 2)
+
+	assert.Equal(t, map[string]string{"service_id": "1"}, body.Streams[0].Stream)
+	assert.Equal(t, [][]string{{"10000000000", "a"}, {"11000000000", "b"}}, body.Streams[0].Values)
+	assert.Equal(t, map[string]string{"service_id": "2"}, body.Streams[1].Stream)
+}
+
+func TestBatcherRetry(t *testing.T) {
+	loki := newMockLoki(t)
+	loki.statuses = []int{http.StatusServiceUnavailable, http.StatusTooManyRequests}
+
+	batcher := NewBatcher(NewClient(loki.URL, ""), BatcherSettings{Retry: retry})
+
+	defer batcher.Close()
+
+	require.Nil(t, batcher.Add(context.Background(), map[string]string{}, Entry{Timestamp: time.Now(), Line: "a"}))
+
+	assert.Len(t, loki.pushes, 1)
+}
+
+func TestBatcherRefused(t *testing.T) {
+	loki := newMockLoki(t)
+	loki.statuses = []int{http.StatusBadRequest}
+
+	batcher := NewBatcher(NewClient(loki.URL, ""), BatcherSettings{Retry: retry})
+
+	defer batcher.Close()
+
+	err := batcher.Add(context.Background(), map[string]string{}, Entry{Timestamp: time.Now(), Line: "a"})
+
+	require.ErrorIs(t, err, ErrPush)
+
+	// refused pushes are not retried
+	assert.Empty(t, loki.statuses)
+	assert.Empty(t, loki.pushes)
+}
+
+func TestBatcherClose(t *testing.T) {
+	loki := newMockLoki(t)
+	batcher := NewBatcher(NewClient(loki.URL, ""), BatcherSettings{Retry: retry})
+
+	require.Nil(t, batcher.Add(context.Background(), map[string]string{}, Entry{Timestamp: time.Now(), Line: "a"}))
+	require.Nil(t, batcher.Close())
+
+	assert.Len(t, loki.pushes, 1)
+	assert.ErrorIs(t, batcher.Add(context.Background(), map[string]string{}, Entry{Timestamp: time.Now(), Line: "b"}), batch.ErrClosed)
+}
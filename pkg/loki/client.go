@@ -0,0 +1,123 @@
+// This package pushes events to the Grafana Loki push API as JSON log lines
+package loki
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Represents an error when Loki refuses a push
+var ErrPush = errors.New("the Loki push failed")
+
+// Represents a log stream, the entries sharing a label set
+type Stream struct {
+	Labels  map[string]string
+	Entries []Entry
+}
+
+// Represents a log line of a stream
+type Entry struct {
+	Timestamp time.Time
+	Line      string
+}
+
+// Marshals the entry as the [timestamp in nanoseconds, line] pair of the push API
+func (e Entry) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]string{strconv.FormatInt(e.Timestamp.UnixNano(), 10), e.Line})
+}
+
+type pushStream struct {
+	Stream map[string]string `json:"stream"`
+	Values []Entry           `json:"values"`
+}
+
+type pushRequest struct {
+	Streams []pushStream `json:"streams"`
+}
+
+// Represents a refused push, a client error other than 429 fails again on retry
+type PushError struct {
+	Status int
+	Reason string
+}
+
+// Returns the status and reason
+func (e *PushError) Error() string {
+	return fmt.Sprintf("%v: status %v: %v", ErrPush, e.Status, e.Reason)
+}
+
+// Returns ErrPush
+func (e *PushError) Unwrap() error {
+	return ErrPush
+}
+
+// Indicate that the push may succeed on retry
+func (e *PushError) Retryable() bool {
+	return e.Status == http.StatusTooManyRequests || e.Status >= 500
+}
+
+// The Loki push API client
+type Client struct {
+	url      string
+	tenantId string
+	http     *http.Client
+}
+
+// Push the streams as JSON
+func (c *Client) Push(ctx context.Context, streams []Stream) error {
+	body := pushRequest{Streams: make([]pushStream, 0, len(streams))}
+
+	for _, s := range streams {
+		body.Streams = append(body.Streams, pushStream{Stream: s.Labels, Values: s.Entries})
+	}
+
+	payload, err := json.Marshal(body)
+
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+"/loki/api/v1/push", bytes.NewReader(payload))
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if c.tenantId != "" {
+		req.Header.Set("X-Scope-OrgID", c.tenantId)
+	}
+
+	res, err := c.http.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		reason, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return &PushError{Status: res.StatusCode, Reason: strings.TrimSpace(string(reason))}
+	}
+
+	return nil
+}
+
+// Build a new client of the Loki url, the tenant id is sent when set
+func NewClient(url string, tenantId string) *Client {
+	return &Client{
+		url:      strings.TrimRight(url, "/"),
+		tenantId: tenantId,
+		http:     &http.Client{Timeout: 30 * time.Second},
+	}
+}
@@ -0,0 +1,102 @@
+package loki
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPush(t *testing.T) {
+	loki := newMockLoki(t)
+
+	client := NewClient(loki.URL+"/", "foo")
+
+	err := client.Push(context.Background(), []Stream{
+		{Labels: map[string]string{"job": Job}, Entries: []Entry{{Timestamp: time.Unix(1, 5), Line: `{"id":"foo"}`}}},
+	})
+
+	require.Nil(t, err)
+	require.Len(t, loki.pushes, 1)
+
+	assert.JSONEq(t, `{"streams": [{"stream": {"job": "bugs-channel"}, "values": [["1000000005", "{\"id\":\"foo\"}"]]}]}`, loki.pushes[0])
+	assert.Equal(t, "foo", loki.tenantId)
+}
+
+func TestPushRefused(t *testing.T) {
+	loki := newMockLoki(t)
+	loki.statuses = []int{http.StatusBadRequest, http.StatusTooManyRequests}
+
+	client := NewClient(loki.URL, "")
+
+	err := client.Push(context.Background(), nil)
+
+	var pushErr *PushError
+
+	require.ErrorIs(t, err, ErrPush)
+	require.ErrorAs(t, err, &pushErr)
+	assert.False(t, pushErr.Retryable())
+
+	err = client.Push(context.Background(), nil)
+
+	require.ErrorAs(t, err, &pushErr)
+	assert.True(t, pushErr.Retryable())
+}
+
+// Emulates the push API, answering with the queued statuses and then 204
+type mockLoki struct {
+	*httptest.Server
+	mu       sync.Mutex
+	statuses []int
+	pushes   []string
+	tenantId string
+}
+
+func newMockLoki(t *testing.T) *mockLoki {
+	loki := &mockLoki{}
+	loki.Server = httptest.NewServer(http.HandlerFunc(loki.handle))
+
+	t.Cleanup(loki.Close)
+
+	return loki
+}
+
+func (l *mockLoki) handle(w http.ResponseWriter, req *http.Request) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if req.URL.Path != "/loki/api/v1/push" || req.Header.Get("Content-Type") != "application/json" {
+		http.NotFound(w, req)
+		return
+	}
+
+	l.tenantId = req.Header.Get("X-Scope-OrgID")
+
+	if len(l.statuses) > 0 {
+		status := l.statuses[0]
+		l.statuses = l.statuses[1:]
+
+		http.Error(w, "entry out of order", status)
+		return
+	}
+
+	var body json.RawMessage
+
+	json.NewDecoder(req.Body).Decode(&body)
+	l.pushes = append(l.pushes, string(body))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (l *mockLoki) count() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return len(l.pushes)
+}
@@ -0,0 +1,54 @@
+package loki
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+)
+
+// The job label every stream carries
+const Job = "bugs-channel"
+
+// Returns the stream labels of the event, only the allowed ones are kept and empty values are dropped
+func Labels(e event.Event, allowed []string) map[string]string {
+	values := map[string]string{
+		"service_id":  e.ServiceId,
+		"platform":    e.Platform,
+		"environment": e.Environment,
+		"level":       strings.ToLower(e.Level),
+		"release":     e.Release,
+		"server_name": e.ServerName,
+		"kind":        e.Kind,
+	}
+
+	labels := map[string]string{"job": Job}
+
+	for _, name := range allowed {
+		if value := values[strings.TrimSpace(name)]; value != "" {
+			labels[strings.TrimSpace(name)] = value
+		}
+	}
+
+	return labels
+}
+
+// Returns the label set key, labels are sorted by name
+func labelsKey(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+
+	for name := range labels {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	var sb strings.Builder
+
+	for _, name := range names {
+		fmt.Fprintf(&sb, "%v=%q,", name, labels[name])
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,31 @@
+package loki
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+)
+
+func TestLabels(t *testing.T) {
+	e := event.Event{ID: "foo", ServiceId: "1", Platform: "python", Level: "ERROR", Release: "1.0.0"}
+
+	assert.Equal(t,
+		map[string]string{"job": Job, "service_id": "1", "platform": "python", "level": "error"},
+		Labels(e, []string{"service_id", " platform", "environment", "level", "id"}),
+	)
+
+	assert.Equal(t, map[string]string{"job": Job}, Labels(e, nil))
+}
+
+func TestLabelsKey(t *testing.T) {
+	assert.Equal(t,
+		labelsKey(map[string]string{"a": "1", "b": "2"}),
+		labelsKey(map[string]string{"b": "2", "a": "1"}),
+	)
+
+	assert.NotEqual(t,
+		labelsKey(map[string]string{"a": "1,b=2"}),
+		labelsKey(map[string]string{"a": "1", "b": "2"}),
+	)
+}
@@ -0,0 +1,258 @@
+// This package receives OpenTelemetry traces and logs over OTLP, dispatching their exceptions and error logs as events
+package otlp
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/ingest"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+)
+
+// The span event name of a recorded exception
+const ExceptionEventName = "exception"
+
+// The exception semantic convention attributes
+const (
+	ExceptionType       = "exception.type"
+	ExceptionMessage    = "exception.message"
+	ExceptionStacktrace = "exception.stacktrace"
+)
+
+// Returns the BugsChannel service id of a resource
+type ServiceMapper func(resource map[string]any) (string, error)
+
+// Maps the exception span events of the request onto BugsChannel events
+func TraceEvents(req *coltracepb.ExportTraceServiceRequest, serviceOf ServiceMapper) ([]event.Event, error) {
+	var events []event.Event
+
+	for _, rs := range req.GetResourceSpans() {
+		resource := attributes(rs.GetResource().GetAttributes())
+		serviceId, err := serviceOf(resource)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ss := range rs.GetScopeSpans() {
+			for _, span := range ss.GetSpans() {
+				for _, se := range span.GetEvents() {
+					if se.GetName() != ExceptionEventName {
+						continue
+					}
+
+					attrs := attributes(se.GetAttributes())
+					e, err := newEvent(serviceId, resource, ss.GetScope(), attrs)
+
+					if err != nil {
+						return nil, err
+					}
+
+					e.Level = "error"
+
+					ingest.SetExtra(e.Extra, "trace_id", hex.EncodeToString(span.GetTraceId()))
+					ingest.SetExtra(e.Extra, "span_id", hex.EncodeToString(span.GetSpanId()))
+					ingest.SetExtra(e.Extra, "span_name", span.GetName())
+					ingest.SetExtra(e.Extra, "span_attributes", attributes(span.GetAttributes()))
+					ingest.SetExtra(e.Extra, "timestamp", timestamp(se.GetTimeUnixNano()))
+
+					events = append(events, e)
+				}
+			}
+		}
+	}
+
+	return events, nil
+}
+
+// Maps the error log records of the request onto BugsChannel events
+func LogEvents(req *collogspb.ExportLogsServiceRequest, serviceOf ServiceMapper) ([]event.Event, error) {
+	var events []event.Event
+
+	for _, rl := range req.GetResourceLogs() {
+		resource := attributes(rl.GetResource().GetAttributes())
+		serviceId, err := serviceOf(resource)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, sl := range rl.GetScopeLogs() {
+			for _, record := range sl.GetLogRecords() {
+				level, ok := logLevel(record)
+
+				if !ok {
+					continue
+				}
+
+				attrs := attributes(record.GetAttributes())
+				e, err := newEvent(serviceId, resource, sl.GetScope(), attrs)
+
+				if err != nil {
+					return nil, err
+				}
+
+				e.Level = level
+
+				if e.Body == "" {
+					e.Body = str(anyValue(record.GetBody()))
+				}
+
+				ts := record.GetTimeUnixNano()
+
+				if ts == 0 {
+					ts = record.GetObservedTimeUnixNano()
+				}
+
+				ingest.SetExtra(e.Extra, "trace_id", hex.EncodeToString(record.GetTraceId()))
+				ingest.SetExtra(e.Extra, "span_id", hex.EncodeToString(record.GetSpanId()))
+				ingest.SetExtra(e.Extra, "severity_text", record.GetSeverityText())
+				ingest.SetExtra(e.Extra, "timestamp", timestamp(ts))
+
+				events = append(events, e)
+			}
+		}
+	}
+
+	return events, nil
+}
+
+// Returns the level of an error log record, false when the record is not an error
+func logLevel(record *logspb.LogRecord) (string, bool) {
+	number := record.GetSeverityNumber()
+
+	switch {
+	case number >= logspb.SeverityNumber_SEVERITY_NUMBER_FATAL:
+		return "fatal", true
+	case number >= logspb.SeverityNumber_SEVERITY_NUMBER_ERROR:
+		return "error", true
+	case number != logspb.SeverityNumber_SEVERITY_NUMBER_UNSPECIFIED:
+		return "", false
+	}
+
+	// records without a severity number are judged by their text
+	switch strings.ToLower(record.GetSeverityText()) {
+	case "fatal", "critical":
+		return "fatal", true
+	case "error":
+		return "error", true
+	}
+
+	return "", false
+}
+
+// Build the event of an exception, the resource describes the service that raised it
+func newEvent(serviceId string, resource map[string]any, scope *commonpb.InstrumentationScope, attrs map[string]any) (event.Event, error) {
+	environment := str(resource["deployment.environment.name"])
+
+	if environment == "" {
+		environment = str(resource["deployment.environment"])
+	}
+
+	platform := str(resource["telemetry.sdk.language"])
+
+	if platform == "" {
+		platform = "opentelemetry"
+	}
+
+	id, err := ingest.NewEventId()
+
+	if err != nil {
+		return event.Event{}, err
+	}
+
+	e := event.Event{
+		ID:          id,
+		ServiceId:   serviceId,
+		Platform:    platform,
+		Environment: environment,
+		Release:     str(resource["service.version"]),
+		ServerName:  str(resource["host.name"]),
+		Title:       str(attrs[ExceptionType]),
+		Body:        str(attrs[ExceptionMessage]),
+		Kind:        "error",
+		Extra:       event.EventExtra{},
+	}
+
+	e.Tags = ingest.Tags(
+		ingest.Tag("environment", e.Environment),
+		ingest.Tag("release", e.Release),
+		ingest.Tag("server_name", e.ServerName),
+		ingest.Tag("service_name", resource["service.name"]),
+	)
+
+	// the stack trace is the language's own text, so it is kept as is
+	ingest.SetExtra(e.Extra, "stacktrace", str(attrs[ExceptionStacktrace]))
+
+	delete(attrs, ExceptionType)
+	delete(attrs, ExceptionMessage)
+	delete(attrs, ExceptionStacktrace)
+
+	ingest.SetExtra(e.Extra, "attributes", attrs)
+	ingest.SetExtra(e.Extra, "resource", resource)
+	ingest.SetExtra(e.Extra, "scope", strings.TrimSpace(scope.GetName()+" "+scope.GetVersion()))
+
+	return e, nil
+}
+
+// Returns the attributes as a map
+func attributes(kvs []*commonpb.KeyValue) map[string]any {
+	attrs := make(map[string]any, len(kvs))
+
+	for _, kv := range kvs {
+		attrs[kv.GetKey()] = anyValue(kv.GetValue())
+	}
+
+	return attrs
+}
+
+// Returns the Go value of an attribute value
+func anyValue(v *commonpb.AnyValue) any {
+	switch value := v.GetValue().(type) {
+	case *commonpb.AnyValue_StringValue:
+		return value.StringValue
+	case *commonpb.AnyValue_BoolValue:
+		return value.BoolValue
+	case *commonpb.AnyValue_IntValue:
+		return value.IntValue
+	case *commonpb.AnyValue_DoubleValue:
+		return value.DoubleValue
+	case *commonpb.AnyValue_BytesValue:
+		return hex.EncodeToString(value.BytesValue)
+	case *commonpb.AnyValue_ArrayValue:
+		values := make([]any, 0, len(value.ArrayValue.GetValues()))
+
+		for _, item := range value.ArrayValue.GetValues() {
+			values = append(values, anyValue(item))
+		}
+
+		return values
+	case *commonpb.AnyValue_KvlistValue:
+		return attributes(value.KvlistValue.GetValues())
+	}
+
+	return nil
+}
+
+// Returns the timestamp in RFC 3339, empty when it is not set
+func timestamp(unixNano uint64) string {
+	if unixNano == 0 {
+		return ""
+	}
+
+	return time.Unix(0, int64(unixNano)).UTC().Format(time.RFC3339Nano)
+}
+
+func str(value any) string {
+	if value == nil {
+		return ""
+	}
+
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,101 @@
+package otlp
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestTraceEvents(t *testing.T) {
+	req := &coltracepb.ExportTraceServiceRequest{}
+
+	readRequest(t, "traces.json", req)
+
+	events, err := TraceEvents(req, func(resource map[string]any) (string, error) {
+		assert.Equal(t, "foo bar service", resource["service.name"])
+		return "1", nil
+	})
+
+	require.Nil(t, err)
+
+	require.Len(t, events, 1)
+
+	e := events[0]
+
+	assert.Len(t, e.ID, 32)
+	assert.Equal(t, "1", e.ServiceId)
+	assert.Equal(t, "python", e.Platform)
+	assert.Equal(t, "production", e.Environment)
+	assert.Equal(t, "1.2.0", e.Release)
+	assert.Equal(t, "web-1", e.ServerName)
+	assert.Equal(t, "ValueError", e.Title)
+	assert.Equal(t, "invalid order 123", e.Body)
+	assert.Equal(t, "error", e.Kind)
+	assert.Equal(t, "error", e.Level)
+	assert.Empty(t, e.StackTrace)
+
+	assert.Equal(t, []string{
+		"environment:production",
+		"release:1.2.0",
+		"server_name:web-1",
+		"service_name:foo bar service",
+	}, e.Tags)
+
+	assert.Contains(t, e.Extra["stacktrace"], "line 42, in create")
+	assert.Equal(t, "5b8efff798038103d269b633813fc60c", e.Extra["trace_id"])
+	assert.Equal(t, "eee19b7ec3c1b174", e.Extra["span_id"])
+	assert.Equal(t, "POST /orders", e.Extra["span_name"])
+	assert.Equal(t, map[string]any{"http.request.method": "POST", "http.response.status_code": int64(500)}, e.Extra["span_attributes"])
+	assert.Equal(t, map[string]any{"exception.escaped": true}, e.Extra["attributes"])
+	assert.Equal(t, "2024-05-31T16:08:37.25Z", e.Extra["timestamp"])
+	assert.Equal(t, "opentelemetry.instrumentation.flask 0.46b0", e.Extra["scope"])
+}
+
+func TestLogEvents(t *testing.T) {
+	req := &collogspb.ExportLogsServiceRequest{}
+
+	readRequest(t, "logs.json", req)
+
+	events, err := LogEvents(req, func(resource map[string]any) (string, error) { return "1", nil })
+
+	require.Nil(t, err)
+
+	require.Len(t, events, 2)
+
+	assert.Equal(t, "go", events[0].Platform)
+	assert.Equal(t, "", events[0].Title)
+	assert.Equal(t, "payment gateway timed out", events[0].Body)
+	assert.Equal(t, "error", events[0].Level)
+	assert.Equal(t, map[string]any{"order.id": "123"}, events[0].Extra["attributes"])
+	assert.Equal(t, "5b8efff798038103d269b633813fc60c", events[0].Extra["trace_id"])
+	assert.Equal(t, "ERROR", events[0].Extra["severity_text"])
+	assert.Equal(t, []string{"service_name:checkout"}, events[0].Tags)
+
+	assert.Equal(t, "runtime.Error", events[1].Title)
+	assert.Equal(t, "runtime: out of memory", events[1].Body)
+	assert.Equal(t, "fatal", events[1].Level)
+	assert.Equal(t, "2024-05-31T16:08:38Z", events[1].Extra["timestamp"])
+	assert.NotContains(t, events[1].Extra, "attributes")
+	assert.NotContains(t, events[1].Extra, "trace_id")
+}
+
+func TestTraceEventsWithoutExceptions(t *testing.T) {
+	events, err := TraceEvents(&coltracepb.ExportTraceServiceRequest{}, nil)
+
+	require.Nil(t, err)
+	assert.Empty(t, events)
+}
+
+func readRequest(t *testing.T, name string, msg proto.Message) []byte {
+	body, err := os.ReadFile("../../fixtures/otlp/" + name)
+
+	require.Nil(t, err)
+	require.Nil(t, unmarshal(jsonContentType, body, msg))
+
+	return body
+}
@@ -0,0 +1,56 @@
+package otlp
+
+import (
+	"context"
+
+	"github.com/williampsena/bugs-channel/pkg/config"
+	"github.com/williampsena/bugs-channel/pkg/ingest"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+)
+
+// The OTLP/gRPC trace service
+type traceService struct {
+	coltracepb.UnimplementedTraceServiceServer
+	c *ServerContext
+}
+
+// Receives an OTLP/gRPC traces export
+func (s *traceService) Export(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+	if err := s.c.ExportTraces(ingest.MetadataAuthKey(ctx), req); err != nil {
+		return nil, ingest.GrpcError(err)
+	}
+
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+// The OTLP/gRPC logs service
+type logsService struct {
+	collogspb.UnimplementedLogsServiceServer
+	c *ServerContext
+}
+
+// Receives an OTLP/gRPC logs export
+func (s *logsService) Export(ctx context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	if err := s.c.ExportLogs(ingest.MetadataAuthKey(ctx), req); err != nil {
+		return nil, ingest.GrpcError(err)
+	}
+
+	return &collogspb.ExportLogsServiceResponse{}, nil
+}
+
+// Build the OTLP/gRPC server with the trace and logs services
+func BuildGrpcServer(c *ServerContext) *grpc.Server {
+	srv := grpc.NewServer(grpc.MaxRecvMsgSize(int(config.OtlpMaxBodyBytes())))
+
+	coltracepb.RegisterTraceServiceServer(srv, &traceService{c: c})
+	collogspb.RegisterLogsServiceServer(srv, &logsService{c: c})
+
+	return srv
+}
+
+// Listens the OTLP/gRPC server at OTLP_GRPC_PORT
+func SetupGrpcServer(srv *grpc.Server) {
+	ingest.ServeGrpc("OTLP/gRPC", config.OtlpGrpcPort(), srv)
+}
@@ -0,0 +1,71 @@
+package otlp
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel/pkg/test"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestGrpcTraceExport(t *testing.T) {
+	dispatcher := &test.MockDispatcher{}
+	conn := buildTestGrpcConn(t, dispatcher)
+	req := &coltracepb.ExportTraceServiceRequest{}
+
+	readRequest(t, "traces.json", req)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-auth-key", "key")
+
+	_, err := coltracepb.NewTraceServiceClient(conn).Export(ctx, req)
+
+	require.Nil(t, err)
+	require.Len(t, dispatcher.Events, 1)
+	assert.Equal(t, "1", dispatcher.Events[0].ServiceId)
+}
+
+func TestGrpcLogsExport(t *testing.T) {
+	dispatcher := &test.MockDispatcher{}
+	conn := buildTestGrpcConn(t, dispatcher)
+	req := &collogspb.ExportLogsServiceRequest{}
+
+	readRequest(t, "logs.json", req)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer key")
+
+	_, err := collogspb.NewLogsServiceClient(conn).Export(ctx, req)
+
+	require.Nil(t, err)
+	require.Len(t, dispatcher.Events, 2)
+}
+
+func TestGrpcExportErrors(t *testing.T) {
+	dispatcher := &test.MockDispatcher{}
+	conn := buildTestGrpcConn(t, dispatcher)
+	req := &collogspb.ExportLogsServiceRequest{}
+
+	readRequest(t, "logs.json", req)
+
+	_, err := collogspb.NewLogsServiceClient(conn).Export(context.Background(), req)
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	dispatcher.Err = errors.New("queue is down")
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-auth-key", "key")
+
+	_, err = collogspb.NewLogsServiceClient(conn).Export(ctx, req)
+
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func buildTestGrpcConn(t *testing.T, dispatcher *test.MockDispatcher) *grpc.ClientConn {
+	return test.BuildGrpcConn(t, BuildGrpcServer(buildTestContext(dispatcher)))
+}
@@ -0,0 +1,201 @@
+package otlp
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+	"github.com/williampsena/bugs-channel/pkg/config"
+	"github.com/williampsena/bugs-channel/pkg/ingest"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Represents an error when the request is neither protobuf nor JSON
+var ErrUnsupportedContentType = errors.New("the content type is not supported, use application/x-protobuf or application/json")
+
+const (
+	protobufContentType = "application/x-protobuf"
+	jsonContentType     = "application/json"
+)
+
+// The OTLP/JSON fields encoded as hex instead of the protobuf JSON base64
+var hexFields = map[string]bool{"traceId": true, "spanId": true, "parentSpanId": true}
+
+// Receives an OTLP/HTTP traces export
+func TracesEndpoint(c *ServerContext) http.HandlerFunc {
+	return exportEndpoint(c, &coltracepb.ExportTraceServiceRequest{}, &coltracepb.ExportTraceServiceResponse{}, func(key string, msg proto.Message) error {
+		return c.ExportTraces(key, msg.(*coltracepb.ExportTraceServiceRequest))
+	})
+}
+
+// Receives an OTLP/HTTP logs export
+func LogsEndpoint(c *ServerContext) http.HandlerFunc {
+	return exportEndpoint(c, &collogspb.ExportLogsServiceRequest{}, &collogspb.ExportLogsServiceResponse{}, func(key string, msg proto.Message) error {
+		return c.ExportLogs(key, msg.(*collogspb.ExportLogsServiceRequest))
+	})
+}
+
+func exportEndpoint(c *ServerContext, request proto.Message, response proto.Message, export func(string, proto.Message) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		contentType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+
+		if contentType != protobufContentType && contentType != jsonContentType {
+			writeStatus(w, protobufContentType, http.StatusUnsupportedMediaType, ErrUnsupportedContentType)
+			return
+		}
+
+		body, err := ingest.ReadBodyLimit(w, req, config.OtlpMaxBodyBytes())
+
+		if err != nil {
+			writeStatus(w, contentType, ingest.BodyStatus(err), err)
+			return
+		}
+
+		msg := proto.Clone(request)
+		proto.Reset(msg)
+
+		if err := unmarshal(contentType, body, msg); err != nil {
+			writeStatus(w, contentType, http.StatusBadRequest, errors.Join(ingest.ErrInvalidPayload, err))
+			return
+		}
+
+		if err := export(requestAuthKey(req), msg); err != nil {
+			writeStatus(w, contentType, httpStatus(err), err)
+			return
+		}
+
+		writeMessage(w, contentType, http.StatusOK, response)
+	}
+}
+
+// Returns the auth key header, or the bearer token of the authorization header
+func requestAuthKey(req *http.Request) string {
+	if key := req.Header.Get(AuthKeyHeader); key != "" {
+		return key
+	}
+
+	return ingest.BearerToken(req.Header.Get("Authorization"))
+}
+
+// Returns the status of an export error
+func httpStatus(err error) int {
+	if errors.Is(err, ingest.ErrUnauthorized) {
+		return http.StatusUnauthorized
+	}
+
+	if errors.Is(err, ingest.ErrForbidden) {
+		return http.StatusForbidden
+	}
+
+	return ingest.DispatchStatus(err)
+}
+
+// Decodes the protobuf or JSON body, the JSON ids are hex as the OTLP specification says
+func unmarshal(contentType string, body []byte, msg proto.Message) error {
+	if contentType == protobufContentType {
+		return proto.Unmarshal(body, msg)
+	}
+
+	var v any
+
+	if err := json.Unmarshal(body, &v); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(hexToBase64(v))
+
+	if err != nil {
+		return err
+	}
+
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(b, msg)
+}
+
+// Encodes the hex id fields as base64, so the protobuf JSON decoder accepts them
+func hexToBase64(v any) any {
+	switch value := v.(type) {
+	case map[string]any:
+		for key, item := range value {
+			if s, ok := item.(string); ok && hexFields[key] {
+				if b, err := hex.DecodeString(s); err == nil {
+					value[key] = base64.StdEncoding.EncodeToString(b)
+				}
+
+				continue
+			}
+
+			value[key] = hexToBase64(item)
+		}
+	case []any:
+		for i, item := range value {
+			value[i] = hexToBase64(item)
+		}
+	}
+
+	return v
+}
+
+// Writes the message in the content type of the request
+func writeMessage(w http.ResponseWriter, contentType string, status int, msg proto.Message) {
+	var body []byte
+	var err error
+
+	if contentType == jsonContentType {
+		body, err = protojson.Marshal(msg)
+	} else {
+		body, err = proto.Marshal(msg)
+	}
+
+	if err != nil {
+		log.Errorf("⛔ %v", err)
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// Logs and writes the error as a google.rpc.Status, as the OTLP specification says
+func writeStatus(w http.ResponseWriter, contentType string, code int, err error) {
+	log.Errorf("⛔ %v", err)
+	writeMessage(w, contentType, code, &status.Status{Code: int32(grpcCode(err)), Message: err.Error()})
+}
+
+// Returns the gRPC code of an export error
+func grpcCode(err error) codes.Code {
+	if errors.Is(err, ErrUnsupportedContentType) {
+		return codes.InvalidArgument
+	}
+
+	return ingest.GrpcCode(err)
+}
+
+// Build the OTLP/HTTP router
+func BuildRouter(c *ServerContext) *mux.Router {
+	r := mux.NewRouter()
+
+	r.HandleFunc("/v1/traces", TracesEndpoint(c)).Methods("POST")
+	r.HandleFunc("/v1/logs", LogsEndpoint(c)).Methods("POST")
+
+	return r
+}
+
+// Build the OTLP/HTTP server listening at OTLP_HTTP_PORT
+func BuildServer(c *ServerContext) *http.Server {
+	return ingest.NewServer(config.OtlpHttpPort(), BuildRouter(c))
+}
+
+// Listens the OTLP/HTTP server
+func SetupServer(srv *http.Server) {
+	ingest.ListenAndServe("OTLP/HTTP", srv)
+}
@@ -0,0 +1,162 @@
+package otlp
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel/pkg/ratelimit"
+	"github.com/williampsena/bugs-channel/pkg/test"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestTracesEndpointProtobuf(t *testing.T) {
+	dispatcher := &test.MockDispatcher{}
+	svr := buildTestServer(t, dispatcher)
+	req := &coltracepb.ExportTraceServiceRequest{}
+
+	readRequest(t, "traces.json", req)
+
+	body, err := proto.Marshal(req)
+
+	require.Nil(t, err)
+
+	res := postExport(t, svr.URL+"/v1/traces", protobufContentType, map[string]string{"Authorization": "Bearer key"}, body)
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, protobufContentType, res.Header.Get("Content-Type"))
+	require.Len(t, dispatcher.Events, 1)
+	assert.Equal(t, "ValueError", dispatcher.Events[0].Title)
+	assert.Equal(t, "5b8efff798038103d269b633813fc60c", dispatcher.Events[0].Extra["trace_id"])
+}
+
+func TestLogsEndpointJson(t *testing.T) {
+	dispatcher := &test.MockDispatcher{}
+	svr := buildTestServer(t, dispatcher)
+
+	res := postExport(t, svr.URL+"/v1/logs", "application/json; charset=utf-8", map[string]string{AuthKeyHeader: "key"}, readRequest(t, "logs.json", &collogspb.ExportLogsServiceRequest{}))
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, jsonContentType, res.Header.Get("Content-Type"))
+	require.Len(t, dispatcher.Events, 2)
+	assert.Equal(t, "eee19b7ec3c1b174", dispatcher.Events[0].Extra["span_id"])
+}
+
+func TestExportEndpointErrors(t *testing.T) {
+	cases := []struct {
+		name        string
+		contentType string
+		authKey     string
+		body        string
+		err         error
+		status      int
+		code        codes.Code
+	}{
+		{"unauthorized", jsonContentType, "invalid", `{}`, nil, http.StatusUnauthorized, codes.Unauthenticated},
+		{"invalid", jsonContentType, "key", `{"resourceLogs": 1}`, nil, http.StatusBadRequest, codes.InvalidArgument},
+		{"content type", "text/plain", "key", `{}`, nil, http.StatusUnsupportedMediaType, codes.InvalidArgument},
+		{"rate limited", jsonContentType, "key", `{}`, fmt.Errorf("%w: service 1", ratelimit.ErrRateLimitExceeded), http.StatusTooManyRequests, codes.ResourceExhausted},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			dispatcher := &test.MockDispatcher{Err: c.err}
+			svr := buildTestServer(t, dispatcher)
+
+			body := c.body
+
+			// an empty export dispatches nothing, so the dispatcher error needs an event
+			if c.err != nil {
+				body = string(readRequest(t, "logs.json", &collogspb.ExportLogsServiceRequest{}))
+			}
+
+			res := postExport(t, svr.URL+"/v1/logs", c.contentType, map[string]string{AuthKeyHeader: c.authKey}, []byte(body))
+
+			require.Equal(t, c.status, res.StatusCode)
+
+			b, err := io.ReadAll(res.Body)
+
+			require.Nil(t, err)
+
+			var s status.Status
+
+			if res.Header.Get("Content-Type") == jsonContentType {
+				require.Nil(t, protojson.Unmarshal(b, &s))
+			} else {
+				require.Nil(t, proto.Unmarshal(b, &s))
+			}
+
+			assert.Equal(t, int32(c.code), s.Code)
+			assert.NotEmpty(t, s.Message)
+		})
+	}
+}
+
+func TestExportEndpointForbidden(t *testing.T) {
+	dispatcher := &test.MockDispatcher{}
+	svr := buildTestServer(t, dispatcher)
+	req := &collogspb.ExportLogsServiceRequest{}
+
+	readRequest(t, "logs.json", req)
+	setServiceName(req, "billing")
+
+	body, err := proto.Marshal(req)
+
+	require.Nil(t, err)
+
+	res := postExport(t, svr.URL+"/v1/logs", protobufContentType, map[string]string{AuthKeyHeader: "key"}, body)
+
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	assert.Empty(t, dispatcher.Events)
+}
+
+func TestExportEndpointBodyLimit(t *testing.T) {
+	svr := buildTestServer(t, &test.MockDispatcher{})
+
+	// exports are accepted above the SDK payload limit
+	body := []byte(`{"resourceLogs": []}` + strings.Repeat(" ", 2<<20))
+	res := postExport(t, svr.URL+"/v1/logs", jsonContentType, map[string]string{AuthKeyHeader: "key"}, body)
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	t.Setenv("OTLP_MAX_BODY_BYTES", "1024")
+
+	res = postExport(t, svr.URL+"/v1/logs", jsonContentType, map[string]string{AuthKeyHeader: "key"}, body)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, res.StatusCode)
+}
+
+func buildTestServer(t *testing.T, dispatcher *test.MockDispatcher) *httptest.Server {
+	return test.BuildServer(t, BuildRouter(buildTestContext(dispatcher)))
+}
+
+func postExport(t *testing.T, url string, contentType string, headers map[string]string, body []byte) *http.Response {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+
+	require.Nil(t, err)
+
+	req.Header.Set("Content-Type", contentType)
+
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+
+	require.Nil(t, err)
+
+	t.Cleanup(func() { res.Body.Close() })
+
+	return res
+}
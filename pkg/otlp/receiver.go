@@ -0,0 +1,103 @@
+package otlp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	plugin "github.com/williampsena/bugs-channel-plugins/pkg/service"
+	"github.com/williampsena/bugs-channel/pkg/ingest"
+	"github.com/williampsena/bugs-channel/pkg/service"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+)
+
+// The header carrying the service auth key, exporters set it through OTEL_EXPORTER_OTLP_HEADERS
+const AuthKeyHeader = "X-Auth-Key"
+
+// Returns the service of a resource service.name, matching the service name or id, when the auth key is valid for it
+type ServiceResolver interface {
+	GetServiceByName(name string, authKey string) (plugin.Service, error)
+}
+
+// The OTLP server context, shared by the HTTP and gRPC receivers
+type ServerContext struct {
+	context.Context
+	ServiceFetcher plugin.ServiceFetcher
+	// Maps the resource service.name onto a service, nil reports every resource as the authenticated service
+	ServiceResolver  ServiceResolver
+	EventsDispatcher event.EventsDispatcher
+}
+
+// Dispatches the exception span events of the request, authenticated by the auth key
+func (c *ServerContext) ExportTraces(authKey string, req *coltracepb.ExportTraceServiceRequest) error {
+	serviceOf, err := c.serviceMapper(authKey)
+
+	if err != nil {
+		return err
+	}
+
+	events, err := TraceEvents(req, serviceOf)
+
+	if err != nil {
+		return err
+	}
+
+	return c.dispatch(events)
+}
+
+// Dispatches the error log records of the request, authenticated by the auth key
+func (c *ServerContext) ExportLogs(authKey string, req *collogspb.ExportLogsServiceRequest) error {
+	serviceOf, err := c.serviceMapper(authKey)
+
+	if err != nil {
+		return err
+	}
+
+	events, err := LogEvents(req, serviceOf)
+
+	if err != nil {
+		return err
+	}
+
+	return c.dispatch(events)
+}
+
+// Returns the service mapper of the auth key, a resource is reported as the service of its service.name when the
+// key is valid for it, as the authenticated service when no service has that name, and refused otherwise
+func (c *ServerContext) serviceMapper(authKey string) (ServiceMapper, error) {
+	authenticated, err := c.ServiceFetcher.GetServiceByAuthKey(authKey)
+
+	if err != nil {
+		return nil, errors.Join(ingest.ErrUnauthorized, err)
+	}
+
+	return func(resource map[string]any) (string, error) {
+		name, _ := resource["service.name"].(string)
+
+		if c.ServiceResolver == nil || name == "" {
+			return authenticated.Id, nil
+		}
+
+		s, err := c.ServiceResolver.GetServiceByName(name, authKey)
+
+		if errors.Is(err, service.ErrServiceForbidden) {
+			return "", fmt.Errorf("%w: %v: %w", ingest.ErrForbidden, name, err)
+		}
+
+		if err != nil {
+			return authenticated.Id, nil
+		}
+
+		return s.Id, nil
+	}, nil
+}
+
+func (c *ServerContext) dispatch(events []event.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	return c.EventsDispatcher.DispatchMany(events)
+}
@@ -0,0 +1,94 @@
+package otlp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel/pkg/ingest"
+	"github.com/williampsena/bugs-channel/pkg/service"
+	"github.com/williampsena/bugs-channel/pkg/settings"
+	"github.com/williampsena/bugs-channel/pkg/test"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+)
+
+func TestExportLogs(t *testing.T) {
+	dispatcher := &test.MockDispatcher{}
+	c := buildTestContext(dispatcher)
+	req := &collogspb.ExportLogsServiceRequest{}
+
+	readRequest(t, "logs.json", req)
+
+	require.Nil(t, c.ExportLogs("key", req))
+	require.Len(t, dispatcher.Events, 2)
+
+	// the key is valid for the checkout service, so the checkout resource is reported as it
+	assert.Equal(t, "2", dispatcher.Events[0].ServiceId)
+	assert.Equal(t, "2", dispatcher.Events[1].ServiceId)
+}
+
+func TestExportLogsUnknownService(t *testing.T) {
+	dispatcher := &test.MockDispatcher{}
+	c := buildTestContext(dispatcher)
+	req := &collogspb.ExportLogsServiceRequest{}
+
+	readRequest(t, "logs.json", req)
+	setServiceName(req, "unknown")
+
+	require.Nil(t, c.ExportLogs("key", req))
+	require.Len(t, dispatcher.Events, 2)
+
+	// no service has that name, so the resource is reported as the authenticated service
+	assert.Equal(t, "1", dispatcher.Events[0].ServiceId)
+}
+
+func TestExportLogsForbiddenService(t *testing.T) {
+	dispatcher := &test.MockDispatcher{}
+	c := buildTestContext(dispatcher)
+	req := &collogspb.ExportLogsServiceRequest{}
+
+	readRequest(t, "logs.json", req)
+	setServiceName(req, "billing")
+
+	err := c.ExportLogs("key", req)
+
+	require.ErrorIs(t, err, ingest.ErrForbidden)
+	require.ErrorIs(t, err, service.ErrServiceForbidden)
+	require.Empty(t, dispatcher.Events)
+}
+
+func TestExportUnauthorized(t *testing.T) {
+	dispatcher := &test.MockDispatcher{}
+	c := buildTestContext(dispatcher)
+
+	require.ErrorIs(t, c.ExportLogs("invalid", &collogspb.ExportLogsServiceRequest{}), ingest.ErrUnauthorized)
+	require.Empty(t, dispatcher.Events)
+}
+
+func buildTestContext(dispatcher *test.MockDispatcher) *ServerContext {
+	fetcher := service.NewYAMLServiceFetcher([]settings.ConfigFileService{
+		{Id: "1", Name: "foo", AuthKeys: []settings.ConfigFileServiceAuthKey{{Key: "key"}}},
+		{Id: "2", Name: "checkout", AuthKeys: []settings.ConfigFileServiceAuthKey{{Key: "key"}}},
+		{Id: "3", Name: "billing", AuthKeys: []settings.ConfigFileServiceAuthKey{{Key: "billing_key"}}},
+	})
+
+	return &ServerContext{
+		Context:          context.Background(),
+		ServiceFetcher:   fetcher,
+		ServiceResolver:  fetcher.(ServiceResolver),
+		EventsDispatcher: dispatcher,
+	}
+}
+
+// Sets the service.name of every resource of the request
+func setServiceName(req *collogspb.ExportLogsServiceRequest, name string) {
+	for _, rl := range req.GetResourceLogs() {
+		for _, attr := range rl.GetResource().GetAttributes() {
+			if attr.GetKey() == "service.name" {
+				attr.Value = &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: name}}
+			}
+		}
+	}
+}
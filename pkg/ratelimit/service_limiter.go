@@ -0,0 +1,87 @@
+// This package provides rate limiting keyed by service
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/didip/tollbooth/v7"
+	"github.com/didip/tollbooth/v7/limiter"
+	"github.com/williampsena/bugs-channel/pkg/settings"
+)
+
+// Represents an error when a service exceeds its rate limit
+var ErrRateLimitExceeded = errors.New("the service has exceeded its rate limit")
+
+// Represents the outcome of a rate limit check
+type Result struct {
+	// Indicate that the request is allowed
+	Allowed bool
+	// The requests allowed per second, zero means unlimited
+	Limit int
+	// The requests left in the current window
+	Remaining int
+	// How long the client should wait before retrying
+	RetryAfter time.Duration
+}
+
+// Represents a service refused by its rate limit
+type LimitError struct {
+	ServiceId string
+	Result    Result
+}
+
+// Returns the service and how long it should wait
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%v: service %v, retry after %v", ErrRateLimitExceeded, e.ServiceId, e.Result.RetryAfter)
+}
+
+// Returns ErrRateLimitExceeded
+func (e *LimitError) Unwrap() error {
+	return ErrRateLimitExceeded
+}
+
+// Limits requests per service using the service settings rate limit
+type ServiceLimiter struct {
+	limiters map[string]*limiter.Limiter
+}
+
+// Check if the service is allowed to perform one more request
+func (s *ServiceLimiter) Allow(serviceId string) Result {
+	lmt, ok := s.limiters[serviceId]
+
+	if !ok {
+		return Result{Allowed: true}
+	}
+
+	limit := int(lmt.GetMax())
+	retryAfter := time.Duration(math.Ceil(1/lmt.GetMax())) * time.Second
+
+	httpErr, remaining := tollbooth.LimitByKeysAndReturn(lmt, []string{serviceId})
+
+	if httpErr != nil {
+		return Result{Allowed: false, Limit: limit, Remaining: 0, RetryAfter: retryAfter}
+	}
+
+	return Result{Allowed: true, Limit: limit, Remaining: remaining, RetryAfter: retryAfter}
+}
+
+// Build a new service limiter from the configuration file services
+func NewServiceLimiter(services []settings.ConfigFileService) *ServiceLimiter {
+	limiters := make(map[string]*limiter.Limiter)
+
+	for _, s := range services {
+		if s.Settings.RateLimit <= 0 {
+			continue
+		}
+
+		lmt := tollbooth.NewLimiter(float64(s.Settings.RateLimit), nil)
+		lmt.SetTokenBucketExpirationTTL(time.Minute)
+
+		limiters[s.Id] = lmt
+	}
+
+	return &ServiceLimiter{limiters}
+}
@@ -0,0 +1,42 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/williampsena/bugs-channel/pkg/settings"
+)
+
+func TestServiceLimiterAllow(t *testing.T) {
+	limiter := NewServiceLimiter([]settings.ConfigFileService{
+		{Id: "1", Settings: settings.ConfigFileServiceSettings{RateLimit: 1}},
+		{Id: "2", Settings: settings.ConfigFileServiceSettings{RateLimit: 2}},
+	})
+
+	result := limiter.Allow("1")
+
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 1, result.Limit)
+	assert.Equal(t, time.Second, result.RetryAfter)
+
+	result = limiter.Allow("1")
+
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	assert.True(t, limiter.Allow("2").Allowed)
+	assert.True(t, limiter.Allow("2").Allowed)
+	assert.False(t, limiter.Allow("2").Allowed)
+}
+
+func TestServiceLimiterUnlimited(t *testing.T) {
+	limiter := NewServiceLimiter([]settings.ConfigFileService{
+		{Id: "1", Settings: settings.ConfigFileServiceSettings{RateLimit: 0}},
+	})
+
+	for i := 0; i < 10; i++ {
+		assert.True(t, limiter.Allow("1").Allowed)
+		assert.True(t, limiter.Allow("unknown").Allowed)
+	}
+}
@@ -0,0 +1,275 @@
+// This package accepts the Rollbar item API, so Rollbar SDKs report to BugsChannel
+package rollbar
+
+import (
+	"strings"
+
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/fingerprint"
+	"github.com/williampsena/bugs-channel/pkg/ingest"
+)
+
+// Represents a Rollbar item
+type Item struct {
+	AccessToken string `json:"access_token"`
+	Data        Data   `json:"data"`
+}
+
+// Represents the item data
+type Data struct {
+	Environment string         `json:"environment"`
+	Body        Body           `json:"body"`
+	Level       string         `json:"level"`
+	Timestamp   any            `json:"timestamp"`
+	CodeVersion string         `json:"code_version"`
+	Platform    string         `json:"platform"`
+	Language    string         `json:"language"`
+	Framework   string         `json:"framework"`
+	Context     string         `json:"context"`
+	Request     map[string]any `json:"request"`
+	Person      map[string]any `json:"person"`
+	Server      Server         `json:"server"`
+	Client      map[string]any `json:"client"`
+	Custom      map[string]any `json:"custom"`
+	Fingerprint string         `json:"fingerprint"`
+	Title       string         `json:"title"`
+	Uuid        string         `json:"uuid"`
+	Notifier    map[string]any `json:"notifier"`
+}
+
+// Represents the item body, one of trace, trace chain or message is set
+type Body struct {
+	Trace      *Trace         `json:"trace"`
+	TraceChain []Trace        `json:"trace_chain"`
+	Message    map[string]any `json:"message"`
+}
+
+// Represents an exception and its frames, the most recent call last
+type Trace struct {
+	Frames    []Frame   `json:"frames"`
+	Exception Exception `json:"exception"`
+}
+
+// Represents a stack frame
+type Frame struct {
+	Filename  string         `json:"filename"`
+	Lineno    any            `json:"lineno"`
+	Colno     any            `json:"colno"`
+	Method    string         `json:"method"`
+	Code      string         `json:"code"`
+	ClassName string         `json:"class_name"`
+	Context   FrameContext   `json:"context"`
+	Locals    map[string]any `json:"locals"`
+}
+
+// Represents the source lines around a frame
+type FrameContext struct {
+	Pre  []string `json:"pre"`
+	Post []string `json:"post"`
+}
+
+// Represents the exception of a trace
+type Exception struct {
+	Class       string `json:"class"`
+	Message     string `json:"message"`
+	Description string `json:"description"`
+}
+
+// Represents the server the item happened on
+type Server struct {
+	Host        string `json:"host"`
+	Root        string `json:"root"`
+	Branch      string `json:"branch"`
+	CodeVersion string `json:"code_version"`
+}
+
+// The Rollbar levels mapped to the BugsChannel ones
+var levels = map[string]string{
+	"critical": "fatal",
+	"error":    "error",
+	"warning":  "warning",
+	"info":     "info",
+	"debug":    "debug",
+}
+
+// Returns true when the body carries a trace, a trace chain or a message
+func (d Data) Valid() bool {
+	return d.Body.Trace != nil || len(d.Body.TraceChain) > 0 || d.message() != ""
+}
+
+// Maps the item data onto a BugsChannel event of the service
+func (d Data) Event(serviceId string) (event.Event, error) {
+	extra := event.EventExtra{}
+
+	ingest.SetExtra(extra, "request", d.Request)
+	ingest.SetExtra(extra, "person", d.Person)
+	ingest.SetExtra(extra, "custom", d.Custom)
+	ingest.SetExtra(extra, "client", d.Client)
+	ingest.SetExtra(extra, "notifier", d.Notifier)
+	ingest.SetExtra(extra, "timestamp", d.Timestamp)
+	ingest.SetExtra(extra, "context", d.Context)
+	ingest.SetExtra(extra, "server_root", d.Server.Root)
+	ingest.SetExtra(extra, "server_branch", d.Server.Branch)
+	ingest.SetExtra(extra, fingerprint.ExtraKey, d.Fingerprint)
+
+	e := event.Event{
+		ID:          d.Uuid,
+		ServiceId:   serviceId,
+		Platform:    d.platform(),
+		Environment: d.Environment,
+		Release:     d.release(),
+		ServerName:  d.Server.Host,
+		Level:       d.level(),
+		Extra:       extra,
+	}
+
+	if e.ID == "" {
+		id, err := ingest.NewEventId()
+
+		if err != nil {
+			return event.Event{}, err
+		}
+
+		e.ID = id
+	}
+
+	switch trace := d.trace(); {
+	case trace != nil:
+		e.Kind = "error"
+		e.Title = trace.Exception.Class
+		e.Body = trace.Exception.Message
+		e.StackTrace = trace.stackTrace()
+
+		ingest.SetExtra(extra, "description", trace.Exception.Description)
+		ingest.SetExtra(extra, "causes", d.causes())
+	default:
+		e.Kind = "message"
+		e.Title = d.Title
+		e.Body = d.message()
+
+		ingest.SetExtra(extra, "message", d.messageData())
+	}
+
+	e.Tags = ingest.Tags(
+		ingest.Tag("environment", e.Environment),
+		ingest.Tag("release", e.Release),
+		ingest.Tag("server_name", e.ServerName),
+		ingest.Tag("framework", d.Framework),
+		ingest.Tag("context", d.Context),
+		ingest.Tag("person", d.Person["id"]),
+	)
+
+	return e, nil
+}
+
+// Returns the trace, the first one of a trace chain is the raised exception
+func (d Data) trace() *Trace {
+	if d.Body.Trace != nil {
+		return d.Body.Trace
+	}
+
+	if len(d.Body.TraceChain) > 0 {
+		return &d.Body.TraceChain[0]
+	}
+
+	return nil
+}
+
+// Returns the exceptions that caused the raised one, with their frames
+func (d Data) causes() []any {
+	if len(d.Body.TraceChain) < 2 {
+		return nil
+	}
+
+	causes := make([]any, 0, len(d.Body.TraceChain)-1)
+
+	for _, trace := range d.Body.TraceChain[1:] {
+		causes = append(causes, map[string]any{
+			"class":       trace.Exception.Class,
+			"message":     trace.Exception.Message,
+			"stack_trace": trace.stackTrace(),
+		})
+	}
+
+	return causes
+}
+
+// Returns the message body
+func (d Data) message() string {
+	body, _ := d.Body.Message["body"].(string)
+
+	return body
+}
+
+// Returns the custom message fields, except the body
+func (d Data) messageData() map[string]any {
+	data := map[string]any{}
+
+	for key, value := range d.Body.Message {
+		if key != "body" {
+			data[key] = value
+		}
+	}
+
+	return data
+}
+
+// Returns the SDK language, e.g. python, or the platform
+func (d Data) platform() string {
+	switch {
+	case d.Language != "":
+		return d.Language
+	case d.Platform != "":
+		return d.Platform
+	}
+
+	return "rollbar"
+}
+
+// Returns the code version of the item or its server
+func (d Data) release() string {
+	if d.CodeVersion != "" {
+		return d.CodeVersion
+	}
+
+	return d.Server.CodeVersion
+}
+
+// Returns the BugsChannel level, error when it is unknown
+func (d Data) level() string {
+	if level, ok := levels[strings.ToLower(d.Level)]; ok {
+		return level
+	}
+
+	return "error"
+}
+
+// Returns the trace frames, Rollbar does not flag the in-app ones
+func (t Trace) stackTrace() event.StackTrace {
+	frames := make(event.StackTrace, 0, len(t.Frames))
+
+	for _, f := range t.Frames {
+		frame := map[string]interface{}{
+			"filename": f.Filename,
+			"function": f.Method,
+			"lineno":   ingest.Number(f.Lineno),
+			"colno":    ingest.Number(f.Colno),
+		}
+
+		ingest.SetExtra(frame, "module", f.ClassName)
+		ingest.SetExtra(frame, "context_line", f.Code)
+		ingest.SetExtra(frame, "vars", f.Locals)
+
+		if len(f.Context.Pre) > 0 {
+			frame["pre_context"] = f.Context.Pre
+		}
+
+		if len(f.Context.Post) > 0 {
+			frame["post_context"] = f.Context.Post
+		}
+
+		frames = append(frames, frame)
+	}
+
+	return frames
+}
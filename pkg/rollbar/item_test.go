@@ -0,0 +1,133 @@
+package rollbar
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+)
+
+func TestItemTraceChain(t *testing.T) {
+	item := readItem(t, "trace_chain.json")
+
+	require.True(t, item.Data.Valid())
+
+	e, err := item.Data.Event("1")
+
+	require.Nil(t, err)
+
+	assert.Equal(t, "d4c7a2e0-5b1f-4c3a-9e8d-1f2e3d4c5b6a", e.ID)
+	assert.Equal(t, "1", e.ServiceId)
+	assert.Equal(t, "python", e.Platform)
+	assert.Equal(t, "production", e.Environment)
+	assert.Equal(t, "a1b2c3d", e.Release)
+	assert.Equal(t, "web-1", e.ServerName)
+	assert.Equal(t, "CheckoutError", e.Title)
+	assert.Equal(t, "payment failed", e.Body)
+	assert.Equal(t, "error", e.Kind)
+	assert.Equal(t, "fatal", e.Level)
+
+	assert.Equal(t, []string{
+		"environment:production",
+		"release:a1b2c3d",
+		"server_name:web-1",
+		"framework:flask",
+		"context:orders#create",
+		"person:42",
+	}, e.Tags)
+
+	assert.Equal(t, event.StackTrace{
+		{
+			"filename":     "/app/orders/views.py",
+			"function":     "create",
+			"lineno":       42,
+			"colno":        nil,
+			"context_line": "raise CheckoutError(\"payment failed\") from exc",
+			"pre_context":  []string{"try:", "    charge(order)"},
+			"vars":         map[string]any{"order_id": "123"},
+		},
+	}, e.StackTrace)
+
+	assert.Equal(t, []any{
+		map[string]any{
+			"class":   "TimeoutError",
+			"message": "gateway timed out",
+			"stack_trace": event.StackTrace{
+				{"filename": "/app/payments/gateway.py", "function": "charge", "lineno": 17, "colno": nil},
+			},
+		},
+	}, e.Extra["causes"])
+
+	assert.Equal(t, "The order could not be charged", e.Extra["description"])
+	assert.Equal(t, map[string]any{"id": "42", "username": "jane", "email": "jane@example.com"}, e.Extra["person"])
+	assert.Equal(t, "POST", e.Extra["request"].(map[string]any)["method"])
+	assert.Equal(t, map[string]any{"cart_size": float64(3)}, e.Extra["custom"])
+	assert.Equal(t, "main", e.Extra["server_branch"])
+	assert.NotContains(t, e.Extra, "message")
+	assert.NotContains(t, e.Extra, "fingerprint")
+}
+
+func TestItemMessage(t *testing.T) {
+	item := readItem(t, "message.json")
+
+	require.True(t, item.Data.Valid())
+
+	e, err := item.Data.Event("1")
+
+	require.Nil(t, err)
+
+	assert.Len(t, e.ID, 32)
+	assert.Equal(t, "javascript", e.Platform)
+	assert.Equal(t, "e5f6", e.Release)
+	assert.Equal(t, "Slow checkout", e.Title)
+	assert.Equal(t, "Checkout took 12 seconds", e.Body)
+	assert.Equal(t, "message", e.Kind)
+	assert.Equal(t, "warning", e.Level)
+	assert.Empty(t, e.StackTrace)
+	assert.Equal(t, map[string]any{"duration": float64(12)}, e.Extra["message"])
+	assert.Equal(t, []string{"environment:staging", "release:e5f6", "server_name:web-2"}, e.Tags)
+}
+
+func TestItemTrace(t *testing.T) {
+	data := Data{
+		Level:       "unknown",
+		Fingerprint: "checkout",
+		Body: Body{Trace: &Trace{
+			Exception: Exception{Class: "NameError", Message: "undefined local variable"},
+			Frames:    []Frame{{Filename: "app.rb", Lineno: float64(3), Method: "call"}},
+		}},
+	}
+
+	require.True(t, data.Valid())
+
+	e, err := data.Event("1")
+
+	require.Nil(t, err)
+
+	assert.Equal(t, "rollbar", e.Platform)
+	assert.Equal(t, "error", e.Level)
+	assert.Equal(t, "NameError", e.Title)
+	assert.Equal(t, event.StackTrace{{"filename": "app.rb", "function": "call", "lineno": 3, "colno": nil}}, e.StackTrace)
+	assert.Equal(t, "checkout", e.Extra["fingerprint"])
+}
+
+func TestItemInvalid(t *testing.T) {
+	assert.False(t, Data{}.Valid())
+	assert.False(t, Data{Body: Body{Message: map[string]any{"body": ""}}}.Valid())
+	assert.False(t, Data{Body: Body{TraceChain: []Trace{}}}.Valid())
+}
+
+func readItem(t *testing.T, name string) Item {
+	body, err := os.ReadFile("../../fixtures/rollbar/" + name)
+
+	require.Nil(t, err)
+
+	var item Item
+
+	require.Nil(t, json.Unmarshal(body, &item))
+
+	return item
+}
@@ -0,0 +1,107 @@
+package rollbar
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	plugin "github.com/williampsena/bugs-channel-plugins/pkg/service"
+	"github.com/williampsena/bugs-channel/pkg/config"
+	"github.com/williampsena/bugs-channel/pkg/ingest"
+)
+
+// The header carrying the project access token, one of the service auth keys
+const AccessTokenHeader = "X-Rollbar-Access-Token"
+
+// The Rollbar server context
+type ServerContext struct {
+	context.Context
+	ServiceFetcher   plugin.ServiceFetcher
+	EventsDispatcher event.EventsDispatcher
+}
+
+// Represents the item response, err is 1 when the item was refused
+type ItemResponse struct {
+	Err     int         `json:"err"`
+	Message string      `json:"message,omitempty"`
+	Result  *ItemResult `json:"result,omitempty"`
+}
+
+// Represents the accepted item
+type ItemResult struct {
+	Id   *string `json:"id"`
+	Uuid string  `json:"uuid"`
+}
+
+// Receives an item, answering with its event id like the Rollbar API
+func ItemEndpoint(c *ServerContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		var item Item
+
+		if err := ingest.DecodeJson(w, req, &item); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		// SDKs without the header send the token in the body
+		token := req.Header.Get(AccessTokenHeader)
+
+		if token == "" {
+			token = item.AccessToken
+		}
+
+		service, err := c.ServiceFetcher.GetServiceByAuthKey(token)
+
+		if err != nil {
+			writeError(w, http.StatusUnauthorized, errors.Join(ingest.ErrUnauthorized, err))
+			return
+		}
+
+		if !item.Data.Valid() {
+			writeError(w, http.StatusUnprocessableEntity, ingest.ErrInvalidPayload)
+			return
+		}
+
+		e, err := item.Data.Event(service.Id)
+
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		if err := c.EventsDispatcher.Dispatch(e); err != nil {
+			writeError(w, ingest.DispatchStatus(err), err)
+			return
+		}
+
+		ingest.WriteJson(w, http.StatusOK, ItemResponse{Result: &ItemResult{Uuid: e.ID}})
+	}
+}
+
+// Build the Rollbar router
+func BuildRouter(c *ServerContext) *mux.Router {
+	r := mux.NewRouter()
+
+	r.HandleFunc("/api/1/item/", ItemEndpoint(c)).Methods("POST")
+
+	return r
+}
+
+// Build the Rollbar server listening at ROLLBAR_PORT
+func BuildServer(c *ServerContext) *http.Server {
+	return ingest.NewServer(config.RollbarPort(), BuildRouter(c))
+}
+
+// Listens the Rollbar server
+func SetupServer(srv *http.Server) {
+	ingest.ListenAndServe("Rollbar", srv)
+}
+
+// Writes the error as a Rollbar response
+func writeError(w http.ResponseWriter, status int, err error) {
+	log.Errorf("⛔ %v", err)
+	ingest.WriteJson(w, status, ItemResponse{Err: 1, Message: err.Error()})
+}
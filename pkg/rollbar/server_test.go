@@ -0,0 +1,129 @@
+package rollbar
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel/pkg/ratelimit"
+	"github.com/williampsena/bugs-channel/pkg/test"
+)
+
+func TestItemEndpoint(t *testing.T) {
+	dispatcher := &test.MockDispatcher{}
+	svr := buildTestServer(t, dispatcher)
+
+	res := postItem(t, svr.URL, "key", readFixture(t, "message.json"))
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var body ItemResponse
+
+	require.Nil(t, json.NewDecoder(res.Body).Decode(&body))
+	require.Len(t, dispatcher.Events, 1)
+
+	assert.Equal(t, 0, body.Err)
+	assert.Equal(t, dispatcher.Events[0].ID, body.Result.Uuid)
+	assert.Equal(t, "1", dispatcher.Events[0].ServiceId)
+	assert.Equal(t, "Checkout took 12 seconds", dispatcher.Events[0].Body)
+}
+
+func TestItemEndpointBodyToken(t *testing.T) {
+	dispatcher := &test.MockDispatcher{}
+	svr := buildTestServer(t, dispatcher)
+
+	res := postItem(t, svr.URL, "", readFixture(t, "trace_chain.json"))
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Len(t, dispatcher.Events, 1)
+	assert.Equal(t, "CheckoutError", dispatcher.Events[0].Title)
+}
+
+func TestItemEndpointUnauthorized(t *testing.T) {
+	dispatcher := &test.MockDispatcher{}
+	svr := buildTestServer(t, dispatcher)
+
+	for _, token := range []string{"invalid", ""} {
+		res := postItem(t, svr.URL, token, readFixture(t, "message.json"))
+
+		var body ItemResponse
+
+		require.Nil(t, json.NewDecoder(res.Body).Decode(&body))
+
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode, token)
+		assert.Equal(t, 1, body.Err)
+		assert.NotEmpty(t, body.Message)
+	}
+
+	assert.Empty(t, dispatcher.Events)
+}
+
+func TestItemEndpointInvalid(t *testing.T) {
+	svr := buildTestServer(t, &test.MockDispatcher{})
+
+	res := postItem(t, svr.URL, "key", []byte(`foo`))
+
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res = postItem(t, svr.URL, "key", []byte(`{"data": {"body": {}}}`))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, res.StatusCode)
+}
+
+func TestItemEndpointDispatchError(t *testing.T) {
+	svr := buildTestServer(t, &test.MockDispatcher{Err: fmt.Errorf("%w: service 1", ratelimit.ErrRateLimitExceeded)})
+
+	res := postItem(t, svr.URL, "key", readFixture(t, "message.json"))
+
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+
+	svr = buildTestServer(t, &test.MockDispatcher{Err: errors.New("queue is down")})
+
+	res = postItem(t, svr.URL, "key", readFixture(t, "message.json"))
+
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+}
+
+func buildTestServer(t *testing.T, dispatcher *test.MockDispatcher) *httptest.Server {
+	return test.BuildServer(t, BuildRouter(&ServerContext{
+		Context:          context.Background(),
+		ServiceFetcher:   test.BuildServiceFetcher(t),
+		EventsDispatcher: dispatcher,
+	}))
+}
+
+func postItem(t *testing.T, url string, token string, body []byte) *http.Response {
+	req, err := http.NewRequest(http.MethodPost, url+"/api/1/item/", bytes.NewReader(body))
+
+	require.Nil(t, err)
+
+	if token != "" {
+		req.Header.Set(AccessTokenHeader, token)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+
+	require.Nil(t, err)
+
+	t.Cleanup(func() { res.Body.Close() })
+
+	return res
+}
+
+func readFixture(t *testing.T, name string) []byte {
+	body, err := os.ReadFile("../../fixtures/rollbar/" + name)
+
+	require.Nil(t, err)
+
+	return body
+}
@@ -0,0 +1,176 @@
+// This package routes events to topics following the configuration file rules
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/settings"
+	"github.com/williampsena/bugs-channel/pkg/storage"
+)
+
+// Represents an error when a routed topic cannot be published or consumed through the event channel
+var ErrUnsupportedTopic = errors.New("the routed topic is not supported by the event channel")
+
+// The topic used when nothing else is configured
+const DefaultTopic = "events"
+
+// Routes events to topics by rules, service topics and the default topic
+type Router struct {
+	org           string
+	defaultTopic  string
+	rules         []settings.ConfigFileRoutingRule
+	serviceTopics map[string]string
+}
+
+// Returns the topic of the event, the first matching rule wins,
+// then the service topic and finally the default topic.
+func (r *Router) Route(e event.Event) string {
+	for _, rule := range r.rules {
+		if matchRule(rule, e) {
+			return r.render(rule.Topic, e)
+		}
+	}
+
+	if topic, ok := r.serviceTopics[e.ServiceId]; ok {
+		return r.render(topic, e)
+	}
+
+	return r.defaultTopic
+}
+
+func matchRule(rule settings.ConfigFileRoutingRule, e event.Event) bool {
+	if len(rule.Services) > 0 && !slices.Contains(rule.Services, e.ServiceId) {
+		return false
+	}
+
+	if len(rule.Platforms) > 0 && !slices.Contains(rule.Platforms, e.Platform) {
+		return false
+	}
+
+	if len(rule.Levels) > 0 && !slices.Contains(rule.Levels, e.Level) {
+		return false
+	}
+
+	for _, tag := range rule.Tags {
+		if !slices.Contains(e.Tags, tag) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Fills the topic template placeholders, each value becomes a single topic token
+func (r *Router) render(topic string, e event.Event) string {
+	replacer := strings.NewReplacer(
+		"{org}", topicToken(r.org),
+		"{service_id}", topicToken(e.ServiceId),
+		"{platform}", topicToken(e.Platform),
+		"{level}", topicToken(e.Level),
+	)
+
+	return replacer.Replace(topic)
+}
+
+// Returns the topics events are routed to, the placeholders other than the org become "*" wildcards,
+// so a consumer subscribing to every one of them receives all the routed events
+func (r *Router) Topics() []string {
+	replacer := strings.NewReplacer(
+		"{org}", topicToken(r.org),
+		"{service_id}", "*",
+		"{platform}", "*",
+		"{level}", "*",
+	)
+
+	templates := make([]string, 0, len(r.rules)+len(r.serviceTopics))
+
+	for _, rule := range r.rules {
+		templates = append(templates, rule.Topic)
+	}
+
+	serviceTopics := make([]string, 0, len(r.serviceTopics))
+
+	for _, topic := range r.serviceTopics {
+		serviceTopics = append(serviceTopics, topic)
+	}
+
+	slices.Sort(serviceTopics)
+
+	templates = append(templates, serviceTopics...)
+
+	topics := []string{r.defaultTopic}
+
+	for _, template := range templates {
+		if topic := replacer.Replace(template); !slices.Contains(topics, topic) {
+			topics = append(topics, topic)
+		}
+	}
+
+	return topics
+}
+
+// Turns a value into a topic token, so it cannot add hierarchy levels or wildcards
+func topicToken(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+
+	replacer := strings.NewReplacer(".", "_", " ", "_", "*", "_", ">", "_", ":", "_")
+
+	return strings.ToLower(replacer.Replace(value))
+}
+
+// Build a new router from the configuration file
+func NewRouter(configFile *settings.ConfigFile) *Router {
+	defaultTopic := configFile.Routing.DefaultTopic
+
+	if defaultTopic == "" {
+		defaultTopic = DefaultTopic
+	}
+
+	serviceTopics := make(map[string]string)
+
+	for _, s := range configFile.Services {
+		if s.Settings.Topic != "" {
+			serviceTopics[s.Id] = s.Settings.Topic
+		}
+	}
+
+	return &Router{
+		org:           configFile.Org,
+		defaultTopic:  defaultTopic,
+		rules:         configFile.Routing.Rules,
+		serviceTopics: serviceTopics,
+	}
+}
+
+// Returns an error when a rule or service topic holds wildcards, which cannot be published to,
+// or placeholders on an event channel that cannot subscribe to the wildcard topics they are consumed through
+func ValidateTopics(configFile *settings.ConfigFile, eventChannel string) error {
+	var errs []error
+
+	check := func(topic string) {
+		if strings.ContainsAny(topic, "*>") {
+			errs = append(errs, fmt.Errorf("%w: %q holds a wildcard", ErrUnsupportedTopic, topic))
+			return
+		}
+
+		if strings.Contains(topic, "{") && !storage.SupportsWildcardTopics(eventChannel) {
+			errs = append(errs, fmt.Errorf("%w: %q holds placeholders, which %v cannot consume", ErrUnsupportedTopic, topic, eventChannel))
+		}
+	}
+
+	for _, rule := range configFile.Routing.Rules {
+		check(rule.Topic)
+	}
+
+	for _, s := range configFile.Services {
+		check(s.Settings.Topic)
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,106 @@
+package routing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/settings"
+)
+
+func TestRouteRules(t *testing.T) {
+	router := buildTestRouter(t)
+
+	topic := router.Route(event.Event{ServiceId: "1", Platform: "python", Level: "fatal", Tags: []string{"env:production"}})
+
+	assert.Equal(t, "events.foo.1.python", topic)
+
+	topic = router.Route(event.Event{ServiceId: "1", Platform: "python", Level: "error", Tags: []string{"env:production"}})
+
+	assert.Equal(t, "events", topic)
+}
+
+func TestRouteServiceTopic(t *testing.T) {
+	configFile := &settings.ConfigFile{
+		Org: "foo",
+		Services: []settings.ConfigFileService{
+			{Id: "2", Settings: settings.ConfigFileServiceSettings{Topic: "events.{org}.mobile.{platform}"}},
+		},
+		Routing: settings.ConfigFileRouting{
+			Rules: []settings.ConfigFileRoutingRule{
+				{Topic: "events.critical", Services: []string{"2"}, Levels: []string{"fatal"}},
+			},
+		},
+	}
+
+	router := NewRouter(configFile)
+
+	assert.Equal(t, "events.critical", router.Route(event.Event{ServiceId: "2", Level: "fatal"}))
+	assert.Equal(t, "events.foo.mobile.react_native", router.Route(event.Event{ServiceId: "2", Platform: "react.native"}))
+	assert.Equal(t, "events.foo.mobile.unknown", router.Route(event.Event{ServiceId: "2"}))
+	assert.Equal(t, DefaultTopic, router.Route(event.Event{ServiceId: "3"}))
+}
+
+func TestTopics(t *testing.T) {
+	configFile := &settings.ConfigFile{
+		Org: "foo",
+		Services: []settings.ConfigFileService{
+			{Id: "1"},
+			{Id: "2", Settings: settings.ConfigFileServiceSettings{Topic: "events.{org}.mobile.{platform}"}},
+			{Id: "3", Settings: settings.ConfigFileServiceSettings{Topic: "events.critical"}},
+		},
+		Routing: settings.ConfigFileRouting{
+			Rules: []settings.ConfigFileRoutingRule{
+				{Topic: "events.critical", Levels: []string{"fatal"}},
+				{Topic: "events.{org}.{service_id}.{level}", Platforms: []string{"python"}},
+			},
+		},
+	}
+
+	topics := NewRouter(configFile).Topics()
+
+	assert.Equal(t, []string{"events", "events.critical", "events.foo.*.*", "events.foo.mobile.*"}, topics)
+}
+
+func TestValidateTopics(t *testing.T) {
+	configFile, err := settings.BuildConfigFile("../../fixtures/settings/config.yml")
+
+	require.Nil(t, err)
+
+	for _, eventChannel := range []string{"nats", "redis", "rabbitmq", "memory"} {
+		assert.Nil(t, ValidateTopics(configFile, eventChannel), eventChannel)
+	}
+
+	assert.ErrorIs(t, ValidateTopics(configFile, "kafka"), ErrUnsupportedTopic)
+	assert.ErrorIs(t, ValidateTopics(configFile, "redis-streams"), ErrUnsupportedTopic)
+
+	configFile = &settings.ConfigFile{
+		Services: []settings.ConfigFileService{
+			{Id: "1", Settings: settings.ConfigFileServiceSettings{Topic: "events.critical"}},
+		},
+		Routing: settings.ConfigFileRouting{
+			Rules: []settings.ConfigFileRoutingRule{{Topic: "events.mobile"}},
+		},
+	}
+
+	assert.Nil(t, ValidateTopics(configFile, "kafka"))
+
+	configFile.Routing.Rules = append(configFile.Routing.Rules, settings.ConfigFileRoutingRule{Topic: "events.>"})
+
+	assert.ErrorIs(t, ValidateTopics(configFile, "nats"), ErrUnsupportedTopic)
+}
+
+func TestTopicToken(t *testing.T) {
+	assert.Equal(t, "unknown", topicToken(""))
+	assert.Equal(t, "my_app_v1", topicToken("My App.v1"))
+	assert.Equal(t, "a___b", topicToken("a*>:b"))
+}
+
+func buildTestRouter(t *testing.T) *Router {
+	configFile, err := settings.BuildConfigFile("../../fixtures/settings/config.yml")
+
+	require.Nil(t, err)
+
+	return NewRouter(configFile)
+}
@@ -0,0 +1,191 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: bugschannel/v1/event_service.proto
+
+package bugschannelv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	EventService_SendEvent_FullMethodName  = "/bugschannel.v1.EventService/SendEvent"
+	EventService_SendEvents_FullMethodName = "/bugschannel.v1.EventService/SendEvents"
+)
+
+// EventServiceClient is the client API for EventService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Receives events of the service identified by the x-auth-key metadata
+type EventServiceClient interface {
+	// Dispatches a single event
+	SendEvent(ctx context.Context, in *SendEventRequest, opts ...grpc.CallOption) (*SendEventResponse, error)
+	// Dispatches every event of the stream, answering once it is closed
+	SendEvents(ctx context.Context, opts ...grpc.CallOption) (EventService_SendEventsClient, error)
+}
+
+type eventServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEventServiceClient(cc grpc.ClientConnInterface) EventServiceClient {
+	return &eventServiceClient{cc}
+}
+
+func (c *eventServiceClient) SendEvent(ctx context.Context, in *SendEventRequest, opts ...grpc.CallOption) (*SendEventResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendEventResponse)
+	err := c.cc.Invoke(ctx, EventService_SendEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) SendEvents(ctx context.Context, opts ...grpc.CallOption) (EventService_SendEventsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &EventService_ServiceDesc.Streams[0], EventService_SendEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &eventServiceSendEventsClient{ClientStream: stream}
+	return x, nil
+}
+
+type EventService_SendEventsClient interface {
+	Send(*SendEventsRequest) error
+	CloseAndRecv() (*SendEventsResponse, error)
+	grpc.ClientStream
+}
+
+type eventServiceSendEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *eventServiceSendEventsClient) Send(m *SendEventsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *eventServiceSendEventsClient) CloseAndRecv() (*SendEventsResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(SendEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// EventServiceServer is the server API for EventService service.
+// All implementations must embed UnimplementedEventServiceServer
+// for forward compatibility
+//
+// Receives events of the service identified by the x-auth-key metadata
+type EventServiceServer interface {
+	// Dispatches a single event
+	SendEvent(context.Context, *SendEventRequest) (*SendEventResponse, error)
+	// Dispatches every event of the stream, answering once it is closed
+	SendEvents(EventService_SendEventsServer) error
+	mustEmbedUnimplementedEventServiceServer()
+}
+
+// UnimplementedEventServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedEventServiceServer struct {
+}
+
+func (UnimplementedEventServiceServer) SendEvent(context.Context, *SendEventRequest) (*SendEventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendEvent not implemented")
+}
+func (UnimplementedEventServiceServer) SendEvents(EventService_SendEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SendEvents not implemented")
+}
+func (UnimplementedEventServiceServer) mustEmbedUnimplementedEventServiceServer() {}
+
+// UnsafeEventServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EventServiceServer will
+// result in compilation errors.
+type UnsafeEventServiceServer interface {
+	mustEmbedUnimplementedEventServiceServer()
+}
+
+func RegisterEventServiceServer(s grpc.ServiceRegistrar, srv EventServiceServer) {
+	s.RegisterService(&EventService_ServiceDesc, srv)
+}
+
+func _EventService_SendEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).SendEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_SendEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).SendEvent(ctx, req.(*SendEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_SendEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(EventServiceServer).SendEvents(&eventServiceSendEventsServer{ServerStream: stream})
+}
+
+type EventService_SendEventsServer interface {
+	SendAndClose(*SendEventsResponse) error
+	Recv() (*SendEventsRequest, error)
+	grpc.ServerStream
+}
+
+type eventServiceSendEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *eventServiceSendEventsServer) SendAndClose(m *SendEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *eventServiceSendEventsServer) Recv() (*SendEventsRequest, error) {
+	m := new(SendEventsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// EventService_ServiceDesc is the grpc.ServiceDesc for EventService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EventService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bugschannel.v1.EventService",
+	HandlerType: (*EventServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SendEvent",
+			Handler:    _EventService_SendEvent_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SendEvents",
+			Handler:       _EventService_SendEvents_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "bugschannel/v1/event_service.proto",
+}
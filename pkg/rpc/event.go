@@ -0,0 +1,55 @@
+// This package serves the BugsChannel gRPC API defined at proto/bugschannel/v1
+package rpc
+
+import (
+	"fmt"
+
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/ingest"
+	pb "github.com/williampsena/bugs-channel/pkg/rpc/bugschannel/v1"
+)
+
+// Maps the protobuf event onto a BugsChannel event of the service, the id is generated when it is empty
+func newEvent(e *pb.Event, serviceId string) (event.Event, error) {
+	if e == nil {
+		return event.Event{}, fmt.Errorf("%w: the event is missing", ingest.ErrInvalidPayload)
+	}
+
+	id := e.GetId()
+
+	if id == "" {
+		var err error
+
+		if id, err = ingest.NewEventId(); err != nil {
+			return event.Event{}, err
+		}
+	}
+
+	var stackTrace event.StackTrace
+
+	for _, frame := range e.GetStackTrace() {
+		stackTrace = append(stackTrace, frame.AsMap())
+	}
+
+	var extra event.EventExtra
+
+	if e.GetExtra() != nil {
+		extra = e.GetExtra().AsMap()
+	}
+
+	return event.Event{
+		ID:          id,
+		ServiceId:   serviceId,
+		Platform:    e.GetPlatform(),
+		Environment: e.GetEnvironment(),
+		Release:     e.GetRelease(),
+		ServerName:  e.GetServerName(),
+		Title:       e.GetTitle(),
+		Body:        e.GetBody(),
+		StackTrace:  stackTrace,
+		Kind:        e.GetKind(),
+		Level:       e.GetLevel(),
+		Tags:        e.GetTags(),
+		Extra:       extra,
+	}, nil
+}
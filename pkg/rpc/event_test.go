@@ -0,0 +1,70 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/ingest"
+	pb "github.com/williampsena/bugs-channel/pkg/rpc/bugschannel/v1"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestNewEvent(t *testing.T) {
+	frame, err := structpb.NewStruct(map[string]any{"filename": "main.go", "function": "main", "lineno": 42, "in_app": true})
+
+	require.Nil(t, err)
+
+	extra, err := structpb.NewStruct(map[string]any{"order_id": "123"})
+
+	require.Nil(t, err)
+
+	e, err := newEvent(&pb.Event{
+		Id:          "foo",
+		Platform:    "go",
+		Environment: "production",
+		Release:     "1.0.0",
+		ServerName:  "web-1",
+		Title:       "CheckoutError",
+		Body:        "payment failed",
+		StackTrace:  []*structpb.Struct{frame},
+		Kind:        "error",
+		Level:       "error",
+		Tags:        []string{"app:shop"},
+		Extra:       extra,
+	}, "1")
+
+	require.Nil(t, err)
+
+	assert.Equal(t, event.Event{
+		ID:          "foo",
+		ServiceId:   "1",
+		Platform:    "go",
+		Environment: "production",
+		Release:     "1.0.0",
+		ServerName:  "web-1",
+		Title:       "CheckoutError",
+		Body:        "payment failed",
+		StackTrace:  event.StackTrace{{"filename": "main.go", "function": "main", "lineno": float64(42), "in_app": true}},
+		Kind:        "error",
+		Level:       "error",
+		Tags:        []string{"app:shop"},
+		Extra:       event.EventExtra{"order_id": "123"},
+	}, e)
+}
+
+func TestNewEventId(t *testing.T) {
+	e, err := newEvent(&pb.Event{Title: "CheckoutError"}, "1")
+
+	require.Nil(t, err)
+	assert.Len(t, e.ID, 32)
+	assert.Nil(t, e.Extra)
+	assert.Nil(t, e.StackTrace)
+}
+
+func TestNewEventMissing(t *testing.T) {
+	_, err := newEvent(nil, "1")
+
+	require.ErrorIs(t, err, ingest.ErrInvalidPayload)
+}
@@ -0,0 +1,121 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	plugin "github.com/williampsena/bugs-channel-plugins/pkg/service"
+	"github.com/williampsena/bugs-channel/pkg/config"
+	"github.com/williampsena/bugs-channel/pkg/ingest"
+	pb "github.com/williampsena/bugs-channel/pkg/rpc/bugschannel/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// The gRPC server context
+type ServerContext struct {
+	context.Context
+	ServiceFetcher   plugin.ServiceFetcher
+	EventsDispatcher event.EventsDispatcher
+}
+
+// The gRPC event service
+type eventService struct {
+	pb.UnimplementedEventServiceServer
+	c *ServerContext
+}
+
+// Dispatches a single event
+func (s *eventService) SendEvent(ctx context.Context, req *pb.SendEventRequest) (*pb.SendEventResponse, error) {
+	service, err := s.c.authenticate(ctx)
+
+	if err != nil {
+		return nil, ingest.GrpcError(err)
+	}
+
+	id, err := s.c.dispatch(req.GetEvent(), service.Id)
+
+	if err != nil {
+		return nil, ingest.GrpcError(err)
+	}
+
+	return &pb.SendEventResponse{Id: id}, nil
+}
+
+// Dispatches every event as it arrives, a failure ends the stream and the events before it stay accepted
+func (s *eventService) SendEvents(stream pb.EventService_SendEventsServer) error {
+	service, err := s.c.authenticate(stream.Context())
+
+	if err != nil {
+		return ingest.GrpcError(err)
+	}
+
+	ids := []string{}
+
+	for {
+		req, err := stream.Recv()
+
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(&pb.SendEventsResponse{Ids: ids})
+		}
+
+		if err != nil {
+			return err
+		}
+
+		id, err := s.c.dispatch(req.GetEvent(), service.Id)
+
+		if err != nil {
+			return ingest.GrpcError(err)
+		}
+
+		ids = append(ids, id)
+	}
+}
+
+// Returns the service of the auth key metadata
+func (c *ServerContext) authenticate(ctx context.Context) (plugin.Service, error) {
+	service, err := c.ServiceFetcher.GetServiceByAuthKey(ingest.MetadataAuthKey(ctx))
+
+	if err != nil {
+		return service, errors.Join(ingest.ErrUnauthorized, err)
+	}
+
+	return service, nil
+}
+
+// Dispatches the event of the service, returning its id
+func (c *ServerContext) dispatch(e *pb.Event, serviceId string) (string, error) {
+	ev, err := newEvent(e, serviceId)
+
+	if err != nil {
+		return "", err
+	}
+
+	if err := c.EventsDispatcher.Dispatch(ev); err != nil {
+		return "", err
+	}
+
+	return ev.ID, nil
+}
+
+// Build the gRPC server with the event and health services
+func BuildServer(c *ServerContext) *grpc.Server {
+	srv := grpc.NewServer()
+
+	pb.RegisterEventServiceServer(srv, &eventService{c: c})
+
+	healthSrv := health.NewServer()
+	healthSrv.SetServingStatus(pb.EventService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(srv, healthSrv)
+
+	return srv
+}
+
+// Listens the gRPC server at GRPC_PORT
+func SetupServer(srv *grpc.Server) {
+	ingest.ServeGrpc("gRPC", config.GrpcPort(), srv)
+}
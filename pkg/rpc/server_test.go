@@ -0,0 +1,135 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel/pkg/ratelimit"
+	pb "github.com/williampsena/bugs-channel/pkg/rpc/bugschannel/v1"
+	"github.com/williampsena/bugs-channel/pkg/test"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestSendEvent(t *testing.T) {
+	dispatcher := &test.MockDispatcher{}
+	client := pb.NewEventServiceClient(buildTestConn(t, dispatcher))
+
+	res, err := client.SendEvent(authContext("key"), &pb.SendEventRequest{Event: &pb.Event{Title: "CheckoutError"}})
+
+	require.Nil(t, err)
+	require.Len(t, dispatcher.Events, 1)
+	assert.Equal(t, dispatcher.Events[0].ID, res.GetId())
+	assert.Equal(t, "1", dispatcher.Events[0].ServiceId)
+	assert.Equal(t, "CheckoutError", dispatcher.Events[0].Title)
+}
+
+func TestSendEventErrors(t *testing.T) {
+	dispatcher := &test.MockDispatcher{}
+	client := pb.NewEventServiceClient(buildTestConn(t, dispatcher))
+
+	_, err := client.SendEvent(authContext("invalid"), &pb.SendEventRequest{Event: &pb.Event{}})
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = client.SendEvent(context.Background(), &pb.SendEventRequest{Event: &pb.Event{}})
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = client.SendEvent(authContext("key"), &pb.SendEventRequest{})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	dispatcher.Err = fmt.Errorf("%w: service 1", ratelimit.ErrRateLimitExceeded)
+
+	_, err = client.SendEvent(authContext("key"), &pb.SendEventRequest{Event: &pb.Event{}})
+
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	dispatcher.Err = errors.New("queue is down")
+
+	_, err = client.SendEvent(authContext("key"), &pb.SendEventRequest{Event: &pb.Event{}})
+
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Empty(t, dispatcher.Events)
+}
+
+func TestSendEvents(t *testing.T) {
+	dispatcher := &test.MockDispatcher{}
+	client := pb.NewEventServiceClient(buildTestConn(t, dispatcher))
+
+	stream, err := client.SendEvents(authContext("key"))
+
+	require.Nil(t, err)
+
+	for _, id := range []string{"foo", "bar", ""} {
+		require.Nil(t, stream.Send(&pb.SendEventsRequest{Event: &pb.Event{Id: id}}))
+	}
+
+	res, err := stream.CloseAndRecv()
+
+	require.Nil(t, err)
+	require.Len(t, dispatcher.Events, 3)
+	assert.Equal(t, []string{"foo", "bar", dispatcher.Events[2].ID}, res.GetIds())
+	assert.Len(t, dispatcher.Events[2].ID, 32)
+}
+
+func TestSendEventsUnauthorized(t *testing.T) {
+	dispatcher := &test.MockDispatcher{}
+	client := pb.NewEventServiceClient(buildTestConn(t, dispatcher))
+
+	stream, err := client.SendEvents(authContext("invalid"))
+
+	require.Nil(t, err)
+
+	_, err = stream.CloseAndRecv()
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Empty(t, dispatcher.Events)
+}
+
+func TestSendEventsDispatchError(t *testing.T) {
+	dispatcher := &test.MockDispatcher{Err: errors.New("queue is down")}
+	client := pb.NewEventServiceClient(buildTestConn(t, dispatcher))
+
+	stream, err := client.SendEvents(authContext("key"))
+
+	require.Nil(t, err)
+
+	// the server may end the stream before the send, the error comes from CloseAndRecv
+	stream.Send(&pb.SendEventsRequest{Event: &pb.Event{}})
+
+	_, err = stream.CloseAndRecv()
+
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestHealth(t *testing.T) {
+	client := healthpb.NewHealthClient(buildTestConn(t, &test.MockDispatcher{}))
+
+	for _, name := range []string{"", pb.EventService_ServiceDesc.ServiceName} {
+		res, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: name})
+
+		require.Nil(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, res.GetStatus())
+	}
+}
+
+func authContext(key string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "x-auth-key", key)
+}
+
+func buildTestConn(t *testing.T, dispatcher *test.MockDispatcher) *grpc.ClientConn {
+	return test.BuildGrpcConn(t, BuildServer(&ServerContext{
+		Context:          context.Background(),
+		ServiceFetcher:   test.BuildServiceFetcher(t),
+		EventsDispatcher: dispatcher,
+	}))
+}
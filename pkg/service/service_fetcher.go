@@ -12,6 +12,9 @@ import (
 // Represents an error when service is not found
 var ErrServiceNotFound = errors.New("an error occurred when attempting to fetch the service")
 
+// Represents an error when the authentication key is not valid for the service
+var ErrServiceForbidden = errors.New("the authentication key is not valid for the service")
+
 type YAMLServiceFetcher struct {
 	services []settings.ConfigFileService
 }
@@ -34,6 +37,29 @@ func (s *YAMLServiceFetcher) GetServiceByAuthKey(authKey string) (plugin.Service
 	return plugin.Service{}, ErrServiceNotFound
 }
 
+// Returns the service whose name or id is the given name, when the auth key is valid for it
+func (s *YAMLServiceFetcher) GetServiceByName(name string, authKey string) (plugin.Service, error) {
+	if name == "" {
+		return plugin.Service{}, ErrServiceNotFound
+	}
+
+	for _, s := range s.services {
+		if s.Name != name && s.Id != name {
+			continue
+		}
+
+		for _, a := range s.AuthKeys {
+			if a.Key == authKey && !a.Disabled && !isAuthKeyExpired(a.ExpiredAt) {
+				return plugin.Service{Id: s.Id, Name: s.Name}, nil
+			}
+		}
+
+		return plugin.Service{}, ErrServiceForbidden
+	}
+
+	return plugin.Service{}, ErrServiceNotFound
+}
+
 func isAuthKeyExpired(expiredAt int64) bool {
 	if expiredAt == 0 {
 		return false
@@ -29,6 +29,32 @@ func TestGetServiceError(t *testing.T) {
 	assert.Equal(t, ErrServiceNotFound, err)
 }
 
+func TestGetServiceByName(t *testing.T) {
+	fetcher := &YAMLServiceFetcher{[]settings.ConfigFileService{
+		{Id: "1", Name: "foo", AuthKeys: []settings.ConfigFileServiceAuthKey{{Key: "key"}, {Key: "disabled_key", Disabled: true}}},
+		{Id: "2", Name: "checkout", AuthKeys: []settings.ConfigFileServiceAuthKey{{Key: "checkout_key"}}},
+	}}
+
+	for _, name := range []string{"foo", "1"} {
+		service, err := fetcher.GetServiceByName(name, "key")
+
+		require.Nil(t, err)
+		assert.Equal(t, plugin.Service{Id: "1", Name: "foo"}, service)
+	}
+
+	_, err := fetcher.GetServiceByName("checkout", "key")
+
+	assert.Equal(t, ErrServiceForbidden, err)
+
+	_, err = fetcher.GetServiceByName("foo", "disabled_key")
+
+	assert.Equal(t, ErrServiceForbidden, err)
+
+	_, err = fetcher.GetServiceByName("unknown", "key")
+
+	assert.Equal(t, ErrServiceNotFound, err)
+}
+
 func buildConfigFileServices(t *testing.T) []settings.ConfigFileService {
 	configFile, err := settings.BuildConfigFile("../../fixtures/settings/config.yml")
 
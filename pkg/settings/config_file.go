@@ -22,6 +22,8 @@ type ConfigFile struct {
 	Org string `yaml:"org"`
 	// The service list
 	Services []ConfigFileService `yaml:"services"`
+	// The topic routing
+	Routing ConfigFileRouting `yaml:"routing"`
 }
 
 // Represents service of the configuration file
@@ -52,6 +54,62 @@ type ConfigFileServiceAuthKey struct {
 type ConfigFileServiceSettings struct {
 	// The request limit per seconds
 	RateLimit int `yaml:"rate_limit"`
+	// The topic events of this service are published to
+	Topic string `yaml:"topic"`
+	// The Graylog destination of this service events
+	Gelf ConfigFileGelf `yaml:"gelf"`
+	// The webhooks this service events and issues are posted to
+	Webhooks []ConfigFileWebhook `yaml:"webhooks"`
+}
+
+// Represents a Graylog GELF destination of the configuration file
+type ConfigFileGelf struct {
+	// The Graylog input address, e.g. localhost:12201
+	Address string `yaml:"address"`
+	// The udp or tcp transport
+	Protocol string `yaml:"protocol"`
+	// The gzip or none UDP compression
+	Compression string `yaml:"compression"`
+	// The UDP datagram size messages are chunked by
+	ChunkSize int `yaml:"chunk_size"`
+}
+
+// Represents a webhook destination of the configuration file
+type ConfigFileWebhook struct {
+	// The url the payloads are posted to
+	Url string `yaml:"url"`
+	// The HMAC-SHA256 signing secret
+	Secret string `yaml:"secret"`
+	// The JSON text/template of the body
+	Template string `yaml:"template"`
+	// Extra request headers
+	Headers map[string]string `yaml:"headers"`
+	// The payload types sent: event, issue.regressed
+	Types []string `yaml:"types"`
+	// How many payloads are posted at the same time
+	Concurrency int `yaml:"concurrency"`
+}
+
+// Represents the topic routing of the configuration file
+type ConfigFileRouting struct {
+	// The topic used when no rule or service topic applies
+	DefaultTopic string `yaml:"default_topic"`
+	// The rules evaluated in order, the first match wins
+	Rules []ConfigFileRoutingRule `yaml:"rules"`
+}
+
+// Represents a routing rule, every filled criteria must match
+type ConfigFileRoutingRule struct {
+	// The topic template, e.g. events.{org}.{service_id}.{platform}
+	Topic string `yaml:"topic"`
+	// The service ids
+	Services []string `yaml:"services"`
+	// The platforms
+	Platforms []string `yaml:"platforms"`
+	// The levels
+	Levels []string `yaml:"levels"`
+	// The tags, all of them must be present
+	Tags []string `yaml:"tags"`
 }
 
 // Read a yaml configuration file into ConfigFile struct.
@@ -39,7 +39,20 @@ func TestParseConfigFileSuccess(t *testing.T) {
 							ExpiredAt: 0,
 						},
 					},
-					Settings: ConfigFileServiceSettings{RateLimit: 1},
+					Settings: ConfigFileServiceSettings{
+						RateLimit: 1,
+						Gelf:      ConfigFileGelf{Address: "localhost:12201", Protocol: "udp"},
+					},
+				},
+			},
+			Routing: ConfigFileRouting{
+				DefaultTopic: "events",
+				Rules: []ConfigFileRoutingRule{
+					{
+						Topic:  "events.{org}.{service_id}.{platform}",
+						Levels: []string{"fatal"},
+						Tags:   []string{"env:production"},
+					},
 				},
 			},
 		}, configFile)
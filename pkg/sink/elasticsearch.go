@@ -0,0 +1,65 @@
+package sink
+
+import (
+	"context"
+	"time"
+
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/config"
+	"github.com/williampsena/bugs-channel/pkg/elastic"
+	bcevent "github.com/williampsena/bugs-channel/pkg/event"
+)
+
+// Bulk indexes events into daily Elasticsearch indices
+type ElasticsearchSink struct {
+	indexer     *elastic.BulkIndexer
+	indexPrefix string
+}
+
+// Returns the sink name
+func (s *ElasticsearchSink) Name() string {
+	return "elasticsearch"
+}
+
+// Adds the event to the bulk batch of its daily index
+func (s *ElasticsearchSink) Write(ctx context.Context, e event.Event) error {
+	now := time.Now()
+
+	return s.indexer.Add(ctx, elastic.BulkItem{
+		Index:    elastic.IndexName(s.indexPrefix, now),
+		ID:       elastic.DocumentID(e),
+		Document: elastic.NewDocument(e, now),
+	})
+}
+
+// Sends the pending events
+func (s *ElasticsearchSink) Close() error {
+	return s.indexer.Close()
+}
+
+// Build a new sink indexing events into the daily indices of the prefix
+func NewElasticsearchSink(indexer *elastic.BulkIndexer, indexPrefix string) *ElasticsearchSink {
+	return &ElasticsearchSink{indexer, indexPrefix}
+}
+
+// Build the Elasticsearch sink from the environment settings, installing the index template
+func BuildElasticsearchSink(ctx context.Context) (*ElasticsearchSink, error) {
+	client := elastic.NewClient(config.ElasticsearchUrl(), config.ElasticsearchUsername(), config.ElasticsearchPassword())
+	prefix := config.ElasticsearchIndexPrefix()
+
+	if err := client.PutIndexTemplate(ctx, prefix, elastic.IndexTemplate(prefix)); err != nil {
+		return nil, err
+	}
+
+	indexer := elastic.NewBulkIndexer(client, elastic.IndexerSettings{
+		BatchSize:     config.ElasticsearchBatchSize(),
+		FlushInterval: config.ElasticsearchFlushInterval(),
+		Retry: bcevent.RetryPolicy{
+			Attempts:       config.DispatchRetryAttempts(),
+			InitialBackoff: config.DispatchRetryBackoff(),
+			MaxBackoff:     config.DispatchRetryMaxBackoff(),
+		},
+	})
+
+	return NewElasticsearchSink(indexer, prefix), nil
+}
@@ -0,0 +1,54 @@
+package sink
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+)
+
+func TestElasticsearchSink(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	var bulk string
+
+	es := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		paths = append(paths, req.Method+" "+req.URL.Path)
+
+		if req.URL.Path == "/_bulk" {
+			body, _ := io.ReadAll(req.Body)
+			bulk = string(body)
+
+			w.Write([]byte(`{"errors": false, "items": [{"create": {"_id": "1:foo", "status": 201}}]}`))
+			return
+		}
+
+		w.Write([]byte(`{"acknowledged": true}`))
+	}))
+
+	defer es.Close()
+
+	t.Setenv("ELASTICSEARCH_URL", es.URL)
+	t.Setenv("ELASTICSEARCH_INDEX_PREFIX", "errors")
+
+	s, err := BuildElasticsearchSink(context.Background())
+
+	require.Nil(t, err)
+	assert.Equal(t, "elasticsearch", s.Name())
+
+	require.Nil(t, s.Write(context.Background(), event.Event{ID: "foo", ServiceId: "1"}))
+	require.Nil(t, s.Close())
+
+	assert.Equal(t, []string{"PUT /_index_template/errors", "POST /_bulk"}, paths)
+	assert.True(t, strings.HasPrefix(bulk, `{"create":{"_id":"1:foo","_index":"errors-`))
+}
@@ -0,0 +1,128 @@
+package sink
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/config"
+	"github.com/williampsena/bugs-channel/pkg/gelf"
+	"github.com/williampsena/bugs-channel/pkg/settings"
+)
+
+// Sends events as GELF messages to the Graylog destination of their service
+type GelfSink struct {
+	fallback gelf.Writer
+	writers  map[string]gelf.Writer
+}
+
+// Returns the sink name
+func (g *GelfSink) Name() string {
+	return "gelf"
+}
+
+// Send a event to its service destination, events without one are skipped
+func (g *GelfSink) Write(ctx context.Context, e event.Event) error {
+	w, ok := g.writers[e.ServiceId]
+
+	if !ok {
+		w = g.fallback
+	}
+
+	if w == nil {
+		return nil
+	}
+
+	return w.Write(gelf.NewMessage(e, time.Now()))
+}
+
+// Close every destination
+func (g *GelfSink) Close() error {
+	var errs []error
+
+	for _, w := range g.unique() {
+		errs = append(errs, w.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// Returns the writers once, services may share a destination
+func (g *GelfSink) unique() []gelf.Writer {
+	seen := make(map[gelf.Writer]bool)
+
+	if g.fallback != nil {
+		seen[g.fallback] = true
+	}
+
+	for _, w := range g.writers {
+		seen[w] = true
+	}
+
+	writers := make([]gelf.Writer, 0, len(seen))
+
+	for w := range seen {
+		writers = append(writers, w)
+	}
+
+	return writers
+}
+
+// Build a new sink sending the service events to their writers and the others to the fallback, which may be nil
+func NewGelfSink(fallback gelf.Writer, writers map[string]gelf.Writer) *GelfSink {
+	return &GelfSink{fallback, writers}
+}
+
+// Build the GELF sink from the service settings, services without one use GELF_ADDRESS
+func BuildGelfSink(configFile *settings.ConfigFile) (*GelfSink, error) {
+	cache := make(map[gelf.Settings]gelf.Writer)
+	s := NewGelfSink(nil, make(map[string]gelf.Writer))
+
+	build := func(settings gelf.Settings) (gelf.Writer, error) {
+		if w, ok := cache[settings]; ok {
+			return w, nil
+		}
+
+		w, err := gelf.NewWriter(settings)
+
+		if err != nil {
+			return nil, err
+		}
+
+		cache[settings] = w
+
+		return w, nil
+	}
+
+	var err error
+
+	if config.GelfAddress() != "" {
+		if s.fallback, err = build(gelf.Settings{Address: config.GelfAddress(), Protocol: config.GelfProtocol()}); err != nil {
+			return nil, errors.Join(err, s.Close())
+		}
+	}
+
+	for _, service := range configFile.Services {
+		destination := service.Settings.Gelf
+
+		if destination.Address == "" {
+			continue
+		}
+
+		w, err := build(gelf.Settings{
+			Address:     destination.Address,
+			Protocol:    destination.Protocol,
+			Compression: destination.Compression,
+			ChunkSize:   destination.ChunkSize,
+		})
+
+		if err != nil {
+			return nil, errors.Join(err, s.Close())
+		}
+
+		s.writers[service.Id] = w
+	}
+
+	return s, nil
+}
@@ -0,0 +1,81 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/gelf"
+	"github.com/williampsena/bugs-channel/pkg/settings"
+)
+
+func TestGelfSink(t *testing.T) {
+	t.Setenv("GELF_ADDRESS", "")
+
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+
+	require.Nil(t, err)
+
+	defer conn.Close()
+
+	configFile := &settings.ConfigFile{
+		Services: []settings.ConfigFileService{
+			{Id: "1", Settings: settings.ConfigFileServiceSettings{
+				Gelf: settings.ConfigFileGelf{Address: conn.LocalAddr().String(), Compression: gelf.CompressionNone},
+			}},
+			{Id: "2"},
+		},
+	}
+
+	s, err := BuildGelfSink(configFile)
+
+	require.Nil(t, err)
+
+	defer s.Close()
+
+	ctx := context.Background()
+
+	// a service without destination is skipped
+	require.Nil(t, s.Write(ctx, event.Event{ID: "bar", ServiceId: "2"}))
+	require.Nil(t, s.Write(ctx, event.Event{ID: "foo", ServiceId: "1", Title: "ValueError", Tags: []string{"env:production"}}))
+
+	buf := make([]byte, 65535)
+
+	require.Nil(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	n, err := conn.Read(buf)
+
+	require.Nil(t, err)
+
+	var m gelf.Message
+
+	require.Nil(t, json.Unmarshal(buf[:n], &m))
+
+	assert.Equal(t, "gelf", s.Name())
+	assert.Equal(t, "foo", m["_event_id"])
+	assert.Equal(t, "1", m["_service_id"])
+	assert.Equal(t, "ValueError", m["short_message"])
+	assert.Equal(t, "production", m["_tag_env"])
+}
+
+func TestBuildGelfSinkFallback(t *testing.T) {
+	t.Setenv("GELF_ADDRESS", "127.0.0.1:12201")
+	t.Setenv("GELF_PROTOCOL", "tcp")
+
+	s, err := BuildGelfSink(&settings.ConfigFile{})
+
+	require.Nil(t, err)
+	require.NotNil(t, s.fallback)
+	require.Nil(t, s.Close())
+
+	t.Setenv("GELF_PROTOCOL", "http")
+
+	_, err = BuildGelfSink(&settings.ConfigFile{})
+
+	require.ErrorIs(t, err, gelf.ErrUnsupportedProtocol)
+}
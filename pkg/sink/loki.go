@@ -0,0 +1,59 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/config"
+	bcevent "github.com/williampsena/bugs-channel/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/loki"
+)
+
+// Pushes events as JSON log lines to Loki
+type LokiSink struct {
+	batcher *loki.Batcher
+	labels  []string
+}
+
+// Returns the sink name
+func (s *LokiSink) Name() string {
+	return "loki"
+}
+
+// Adds the event to the batch of its label set
+func (s *LokiSink) Write(ctx context.Context, e event.Event) error {
+	line, err := json.Marshal(e)
+
+	if err != nil {
+		return err
+	}
+
+	return s.batcher.Add(ctx, loki.Labels(e, s.labels), loki.Entry{Timestamp: time.Now(), Line: string(line)})
+}
+
+// Pushes the pending events
+func (s *LokiSink) Close() error {
+	return s.batcher.Close()
+}
+
+// Build a new sink labelling the streams with the allowed event fields
+func NewLokiSink(batcher *loki.Batcher, labels []string) *LokiSink {
+	return &LokiSink{batcher, labels}
+}
+
+// Build the Loki sink from the environment settings
+func BuildLokiSink() *LokiSink {
+	batcher := loki.NewBatcher(loki.NewClient(config.LokiUrl(), config.LokiTenantId()), loki.BatcherSettings{
+		BatchSize:     config.LokiBatchSize(),
+		FlushInterval: config.LokiFlushInterval(),
+		Retry: bcevent.RetryPolicy{
+			Attempts:       config.DispatchRetryAttempts(),
+			InitialBackoff: config.DispatchRetryBackoff(),
+			MaxBackoff:     config.DispatchRetryMaxBackoff(),
+		},
+	})
+
+	return NewLokiSink(batcher, config.LokiLabels())
+}
@@ -0,0 +1,48 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+)
+
+func TestLokiSink(t *testing.T) {
+	var body struct {
+		Streams []struct {
+			Stream map[string]string `json:"stream"`
+			Values [][]string        `json:"values"`
+		} `json:"streams"`
+	}
+
+	loki := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		json.NewDecoder(req.Body).Decode(&body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	defer loki.Close()
+
+	t.Setenv("LOKI_URL", loki.URL)
+	t.Setenv("LOKI_LABELS", "service_id,level")
+
+	s := BuildLokiSink()
+
+	assert.Equal(t, "loki", s.Name())
+
+	require.Nil(t, s.Write(context.Background(), event.Event{ID: "foo", ServiceId: "1", Platform: "python", Level: "error"}))
+	require.Nil(t, s.Close())
+
+	require.Len(t, body.Streams, 1)
+	assert.Equal(t, map[string]string{"job": "bugs-channel", "service_id": "1", "level": "error"}, body.Streams[0].Stream)
+
+	var line event.Event
+
+	require.Len(t, body.Streams[0].Values, 1)
+	require.Nil(t, json.Unmarshal([]byte(body.Streams[0].Values[0][1]), &line))
+	assert.Equal(t, "foo", line.ID)
+}
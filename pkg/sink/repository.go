@@ -0,0 +1,56 @@
+package sink
+
+import (
+	"context"
+	"time"
+
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/issue"
+	"github.com/williampsena/bugs-channel/pkg/store"
+)
+
+// Persists events to an event repository
+type RepositorySink struct {
+	name    string
+	repo    store.EventRepository
+	tracker *issue.Tracker
+}
+
+// Returns the sink name
+func (r *RepositorySink) Name() string {
+	return r.name
+}
+
+// Save a event record received now, then count it on its issue when a tracker is set.
+// A redelivered event was already counted, so only new events are tracked.
+func (r *RepositorySink) Write(ctx context.Context, e event.Event) error {
+	record := store.NewEventRecord(e, time.Now())
+
+	inserted, err := r.repo.Save(ctx, record)
+
+	if err != nil {
+		return err
+	}
+
+	if r.tracker == nil || !inserted {
+		return nil
+	}
+
+	return r.tracker.Track(ctx, record)
+}
+
+// Close the repository
+func (r *RepositorySink) Close() error {
+	return r.repo.Close()
+}
+
+// Set the tracker that groups the saved events into issues
+func (r *RepositorySink) WithTracker(tracker *issue.Tracker) *RepositorySink {
+	r.tracker = tracker
+	return r
+}
+
+// Build a new sink persisting events to the repository
+func NewRepositorySink(name string, repo store.EventRepository) *RepositorySink {
+	return &RepositorySink{name: name, repo: repo}
+}
@@ -0,0 +1,78 @@
+package sink
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/issue"
+	"github.com/williampsena/bugs-channel/pkg/store"
+)
+
+func TestRepositorySink(t *testing.T) {
+	repo := &mockRepository{}
+	s := NewRepositorySink("mongo", repo)
+
+	require.Nil(t, s.Write(context.Background(), event.Event{ID: "foo", ServiceId: "1", Tags: []string{"fingerprint:abc"}}))
+	require.Nil(t, s.Close())
+
+	require.Len(t, repo.records, 1)
+	assert.Equal(t, "mongo", s.Name())
+	assert.Equal(t, "foo", repo.records[0].ID)
+	assert.Equal(t, "abc", repo.records[0].Fingerprint)
+	assert.False(t, repo.records[0].Timestamp.IsZero())
+	assert.True(t, repo.closed)
+}
+
+func TestRepositorySinkTracker(t *testing.T) {
+	ctx := context.Background()
+	repo, err := store.NewSQLiteEventRepository(ctx, filepath.Join(t.TempDir(), "events.db"), store.SQLiteSettings{})
+
+	require.Nil(t, err)
+
+	s := NewRepositorySink("sqlite", repo).WithTracker(issue.NewTracker(repo, nil, ""))
+
+	defer s.Close()
+
+	// the redelivered foo is not counted twice
+	for _, id := range []string{"foo", "bar", "foo"} {
+		require.Nil(t, s.Write(ctx, event.Event{ID: id, ServiceId: "1", Title: "ValueError", Tags: []string{"fingerprint:abc"}}))
+	}
+
+	found, err := repo.GetIssue(ctx, "1", "abc")
+
+	require.Nil(t, err)
+	assert.Equal(t, int64(2), found.Count)
+	assert.Equal(t, "ValueError", found.Title)
+	assert.Equal(t, store.IssueUnresolved, found.Status)
+}
+
+type mockRepository struct {
+	records []store.EventRecord
+	closed  bool
+}
+
+// Save a event record
+func (m *mockRepository) Save(ctx context.Context, record store.EventRecord) (bool, error) {
+	m.records = append(m.records, record)
+	return true, nil
+}
+
+// Returns every record
+func (m *mockRepository) Find(ctx context.Context, query store.EventQuery) ([]store.EventRecord, error) {
+	return m.records, nil
+}
+
+// Returns the record of the service event id
+func (m *mockRepository) Get(ctx context.Context, serviceId string, id string) (store.EventRecord, error) {
+	return store.EventRecord{}, store.ErrEventNotFound
+}
+
+// Close the repository
+func (m *mockRepository) Close() error {
+	m.closed = true
+	return nil
+}
@@ -0,0 +1,94 @@
+// This package contains the worker sinks, the targets consumed events are written to
+package sink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/config"
+	"github.com/williampsena/bugs-channel/pkg/issue"
+	"github.com/williampsena/bugs-channel/pkg/settings"
+	"github.com/williampsena/bugs-channel/pkg/storage"
+	"github.com/williampsena/bugs-channel/pkg/store"
+)
+
+// Represents an error when the sink is unknown
+var ErrUnsupportedSink = errors.New("the sink is not supported")
+
+// The sink interface
+type Sink interface {
+	// Returns the sink name used by logs and metrics
+	Name() string
+
+	// Write a event
+	Write(ctx context.Context, e event.Event) error
+
+	// Flush and release the sink resources
+	Close() error
+}
+
+// Build the sinks of the given names from the environment settings and the service settings,
+// the queue receives the issue regressions of the repository sinks
+func BuildSinks(names []string, queue storage.Queue, configFile *settings.ConfigFile) ([]Sink, error) {
+	sinks := make([]Sink, 0, len(names))
+
+	for _, name := range names {
+		s, err := buildSink(name, queue, configFile)
+
+		if err != nil {
+			return nil, errors.Join(err, CloseSinks(sinks))
+		}
+
+		sinks = append(sinks, s)
+	}
+
+	return sinks, nil
+}
+
+func buildSink(name string, queue storage.Queue, configFile *settings.ConfigFile) (Sink, error) {
+	switch name {
+	case "stdout":
+		return NewStdoutSink(), nil
+	case "file":
+		return NewFileSink(config.WorkerFilePath())
+	case "gelf":
+		return BuildGelfSink(configFile)
+	case "elasticsearch":
+		return BuildElasticsearchSink(context.Background())
+	case "loki":
+		return BuildLokiSink(), nil
+	case "webhook":
+		return BuildWebhookSink(queue, configFile)
+	case "mongo", "sqlite", "postgres":
+		repo, err := store.BuildEventRepository(context.Background(), name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		s := NewRepositorySink(name, repo)
+
+		if issues, ok := repo.(store.IssueRepository); ok {
+			s.WithTracker(issue.NewTracker(issues, queue, config.IssueRegressedTopic()))
+		}
+
+		return s, nil
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrUnsupportedSink, name)
+}
+
+// Close every sink, joining the errors
+func CloseSinks(sinks []Sink) error {
+	var errs []error
+
+	for _, s := range sinks {
+		if err := s.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("%v: %w", s.Name(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
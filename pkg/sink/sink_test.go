@@ -0,0 +1,29 @@
+package sink
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel/pkg/settings"
+)
+
+func TestBuildSinks(t *testing.T) {
+	t.Setenv("WORKER_FILE_PATH", filepath.Join(t.TempDir(), "events.jsonl"))
+
+	sinks, err := BuildSinks([]string{"stdout", "file"}, nil, &settings.ConfigFile{})
+
+	require.Nil(t, err)
+	require.Len(t, sinks, 2)
+	assert.Equal(t, "stdout", sinks[0].Name())
+	assert.Equal(t, "file", sinks[1].Name())
+
+	require.Nil(t, CloseSinks(sinks))
+}
+
+func TestBuildSinksUnsupported(t *testing.T) {
+	_, err := BuildSinks([]string{"stdout", "foo"}, nil, &settings.ConfigFile{})
+
+	require.ErrorIs(t, err, ErrUnsupportedSink)
+}
@@ -0,0 +1,100 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/config"
+	bcevent "github.com/williampsena/bugs-channel/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/issue"
+	"github.com/williampsena/bugs-channel/pkg/settings"
+	"github.com/williampsena/bugs-channel/pkg/storage"
+	"github.com/williampsena/bugs-channel/pkg/webhook"
+)
+
+// Posts the events, and the issue regressions when a webhook wants them, to the service webhooks
+type WebhookSink struct {
+	forwarder *webhook.Forwarder
+	stop      context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+// Returns the sink name
+func (s *WebhookSink) Name() string {
+	return "webhook"
+}
+
+// Post the event to the webhooks of its service
+func (s *WebhookSink) Write(ctx context.Context, e event.Event) error {
+	return s.forwarder.Forward(ctx, webhook.NewEventPayload(e))
+}
+
+// Stop consuming the issue regressions
+func (s *WebhookSink) Close() error {
+	s.stop()
+	s.wg.Wait()
+
+	return nil
+}
+
+// Consumes the regressed issues topic until the sink is closed
+func (s *WebhookSink) subscribe(queue storage.Queue, topic string) {
+	ctx, stop := context.WithCancel(context.Background())
+
+	s.stop = stop
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+
+		err := queue.Subscribe(ctx, topic, func(header map[string][]string, body string) error {
+			var message issue.RegressedMessage
+
+			if err := json.Unmarshal([]byte(body), &message); err != nil {
+				log.Warnf("⚠️ Dropping a malformed issue message: %v", err)
+				return nil
+			}
+
+			if err := s.forwarder.Forward(ctx, webhook.NewRegressedPayload(message)); err != nil {
+				log.Errorf("⛔ Something went wrong when posting the issue %v webhooks: %v", message.Issue.Fingerprint, err)
+				return err
+			}
+
+			return nil
+		})
+
+		if err != nil && !errors.Is(err, context.Canceled) {
+			log.Errorf("⛔ The webhook subscription to %v stopped: %v", topic, err)
+		}
+	}()
+}
+
+// Build a new sink posting to the forwarder destinations, the regressions are consumed from the queue when set
+func NewWebhookSink(forwarder *webhook.Forwarder, queue storage.Queue, regressedTopic string) *WebhookSink {
+	s := &WebhookSink{forwarder: forwarder, stop: func() {}}
+
+	if queue != nil && regressedTopic != "" && forwarder.Accepts(issue.RegressedMessageType) {
+		s.subscribe(queue, regressedTopic)
+	}
+
+	return s
+}
+
+// Build the webhook sink from the service webhooks in the configuration file
+func BuildWebhookSink(queue storage.Queue, configFile *settings.ConfigFile) (*WebhookSink, error) {
+	forwarder, err := webhook.BuildForwarder(configFile, config.WebhookTimeout(), bcevent.RetryPolicy{
+		Attempts:       config.DispatchRetryAttempts(),
+		InitialBackoff: config.DispatchRetryBackoff(),
+		MaxBackoff:     config.DispatchRetryMaxBackoff(),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWebhookSink(forwarder, queue, config.IssueRegressedTopic()), nil
+}
@@ -0,0 +1,120 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/issue"
+	"github.com/williampsena/bugs-channel/pkg/settings"
+	"github.com/williampsena/bugs-channel/pkg/storage"
+	"github.com/williampsena/bugs-channel/pkg/store"
+	"github.com/williampsena/bugs-channel/pkg/webhook"
+)
+
+func TestWebhookSink(t *testing.T) {
+	deliveries := make(chan string, 10)
+
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		deliveries <- req.Header.Get(webhook.DeliveryHeader)
+	}))
+
+	defer hook.Close()
+
+	queue, err := storage.NewMemoryQueue(10, storage.OverflowDropNewest)
+
+	require.Nil(t, err)
+
+	t.Setenv("ISSUE_REGRESSED_TOPIC", "issue.regressed")
+
+	s, err := BuildWebhookSink(queue, &settings.ConfigFile{
+		Services: []settings.ConfigFileService{
+			{Id: "1", Settings: settings.ConfigFileServiceSettings{Webhooks: []settings.ConfigFileWebhook{
+				{Url: hook.URL, Types: []string{"event", "issue.regressed"}},
+			}}},
+		},
+	})
+
+	require.Nil(t, err)
+
+	defer s.Close()
+
+	assert.Equal(t, "webhook", s.Name())
+
+	require.Nil(t, s.Write(context.Background(), event.Event{ID: "foo", ServiceId: "1"}))
+	assert.Equal(t, "event:foo", <-deliveries)
+
+	message, err := json.Marshal(issue.RegressedMessage{Type: issue.RegressedMessageType, EventId: "bar", Issue: store.Issue{ServiceId: "1"}})
+
+	require.Nil(t, err)
+
+	// the subscription starts in background, so publish until it is delivered
+	require.Eventually(t, func() bool {
+		if err := queue.Publish(context.Background(), "issue.regressed", string(message)); err != nil {
+			return false
+		}
+
+		select {
+		case delivery := <-deliveries:
+			return delivery == "issue.regressed:bar"
+		case <-time.After(10 * time.Millisecond):
+			return false
+		}
+	}, time.Second, 20*time.Millisecond)
+}
+
+func TestWebhookSinkRegressedFailure(t *testing.T) {
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	defer hook.Close()
+
+	message, err := json.Marshal(issue.RegressedMessage{Type: issue.RegressedMessageType, EventId: "bar", Issue: store.Issue{ServiceId: "1"}})
+
+	require.Nil(t, err)
+
+	queue := &mockRegressedQueue{message: string(message), errs: make(chan error, 1)}
+
+	t.Setenv("ISSUE_REGRESSED_TOPIC", "issue.regressed")
+	t.Setenv("DISPATCH_RETRY_ATTEMPTS", "1")
+
+	s, err := BuildWebhookSink(queue, &settings.ConfigFile{
+		Services: []settings.ConfigFileService{
+			{Id: "1", Settings: settings.ConfigFileServiceSettings{Webhooks: []settings.ConfigFileWebhook{
+				{Url: hook.URL, Types: []string{"issue.regressed"}},
+			}}},
+		},
+	})
+
+	require.Nil(t, err)
+
+	defer s.Close()
+
+	// the queue keeps the regression for a redelivery
+	assert.NotNil(t, <-queue.errs)
+}
+
+type mockRegressedQueue struct {
+	message string
+	errs    chan error
+}
+
+func (m *mockRegressedQueue) Publish(ctx context.Context, topic string, message string) error {
+	return nil
+}
+
+// Delivers the message once and records the handler error
+func (m *mockRegressedQueue) Subscribe(ctx context.Context, topic string, handler storage.SubscribeHandler) error {
+	m.errs <- handler(map[string][]string{}, m.message)
+
+	<-ctx.Done()
+
+	return ctx.Err()
+}
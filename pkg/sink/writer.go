@@ -0,0 +1,62 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+)
+
+// Writes events as JSON lines
+type WriterSink struct {
+	name    string
+	mu      sync.Mutex
+	encoder *json.Encoder
+	closer  io.Closer
+}
+
+// Returns the sink name
+func (w *WriterSink) Name() string {
+	return w.name
+}
+
+// Write a event as a JSON line
+func (w *WriterSink) Write(ctx context.Context, e event.Event) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.encoder.Encode(e)
+}
+
+// Close the underlying writer
+func (w *WriterSink) Close() error {
+	if w.closer == nil {
+		return nil
+	}
+
+	return w.closer.Close()
+}
+
+// Build a new sink writing JSON lines to the writer
+func NewWriterSink(name string, writer io.Writer, closer io.Closer) *WriterSink {
+	return &WriterSink{name: name, encoder: json.NewEncoder(writer), closer: closer}
+}
+
+// Build a new sink writing JSON lines to stdout
+func NewStdoutSink() *WriterSink {
+	return NewWriterSink("stdout", os.Stdout, nil)
+}
+
+// Build a new sink appending JSON lines to the file
+func NewFileSink(path string) (*WriterSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWriterSink("file", file, file), nil
+}
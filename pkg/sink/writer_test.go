@@ -0,0 +1,46 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+)
+
+func TestWriterSink(t *testing.T) {
+	var buf bytes.Buffer
+
+	s := NewWriterSink("buffer", &buf, nil)
+
+	require.Nil(t, s.Write(context.Background(), event.Event{ID: "foo", ServiceId: "1"}))
+	require.Nil(t, s.Write(context.Background(), event.Event{ID: "bar", ServiceId: "1"}))
+	require.Nil(t, s.Close())
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+
+	assert.Len(t, lines, 2)
+	assert.Contains(t, string(lines[0]), `"foo"`)
+	assert.Contains(t, string(lines[1]), `"bar"`)
+}
+
+func TestFileSinkAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	for _, id := range []string{"foo", "bar"} {
+		s, err := NewFileSink(path)
+
+		require.Nil(t, err)
+		require.Nil(t, s.Write(context.Background(), event.Event{ID: id}))
+		require.Nil(t, s.Close())
+	}
+
+	content, err := os.ReadFile(path)
+
+	require.Nil(t, err)
+	assert.Equal(t, 2, bytes.Count(content, []byte("\n")))
+}
@@ -0,0 +1,112 @@
+package spool
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/williampsena/bugs-channel/pkg/storage"
+)
+
+// A queue that falls back to the spool when publishing fails
+type SpoolingQueue struct {
+	queue storage.Queue
+	spool *Spool
+}
+
+// Publish a message, spooling it when the queue is unavailable.
+// While older messages are spooled new ones are spooled too, keeping the publish order.
+//
+// A spooled message counts as published: the spool replays it until the queue takes it,
+// replacing the dispatcher retries and dead letter topic. The errors that are not spooled are returned,
+// so the dispatcher retries and dead-letters them: a message the queue refused, a done context
+// and a spool failure, joined with the publish error.
+func (q *SpoolingQueue) Publish(ctx context.Context, topic string, message string) error {
+	var publishErr error
+
+	if !q.spool.Pending() {
+		publishErr = q.queue.Publish(ctx, topic, message)
+
+		if publishErr == nil {
+			return nil
+		}
+
+		if !spoolable(ctx, publishErr) {
+			return publishErr
+		}
+
+		log.Warnf("💡 The queue is unavailable, spooling the message: %v", publishErr)
+	}
+
+	if err := q.spool.Append(Record{Key: storage.MessageKey(ctx), Topic: topic, Message: message}); err != nil {
+		return errors.Join(publishErr, err)
+	}
+
+	return nil
+}
+
+// Indicate that the publish failed because the queue is unavailable, rather than because it refused the message
+func spoolable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	return !errors.Is(err, storage.ErrRabbitMQUnroutable)
+}
+
+// Subscribe to a topic of the wrapped queue
+func (q *SpoolingQueue) Subscribe(ctx context.Context, topic string, handler storage.SubscribeHandler) error {
+	return q.queue.Subscribe(ctx, topic, handler)
+}
+
+// Replays the spooled messages in order until the context is done
+func (q *SpoolingQueue) Drain(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if replayed, err := q.drainOnce(ctx); err != nil {
+			stats := q.spool.Stats()
+			log.Warnf("💡 The spool replay stopped after %v messages, %v are still spooled: %v", replayed, stats.Records, err)
+		} else if replayed > 0 {
+			log.Infof("📦 The spool replayed %v messages", replayed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Replays spooled messages until the spool is empty or publishing fails
+func (q *SpoolingQueue) drainOnce(ctx context.Context) (int, error) {
+	replayed := 0
+
+	for ctx.Err() == nil {
+		record, ok, err := q.spool.Peek()
+
+		if err != nil || !ok {
+			return replayed, err
+		}
+
+		if err := q.queue.Publish(storage.WithMessageKey(ctx, record.Key), record.Topic, record.Message); err != nil {
+			return replayed, err
+		}
+
+		if err := q.spool.Commit(); err != nil {
+			return replayed, err
+		}
+
+		replayed++
+	}
+
+	return replayed, nil
+}
+
+// Creates a new spooling queue
+func NewSpoolingQueue(queue storage.Queue, spool *Spool) *SpoolingQueue {
+	return &SpoolingQueue{queue, spool}
+}
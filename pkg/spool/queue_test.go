@@ -0,0 +1,119 @@
+package spool
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel/pkg/storage"
+)
+
+func TestSpoolingQueueFallback(t *testing.T) {
+	s, err := Open(t.TempDir(), 1<<10, 1<<20)
+
+	require.Nil(t, err)
+
+	defer s.Close()
+
+	inner := &mockQueue{err: errors.New("broker is down")}
+	queue := NewSpoolingQueue(inner, s)
+
+	ctx := storage.WithMessageKey(context.Background(), "1")
+
+	require.Nil(t, queue.Publish(ctx, "events", "foo"))
+
+	inner.err = nil
+
+	// keeps the order while older messages are spooled
+	require.Nil(t, queue.Publish(ctx, "events", "bar"))
+
+	assert.Empty(t, inner.messages)
+	assert.Equal(t, int64(2), s.Stats().Records)
+
+	replayed, err := queue.drainOnce(context.Background())
+
+	require.Nil(t, err)
+	assert.Equal(t, 2, replayed)
+	assert.Equal(t, []string{"foo", "bar"}, inner.messages)
+	assert.Equal(t, []string{"1", "1"}, inner.keys)
+
+	require.Nil(t, queue.Publish(ctx, "events", "baz"))
+
+	assert.Equal(t, []string{"foo", "bar", "baz"}, inner.messages)
+}
+
+func TestSpoolingQueueReturnsUnspooledErrors(t *testing.T) {
+	s, err := Open(t.TempDir(), 1<<10, 64)
+
+	require.Nil(t, err)
+
+	defer s.Close()
+
+	inner := &mockQueue{err: storage.ErrRabbitMQUnroutable}
+	queue := NewSpoolingQueue(inner, s)
+
+	// a refused message is left to the dispatcher retries and dead letter topic
+	assert.ErrorIs(t, queue.Publish(context.Background(), "events", "foo"), storage.ErrRabbitMQUnroutable)
+	assert.False(t, s.Pending())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	inner.err = context.Canceled
+
+	assert.ErrorIs(t, queue.Publish(ctx, "events", "foo"), context.Canceled)
+	assert.False(t, s.Pending())
+
+	// a full spool returns the publish error too
+	inner.err = errors.New("broker is down")
+
+	err = queue.Publish(context.Background(), "events", strings.Repeat("foo", 64))
+
+	assert.ErrorIs(t, err, ErrSpoolFull)
+	assert.ErrorContains(t, err, "broker is down")
+}
+
+func TestSpoolingQueueDrainStopsOnFailure(t *testing.T) {
+	s, err := Open(t.TempDir(), 1<<10, 1<<20)
+
+	require.Nil(t, err)
+
+	defer s.Close()
+
+	inner := &mockQueue{err: errors.New("broker is down")}
+	queue := NewSpoolingQueue(inner, s)
+
+	require.Nil(t, queue.Publish(context.Background(), "events", "foo"))
+
+	replayed, err := queue.drainOnce(context.Background())
+
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, replayed)
+	assert.True(t, s.Pending())
+}
+
+type mockQueue struct {
+	err      error
+	messages []string
+	keys     []string
+}
+
+// Publish a message
+func (q *mockQueue) Publish(ctx context.Context, topic string, message string) error {
+	if q.err != nil {
+		return q.err
+	}
+
+	q.messages = append(q.messages, message)
+	q.keys = append(q.keys, storage.MessageKey(ctx))
+
+	return nil
+}
+
+// Subscribe to a topic
+func (q *mockQueue) Subscribe(ctx context.Context, topic string, handler storage.SubscribeHandler) error {
+	return nil
+}
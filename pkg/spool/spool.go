@@ -0,0 +1,463 @@
+// This package provides a disk write-ahead spool for messages that could not be published
+package spool
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Represents an error when the spool reached its size cap
+var ErrSpoolFull = errors.New("the spool has reached its maximum size")
+
+// Represents an error when the spool directory cannot be used
+var ErrSpoolDirectory = errors.New("an error occurred while attempting to open the spool directory")
+
+const (
+	// The segment file extension
+	segmentExtension = ".seg"
+	// The file that keeps the replay position
+	cursorFile = "cursor"
+	// The frame header holds the payload length and its checksum
+	frameHeaderSize = 8
+)
+
+// Represents a spooled message
+type Record struct {
+	// The message key used by partitioned queues
+	Key string `json:"key,omitempty"`
+	// The topic the message was published to
+	Topic string `json:"topic"`
+	// The message body
+	Message string `json:"message"`
+}
+
+// Represents the spool depth
+type Stats struct {
+	// The records waiting to be replayed
+	Records int64 `json:"records"`
+	// The bytes used by the segment files
+	Bytes int64 `json:"bytes"`
+	// The segment files on disk
+	Segments int `json:"segments"`
+}
+
+// An append-only spool split in segment files, replayed in order
+type Spool struct {
+	mu           sync.Mutex
+	dir          string
+	segmentBytes int64
+	maxBytes     int64
+	segments     []uint64
+	sizes        map[uint64]int64
+	active       *os.File
+	cursorSeq    uint64
+	cursorOffset int64
+	records      int64
+	peekedSize   int64
+}
+
+// Open or create a spool in the given directory
+func Open(dir string, segmentBytes int64, maxBytes int64) (*Spool, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Join(ErrSpoolDirectory, err)
+	}
+
+	s := &Spool{
+		dir:          dir,
+		segmentBytes: segmentBytes,
+		maxBytes:     maxBytes,
+		sizes:        make(map[uint64]int64),
+	}
+
+	if err := s.load(); err != nil {
+		return nil, errors.Join(ErrSpoolDirectory, err)
+	}
+
+	if err := syncDir(dir); err != nil {
+		return nil, errors.Join(ErrSpoolDirectory, err)
+	}
+
+	return s, nil
+}
+
+// Loads the segments and cursor, truncating a torn record left by a crash
+func (s *Spool) load() error {
+	entries, err := os.ReadDir(s.dir)
+
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+
+		if !strings.HasSuffix(name, segmentExtension) {
+			continue
+		}
+
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, segmentExtension), 10, 64)
+
+		if err != nil {
+			continue
+		}
+
+		s.segments = append(s.segments, seq)
+	}
+
+	sort.Slice(s.segments, func(i, j int) bool { return s.segments[i] < s.segments[j] })
+
+	s.readCursor()
+
+	for _, seq := range s.segments {
+		if seq < s.cursorSeq {
+			os.Remove(s.segmentPath(seq))
+			continue
+		}
+
+		if err := s.scanSegment(seq); err != nil {
+			return err
+		}
+	}
+
+	s.segments = s.pendingSegments()
+
+	if len(s.segments) == 0 {
+		s.segments = []uint64{s.cursorSeq}
+		s.cursorOffset = 0
+	} else if s.segments[0] != s.cursorSeq {
+		s.cursorSeq = s.segments[0]
+		s.cursorOffset = 0
+	}
+
+	return s.openActive()
+}
+
+func (s *Spool) pendingSegments() []uint64 {
+	var segments []uint64
+
+	for _, seq := range s.segments {
+		if seq >= s.cursorSeq {
+			segments = append(segments, seq)
+		}
+	}
+
+	return segments
+}
+
+// Counts the pending records of a segment and drops a torn trailing record
+func (s *Spool) scanSegment(seq uint64) error {
+	f, err := os.OpenFile(s.segmentPath(seq), os.O_RDWR, 0o644)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	var offset int64
+
+	for {
+		_, size, err := readFrame(f, offset)
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			log.Warnf("💡 The spool segment %v has a torn record at %v, truncating it", seq, offset)
+
+			if err := f.Truncate(offset); err != nil {
+				return err
+			}
+
+			break
+		}
+
+		if seq > s.cursorSeq || offset >= s.cursorOffset {
+			s.records++
+		}
+
+		offset += size
+	}
+
+	s.sizes[seq] = offset
+
+	return nil
+}
+
+func (s *Spool) openActive() error {
+	seq := s.segments[len(s.segments)-1]
+	f, err := os.OpenFile(s.segmentPath(seq), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+
+	if err != nil {
+		return err
+	}
+
+	s.active = f
+
+	return nil
+}
+
+// Append a record at the tail of the spool, syncing it to disk before returning
+func (s *Spool) Append(record Record) error {
+	payload, err := json.Marshal(record)
+
+	if err != nil {
+		return err
+	}
+
+	frame := make([]byte, frameHeaderSize+len(payload))
+	binary.BigEndian.PutUint32(frame[0:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(frame[4:8], crc32.ChecksumIEEE(payload))
+	copy(frame[frameHeaderSize:], payload)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.totalBytes()+int64(len(frame)) > s.maxBytes {
+		return ErrSpoolFull
+	}
+
+	activeSeq := s.segments[len(s.segments)-1]
+
+	if s.sizes[activeSeq] > 0 && s.sizes[activeSeq]+int64(len(frame)) > s.segmentBytes {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+
+		activeSeq = s.segments[len(s.segments)-1]
+	}
+
+	if _, err := s.active.Write(frame); err != nil {
+		return err
+	}
+
+	// the frame is counted even when the sync fails, it may already be on disk and a torn one is truncated on open
+	s.sizes[activeSeq] += int64(len(frame))
+	s.records++
+
+	return s.active.Sync()
+}
+
+// Starts a new segment, syncing the directory so the new file survives a crash
+func (s *Spool) rotate() error {
+	if err := s.active.Close(); err != nil {
+		return err
+	}
+
+	s.segments = append(s.segments, s.segments[len(s.segments)-1]+1)
+
+	if err := s.openActive(); err != nil {
+		return err
+	}
+
+	return syncDir(s.dir)
+}
+
+// Returns the oldest record without removing it
+func (s *Spool) Peek() (Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for {
+		if s.cursorOffset < s.sizes[s.cursorSeq] {
+			break
+		}
+
+		if len(s.segments) == 1 {
+			return Record{}, false, nil
+		}
+
+		// the oldest segment was fully replayed
+		os.Remove(s.segmentPath(s.cursorSeq))
+		delete(s.sizes, s.cursorSeq)
+
+		s.segments = s.segments[1:]
+		s.cursorSeq = s.segments[0]
+		s.cursorOffset = 0
+
+		if err := s.writeCursor(); err != nil {
+			return Record{}, false, err
+		}
+	}
+
+	f, err := os.Open(s.segmentPath(s.cursorSeq))
+
+	if err != nil {
+		return Record{}, false, err
+	}
+
+	defer f.Close()
+
+	payload, size, err := readFrame(f, s.cursorOffset)
+
+	if err != nil {
+		return Record{}, false, err
+	}
+
+	var record Record
+
+	if err := json.Unmarshal(payload, &record); err != nil {
+		return Record{}, false, err
+	}
+
+	s.peekedSize = size
+
+	return record, true, nil
+}
+
+// Removes the record returned by the last Peek
+func (s *Spool) Commit() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.peekedSize == 0 {
+		return nil
+	}
+
+	s.cursorOffset += s.peekedSize
+	s.peekedSize = 0
+	s.records--
+
+	return s.writeCursor()
+}
+
+// Returns the spool depth
+func (s *Spool) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return Stats{Records: s.records, Bytes: s.totalBytes(), Segments: len(s.segments)}
+}
+
+// Indicate that there are records waiting to be replayed
+func (s *Spool) Pending() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.records > 0
+}
+
+// Publish the spool depth as the "spool" expvar
+func (s *Spool) PublishMetrics() {
+	expvar.Publish("spool", expvar.Func(func() any { return s.Stats() }))
+}
+
+// Close the active segment
+func (s *Spool) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.active.Close()
+}
+
+func (s *Spool) totalBytes() int64 {
+	var total int64
+
+	for _, size := range s.sizes {
+		total += size
+	}
+
+	return total
+}
+
+func (s *Spool) segmentPath(seq uint64) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%020d%v", seq, segmentExtension))
+}
+
+func (s *Spool) readCursor() {
+	raw, err := os.ReadFile(filepath.Join(s.dir, cursorFile))
+
+	if err != nil {
+		return
+	}
+
+	fmt.Sscanf(string(raw), "%d %d", &s.cursorSeq, &s.cursorOffset)
+}
+
+// Replaces the cursor file through a synced temporary file, so a crash leaves either cursor and never a torn one
+func (s *Spool) writeCursor() error {
+	path := filepath.Join(s.dir, cursorFile)
+	tmp := path + ".tmp"
+
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(f, "%d %d", s.cursorSeq, s.cursorOffset)
+
+	if err == nil {
+		err = f.Sync()
+	}
+
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+
+	return syncDir(s.dir)
+}
+
+// Syncs the directory entries, so created and renamed files survive a crash
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+
+	if err != nil {
+		return err
+	}
+
+	defer d.Close()
+
+	return d.Sync()
+}
+
+// Reads the frame at offset, returning its payload and total size
+func readFrame(f *os.File, offset int64) ([]byte, int64, error) {
+	header := make([]byte, frameHeaderSize)
+
+	n, err := f.ReadAt(header, offset)
+
+	if n == 0 && err == io.EOF {
+		return nil, 0, io.EOF
+	}
+
+	if err != nil {
+		return nil, 0, io.ErrUnexpectedEOF
+	}
+
+	length := binary.BigEndian.Uint32(header[0:4])
+	checksum := binary.BigEndian.Uint32(header[4:8])
+	payload := make([]byte, length)
+
+	if _, err := f.ReadAt(payload, offset+frameHeaderSize); err != nil {
+		return nil, 0, io.ErrUnexpectedEOF
+	}
+
+	if crc32.ChecksumIEEE(payload) != checksum {
+		return nil, 0, io.ErrUnexpectedEOF
+	}
+
+	return payload, frameHeaderSize + int64(length), nil
+}
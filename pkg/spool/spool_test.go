@@ -0,0 +1,118 @@
+package spool
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpoolReplayInOrder(t *testing.T) {
+	s, err := Open(t.TempDir(), 64, 1<<20)
+
+	require.Nil(t, err)
+
+	defer s.Close()
+
+	for _, message := range []string{"foo", "bar", "baz"} {
+		require.Nil(t, s.Append(Record{Key: "1", Topic: "events", Message: message}))
+	}
+
+	stats := s.Stats()
+
+	assert.Equal(t, int64(3), stats.Records)
+	assert.Greater(t, stats.Segments, 1)
+
+	assert.Equal(t, []string{"foo", "bar", "baz"}, drainSpool(t, s))
+	assert.False(t, s.Pending())
+	assert.Equal(t, 1, s.Stats().Segments)
+}
+
+func TestSpoolReopen(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(dir, 1<<10, 1<<20)
+
+	require.Nil(t, err)
+
+	require.Nil(t, s.Append(Record{Topic: "events", Message: "foo"}))
+	require.Nil(t, s.Append(Record{Topic: "events", Message: "bar"}))
+
+	record, ok, err := s.Peek()
+
+	require.Nil(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "foo", record.Message)
+	require.Nil(t, s.Commit())
+
+	s.Close()
+
+	s, err = Open(dir, 1<<10, 1<<20)
+
+	require.Nil(t, err)
+
+	defer s.Close()
+
+	assert.Equal(t, int64(1), s.Stats().Records)
+	assert.Equal(t, []string{"bar"}, drainSpool(t, s))
+}
+
+func TestSpoolTruncatesTornRecord(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(dir, 1<<10, 1<<20)
+
+	require.Nil(t, err)
+	require.Nil(t, s.Append(Record{Topic: "events", Message: "foo"}))
+
+	s.Close()
+
+	f, err := os.OpenFile(filepath.Join(dir, "00000000000000000000.seg"), os.O_WRONLY|os.O_APPEND, 0o644)
+
+	require.Nil(t, err)
+
+	f.Write([]byte{0, 0, 0, 42, 1, 2})
+	f.Close()
+
+	s, err = Open(dir, 1<<10, 1<<20)
+
+	require.Nil(t, err)
+
+	defer s.Close()
+
+	require.Nil(t, s.Append(Record{Topic: "events", Message: "bar"}))
+
+	assert.Equal(t, []string{"foo", "bar"}, drainSpool(t, s))
+}
+
+func TestSpoolFull(t *testing.T) {
+	s, err := Open(t.TempDir(), 1<<10, 64)
+
+	require.Nil(t, err)
+
+	defer s.Close()
+
+	require.Nil(t, s.Append(Record{Topic: "events", Message: "foo"}))
+
+	err = s.Append(Record{Topic: "events", Message: "bar"})
+
+	assert.Equal(t, ErrSpoolFull, err)
+}
+
+func drainSpool(t *testing.T, s *Spool) []string {
+	var messages []string
+
+	for {
+		record, ok, err := s.Peek()
+
+		require.Nil(t, err)
+
+		if !ok {
+			return messages
+		}
+
+		messages = append(messages, record.Message)
+
+		require.Nil(t, s.Commit())
+	}
+}
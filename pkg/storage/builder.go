@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/williampsena/bugs-channel/pkg/config"
+)
+
+// Represents an error when the event channel is unknown
+var ErrUnsupportedEventChannel = errors.New("the event channel is not supported")
+
+// Build the queue of the given event channel from the environment settings
+func BuildQueue(eventChannel string) (Queue, error) {
+	switch eventChannel {
+	case "nats":
+		return buildNatsQueue()
+	case "redis":
+		return NewRedisConnection(config.RedisConnectionUrl())
+	case "redis-streams":
+		return NewRedisStreamsConnection(config.RedisConnectionUrl(), RedisStreamsSettings{
+			MaxLen:    config.RedisStreamMaxLen(),
+			Group:     config.RedisStreamGroup(),
+			Consumer:  config.RedisStreamConsumer(),
+			ClaimIdle: config.RedisStreamClaimIdle(),
+			Block:     5 * time.Second,
+		})
+	case "rabbitmq":
+		return NewRabbitMQConnection(config.RabbitMQConnectionUrl(), RabbitMQSettings{
+			Exchange:        config.RabbitMQExchange(),
+			Queue:           config.RabbitMQQueue(),
+			Prefetch:        config.RabbitMQPrefetch(),
+			Topics:          append(config.RabbitMQTopics(), config.IssueRegressedTopic()),
+			DeadLetterTopic: config.DispatchDeadLetterTopic(),
+		})
+	case "kafka":
+		return NewKafkaConnection(KafkaSettings{
+			Brokers:         config.KafkaBrokers(),
+			GroupId:         config.KafkaGroupId(),
+			SASLMechanism:   config.KafkaSASLMechanism(),
+			Username:        config.KafkaUsername(),
+			Password:        config.KafkaPassword(),
+			TLS:             config.KafkaTLS(),
+			MaxAttempts:     config.KafkaMaxAttempts(),
+			DeadLetterTopic: config.KafkaDeadLetterTopic(),
+		})
+	case "memory":
+		return NewMemoryQueue(config.MemoryQueueBufferSize(), OverflowPolicy(config.MemoryQueueOverflow()))
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrUnsupportedEventChannel, eventChannel)
+}
+
+// Indicate that the queue of the event channel subscribes to "*" and ">" wildcard topics,
+// Redis streams and Kafka only consume exact streams and topics
+func SupportsWildcardTopics(eventChannel string) bool {
+	switch eventChannel {
+	case "redis-streams", "kafka":
+		return false
+	}
+
+	return true
+}
+
+func buildNatsQueue() (Queue, error) {
+	if !config.NatsJetStream() {
+		return NewNatsConnection(config.NatsConnectionUrl())
+	}
+
+	return NewNatsJetStreamConnection(config.NatsConnectionUrl(), NatsJetStreamSettings{
+		Stream:     config.NatsStream(),
+		Subjects:   natsStreamSubjects(config.NatsStreamSubjects(), config.IssueRegressedTopic(), config.DispatchDeadLetterTopic()),
+		Durable:    config.NatsDurable(),
+		AckWait:    config.NatsAckWait(),
+		MaxDeliver: config.NatsMaxDeliver(),
+	})
+}
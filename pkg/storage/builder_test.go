@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildQueue(t *testing.T) {
+	t.Setenv("MEMORY_QUEUE_BUFFER_SIZE", "8")
+
+	queue, err := BuildQueue("memory")
+
+	require.Nil(t, err)
+	assert.IsType(t, &MemoryQueue{}, queue)
+
+	_, err = BuildQueue("carrier-pigeon")
+
+	assert.True(t, errors.Is(err, ErrUnsupportedEventChannel))
+}
+
+func TestSupportsWildcardTopics(t *testing.T) {
+	for _, eventChannel := range []string{"nats", "redis", "rabbitmq", "memory"} {
+		assert.True(t, SupportsWildcardTopics(eventChannel), eventChannel)
+	}
+
+	assert.False(t, SupportsWildcardTopics("redis-streams"))
+	assert.False(t, SupportsWildcardTopics("kafka"))
+}
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Represents a message that could not be published or handled after every attempt
+type DeadLetter struct {
+	// The event id
+	ID string `json:"id"`
+	// The message key used by partitioned queues
+	Key string `json:"key,omitempty"`
+	// The topic the event was meant for
+	Topic string `json:"topic"`
+	// The last publish or handler error
+	Reason string `json:"reason"`
+	// The attempts made
+	Attempts int `json:"attempts"`
+	// When the event was dead-lettered
+	FailedAt time.Time `json:"failed_at"`
+	// The event payload
+	Message json.RawMessage `json:"message"`
+}
+
+// Build the dead letter envelope of a message a consumer gave up on, a message that is not JSON is kept as a string
+func buildDeadLetter(topic string, key string, message string, attempts int, reason error) (string, error) {
+	payload := json.RawMessage(message)
+
+	var e struct {
+		ID string `json:"id"`
+	}
+
+	if err := json.Unmarshal(payload, &e); err != nil {
+		payload, _ = json.Marshal(message)
+	}
+
+	envelope, err := json.Marshal(DeadLetter{
+		ID:       e.ID,
+		Key:      key,
+		Topic:    topic,
+		Reason:   reason.Error(),
+		Attempts: attempts,
+		FailedAt: time.Now().UTC(),
+		Message:  payload,
+	})
+
+	return string(envelope), err
+}
@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildDeadLetter(t *testing.T) {
+	envelope, err := buildDeadLetter("events", "1", `{"id":"foo"}`, 3, errors.New("sink is down"))
+
+	require.Nil(t, err)
+
+	var deadLetter DeadLetter
+
+	require.Nil(t, json.Unmarshal([]byte(envelope), &deadLetter))
+
+	assert.Equal(t, "foo", deadLetter.ID)
+	assert.Equal(t, "1", deadLetter.Key)
+	assert.Equal(t, "events", deadLetter.Topic)
+	assert.Equal(t, "sink is down", deadLetter.Reason)
+	assert.Equal(t, 3, deadLetter.Attempts)
+	assert.False(t, deadLetter.FailedAt.IsZero())
+	assert.JSONEq(t, `{"id":"foo"}`, string(deadLetter.Message))
+
+	envelope, err = buildDeadLetter("events", "", "not json", 1, errors.New("malformed"))
+
+	require.Nil(t, err)
+	require.Nil(t, json.Unmarshal([]byte(envelope), &deadLetter))
+
+	assert.Equal(t, "", deadLetter.ID)
+	assert.Equal(t, `"not json"`, string(deadLetter.Message))
+}
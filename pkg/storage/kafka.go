@@ -0,0 +1,316 @@
+package storage
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+	log "github.com/sirupsen/logrus"
+)
+
+// Represents a Kafka connection settings error
+var ErrKafkaSettings = errors.New("the Kafka settings are invalid")
+
+// Represents a Kafka subscribe error
+var ErrKafkaSubscribe = errors.New("an error occurred while attempting to consume a Kafka topic")
+
+// Represents the Kafka brokers, consumer group and security settings
+type KafkaSettings struct {
+	// The bootstrap brokers
+	Brokers []string
+	// The consumer group id
+	GroupId string
+	// The SASL mechanism (plain/scram-sha-256/scram-sha-512), empty disables SASL
+	SASLMechanism string
+	// The SASL username
+	Username string
+	// The SASL password
+	Password string
+	// Indicate that connections must use TLS
+	TLS bool
+	// How many times a message is handled before being dead-lettered
+	MaxAttempts int
+	// The topic the dead letter envelopes of the messages whose attempts are exhausted go to, empty skips them
+	DeadLetterTopic string
+}
+
+// The Kafka producer interface
+type kafkaWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// The Kafka instance
+type Kafka struct {
+	writer   kafkaWriter
+	dialer   *kafka.Dialer
+	settings KafkaSettings
+}
+
+// Build a new Kafka producer, consumers are created per subscription
+func NewKafkaConnection(settings KafkaSettings) (Queue, error) {
+	if len(settings.Brokers) == 0 {
+		return nil, fmt.Errorf("%w: at least one broker is required", ErrKafkaSettings)
+	}
+
+	mechanism, err := buildKafkaSASLMechanism(settings)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var tlsConfig *tls.Config
+
+	if settings.TLS {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	writer := &kafka.Writer{
+		Addr:                   kafka.TCP(settings.Brokers...),
+		Balancer:               &kafka.Hash{},
+		RequiredAcks:           kafka.RequireAll,
+		AllowAutoTopicCreation: true,
+		// messages are published one at a time, so a batch must not wait the default second to fill up
+		BatchTimeout: 5 * time.Millisecond,
+		Transport: &kafka.Transport{
+			SASL: mechanism,
+			TLS:  tlsConfig,
+		},
+	}
+
+	dialer := &kafka.Dialer{
+		Timeout:       10 * time.Second,
+		DualStack:     true,
+		SASLMechanism: mechanism,
+		TLS:           tlsConfig,
+	}
+
+	return &Kafka{writer, dialer, settings}, nil
+}
+
+func buildKafkaSASLMechanism(settings KafkaSettings) (sasl.Mechanism, error) {
+	switch settings.SASLMechanism {
+	case "":
+		return nil, nil
+	case "plain":
+		return plain.Mechanism{Username: settings.Username, Password: settings.Password}, nil
+	case "scram-sha-256":
+		return scram.Mechanism(scram.SHA256, settings.Username, settings.Password)
+	case "scram-sha-512":
+		return scram.Mechanism(scram.SHA512, settings.Username, settings.Password)
+	default:
+		return nil, fmt.Errorf("%w: unsupported SASL mechanism %q", ErrKafkaSettings, settings.SASLMechanism)
+	}
+}
+
+// Publish a message keyed by the context message key, so a service keeps its ordering
+func (k *Kafka) Publish(ctx context.Context, topic string, message string) error {
+	msg := kafka.Message{
+		Topic: topic,
+		Value: []byte(message),
+	}
+
+	if key := MessageKey(ctx); key != "" {
+		msg.Key = []byte(key)
+	}
+
+	return k.writer.WriteMessages(ctx, msg)
+}
+
+// Subscribe to a topic through the consumer group until the context is done.
+// Messages are handled one at a time whatever the context concurrency, since the offsets are committed in order.
+func (k *Kafka) Subscribe(ctx context.Context, topic string, handler SubscribeHandler) error {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: k.settings.Brokers,
+		GroupID: k.settings.GroupId,
+		Topic:   topic,
+		Dialer:  k.dialer,
+	})
+
+	defer reader.Close()
+
+	for {
+		msg, err := reader.FetchMessage(ctx)
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return errors.Join(ErrKafkaSubscribe, err)
+		}
+
+		if !k.handleMessage(ctx, msg, handler) {
+			return nil
+		}
+
+		if err := reader.CommitMessages(context.WithoutCancel(ctx), msg); err != nil {
+			log.Errorf("⛔ An error occurred while committing the Kafka offset %v of %v: %v", msg.Offset, topic, err)
+		}
+	}
+}
+
+// Handles a message retrying failures, then dead-letters it once the attempts are exhausted.
+// It returns false when the context is done before the message is settled,
+// so its offset stays uncommitted and it is consumed again later.
+func (k *Kafka) handleMessage(ctx context.Context, msg kafka.Message, handler SubscribeHandler) bool {
+	headers := buildKafkaHeaders(msg)
+
+	for attempt := 1; ; attempt++ {
+		err := handler(headers, string(msg.Value))
+
+		if err == nil {
+			return true
+		}
+
+		if attempt >= k.settings.MaxAttempts {
+			log.Errorf("⛔ The Kafka handler gave up on offset %v of %v after %v attempts: %v", msg.Offset, msg.Topic, attempt, err)
+			return k.deadLetter(ctx, msg, attempt, err)
+		}
+
+		log.Warnf("💡 The Kafka handler failed on offset %v of %v, retrying: %v", msg.Offset, msg.Topic, err)
+
+		if !sleepContext(ctx, time.Duration(attempt)*time.Second) {
+			return false
+		}
+	}
+}
+
+// Publishes the dead letter envelope of the message until it succeeds, returning false when the context is done first.
+// The envelope is the one the dispatcher writes, so cmd/dlq lists and re-drives the dead letter topic.
+func (k *Kafka) deadLetter(ctx context.Context, msg kafka.Message, attempts int, reason error) bool {
+	if k.settings.DeadLetterTopic == "" {
+		return true
+	}
+
+	envelope, err := buildDeadLetter(msg.Topic, string(msg.Key), string(msg.Value), attempts, reason)
+
+	if err != nil {
+		log.Errorf("⛔ An error occurred while building the dead letter of the Kafka offset %v of %v: %v", msg.Offset, msg.Topic, err)
+		return true
+	}
+
+	deadLetter := kafka.Message{
+		Topic:   k.settings.DeadLetterTopic,
+		Key:     msg.Key,
+		Value:   []byte(envelope),
+		Headers: slices.Clone(msg.Headers),
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := k.writer.WriteMessages(ctx, deadLetter)
+
+		if err == nil {
+			return true
+		}
+
+		log.Errorf("⛔ An error occurred while dead-lettering the Kafka offset %v of %v: %v", msg.Offset, msg.Topic, err)
+
+		if !sleepContext(ctx, time.Duration(min(attempt, 30))*time.Second) {
+			return false
+		}
+	}
+}
+
+// Waits for the duration, returning false when the context is done first
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+// Reads every partition of the topic from its first offset to its current end, without a consumer group
+func (k *Kafka) Peek(ctx context.Context, topic string, handler SubscribeHandler) error {
+	partitions, err := k.dialer.LookupPartitions(ctx, "tcp", k.settings.Brokers[0], topic)
+
+	if err != nil {
+		return errors.Join(ErrKafkaSubscribe, err)
+	}
+
+	for _, partition := range partitions {
+		if err := k.peekPartition(ctx, partition, handler); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (k *Kafka) peekPartition(ctx context.Context, partition kafka.Partition, handler SubscribeHandler) error {
+	conn, err := k.dialer.DialPartition(ctx, "tcp", "", partition)
+
+	if err != nil {
+		return errors.Join(ErrKafkaSubscribe, err)
+	}
+
+	first, last, err := conn.ReadOffsets()
+
+	conn.Close()
+
+	if err != nil {
+		return errors.Join(ErrKafkaSubscribe, err)
+	}
+
+	if first >= last {
+		return nil
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:   k.settings.Brokers,
+		Topic:     partition.Topic,
+		Partition: partition.ID,
+		Dialer:    k.dialer,
+	})
+
+	defer reader.Close()
+
+	if err := reader.SetOffset(first); err != nil {
+		return errors.Join(ErrKafkaSubscribe, err)
+	}
+
+	for {
+		msg, err := reader.ReadMessage(ctx)
+
+		if err != nil {
+			return errors.Join(ErrKafkaSubscribe, err)
+		}
+
+		if err := handler(buildKafkaHeaders(msg), string(msg.Value)); err != nil {
+			return err
+		}
+
+		if msg.Offset >= last-1 {
+			return nil
+		}
+	}
+}
+
+func buildKafkaHeaders(msg kafka.Message) map[string][]string {
+	headers := map[string][]string{
+		"topic":     {msg.Topic},
+		"key":       {string(msg.Key)},
+		"partition": {fmt.Sprintf("%v", msg.Partition)},
+		"offset":    {fmt.Sprintf("%v", msg.Offset)},
+	}
+
+	for _, h := range msg.Headers {
+		headers[h.Key] = append(headers[h.Key], string(h.Value))
+	}
+
+	return headers
+}
+
+// Close Kafka connection
+func (k *Kafka) Close() {
+	k.writer.Close()
+}
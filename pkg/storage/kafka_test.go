@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKafkaSettings(t *testing.T) {
+	_, err := NewKafkaConnection(KafkaSettings{})
+	assert.True(t, errors.Is(err, ErrKafkaSettings))
+
+	_, err = NewKafkaConnection(KafkaSettings{Brokers: []string{"localhost:9092"}, SASLMechanism: "unknown"})
+	assert.True(t, errors.Is(err, ErrKafkaSettings))
+
+	for _, mechanism := range []string{"", "plain", "scram-sha-256", "scram-sha-512"} {
+		queue, err := NewKafkaConnection(KafkaSettings{
+			Brokers:       []string{"localhost:9092"},
+			SASLMechanism: mechanism,
+			Username:      "foo",
+			Password:      "bar",
+			TLS:           true,
+		})
+
+		require.Nil(t, err)
+		assert.Equal(t, 5*time.Millisecond, queue.(*Kafka).writer.(*kafka.Writer).BatchTimeout)
+
+		queue.(*Kafka).Close()
+	}
+}
+
+func TestKafkaHandleMessageRetries(t *testing.T) {
+	queue := &Kafka{settings: KafkaSettings{MaxAttempts: 2}}
+	msg := kafka.Message{Topic: "events", Key: []byte("1"), Value: []byte("foo")}
+
+	attempts := 0
+
+	settled := queue.handleMessage(context.Background(), msg, func(header map[string][]string, body string) error {
+		assert.Equal(t, []string{"1"}, header["key"])
+
+		attempts++
+
+		return errors.New("handler failed")
+	})
+
+	assert.True(t, settled)
+	assert.Equal(t, 2, attempts)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	settled = queue.handleMessage(ctx, msg, func(header map[string][]string, body string) error {
+		return errors.New("handler failed")
+	})
+
+	assert.False(t, settled)
+}
+
+func TestKafkaHandleMessageDeadLetter(t *testing.T) {
+	writer := &mockKafkaWriter{}
+	queue := &Kafka{writer: writer, settings: KafkaSettings{MaxAttempts: 1, DeadLetterTopic: "events.consumer.dlq"}}
+	msg := kafka.Message{Topic: "events", Key: []byte("1"), Value: []byte("foo"), Headers: []kafka.Header{{Key: "trace", Value: []byte("abc")}}}
+
+	settled := queue.handleMessage(context.Background(), msg, func(header map[string][]string, body string) error {
+		return errors.New("handler failed")
+	})
+
+	assert.True(t, settled)
+	require.Len(t, writer.messages, 1)
+
+	msgDeadLetter := writer.messages[0]
+
+	assert.Equal(t, "events.consumer.dlq", msgDeadLetter.Topic)
+	assert.Equal(t, []byte("1"), msgDeadLetter.Key)
+	assert.Equal(t, []kafka.Header{{Key: "trace", Value: []byte("abc")}}, msgDeadLetter.Headers)
+
+	var deadLetter DeadLetter
+
+	require.Nil(t, json.Unmarshal(msgDeadLetter.Value, &deadLetter))
+
+	assert.Equal(t, "events", deadLetter.Topic)
+	assert.Equal(t, "1", deadLetter.Key)
+	assert.Equal(t, "handler failed", deadLetter.Reason)
+	assert.Equal(t, 1, deadLetter.Attempts)
+	assert.Equal(t, `"foo"`, string(deadLetter.Message))
+
+	// the offset is not committed while the dead letter cannot be published
+	writer.err = errors.New("broker is down")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	settled = queue.handleMessage(ctx, msg, func(header map[string][]string, body string) error {
+		return errors.New("handler failed")
+	})
+
+	assert.False(t, settled)
+}
+
+// Runs against a local broker, e.g. KAFKA_TEST_BROKERS=localhost:9092 after `docker compose up kafka`
+func TestKafkaPublishSubscribe(t *testing.T) {
+	brokers := os.Getenv("KAFKA_TEST_BROKERS")
+
+	if brokers == "" {
+		t.Skip("KAFKA_TEST_BROKERS is not set")
+	}
+
+	queue, err := NewKafkaConnection(KafkaSettings{
+		Brokers:     strings.Split(brokers, ","),
+		GroupId:     "bugs-channel-test",
+		MaxAttempts: 1,
+	})
+
+	require.Nil(t, err)
+
+	defer queue.(*Kafka).Close()
+
+	topic := "events-test"
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	require.Nil(t, queue.Publish(WithMessageKey(ctx, "1"), topic, "foo"))
+
+	var received string
+
+	err = queue.Subscribe(ctx, topic, func(header map[string][]string, body string) error {
+		received = body
+		cancel()
+		return nil
+	})
+
+	require.Nil(t, err)
+	assert.Equal(t, "foo", received)
+}
+
+type mockKafkaWriter struct {
+	messages []kafka.Message
+	err      error
+}
+
+// Write the messages
+func (m *mockKafkaWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	m.messages = append(m.messages, msgs...)
+
+	return nil
+}
+
+// Close the writer
+func (m *mockKafkaWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,225 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Represents an error when the memory queue has been closed
+var ErrMemoryQueueClosed = errors.New("the memory queue is closed")
+
+// Represents an error when the memory queue settings are invalid
+var ErrMemoryQueueSettings = errors.New("the memory queue settings are invalid")
+
+// The behavior when a subscriber buffer is full
+type OverflowPolicy string
+
+const (
+	// Wait until the subscriber has room or the publish context is done
+	OverflowBlock OverflowPolicy = "block"
+	// Discard the oldest buffered message to make room for the new one
+	OverflowDropOldest OverflowPolicy = "drop_oldest"
+	// Discard the message being published
+	OverflowDropNewest OverflowPolicy = "drop_newest"
+)
+
+// The in-memory queue instance
+type MemoryQueue struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[*memorySubscriber]struct{}
+	bufferSize  int
+	overflow    OverflowPolicy
+	closed      bool
+}
+
+type memorySubscriber struct {
+	messages chan memoryMessage
+	done     chan struct{}
+	once     sync.Once
+}
+
+type memoryMessage struct {
+	topic string
+	body  string
+}
+
+// Build a new in-memory queue
+func NewMemoryQueue(bufferSize int, overflow OverflowPolicy) (Queue, error) {
+	if bufferSize <= 0 {
+		return nil, fmt.Errorf("%w: buffer size must be positive, got %v", ErrMemoryQueueSettings, bufferSize)
+	}
+
+	switch overflow {
+	case OverflowBlock, OverflowDropOldest, OverflowDropNewest:
+	default:
+		return nil, fmt.Errorf("%w: unsupported overflow policy %q", ErrMemoryQueueSettings, overflow)
+	}
+
+	return &MemoryQueue{
+		subscribers: make(map[string]map[*memorySubscriber]struct{}),
+		bufferSize:  bufferSize,
+		overflow:    overflow,
+	}, nil
+}
+
+// Publish a message to every subscriber of a topic matching it, wildcard topics are matched like NATS subjects
+func (m *MemoryQueue) Publish(ctx context.Context, topic string, message string) error {
+	m.mu.RLock()
+
+	if m.closed {
+		m.mu.RUnlock()
+		return ErrMemoryQueueClosed
+	}
+
+	var subscribers []*memorySubscriber
+
+	for pattern, patternSubscribers := range m.subscribers {
+		if !natsSubjectMatch(pattern, topic) {
+			continue
+		}
+
+		for s := range patternSubscribers {
+			subscribers = append(subscribers, s)
+		}
+	}
+
+	m.mu.RUnlock()
+
+	for _, s := range subscribers {
+		if err := m.deliver(ctx, s, memoryMessage{topic, message}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *MemoryQueue) deliver(ctx context.Context, s *memorySubscriber, message memoryMessage) error {
+	switch m.overflow {
+	case OverflowDropNewest:
+		select {
+		case s.messages <- message:
+		case <-s.done:
+		default:
+			log.Warnf("💡 The memory queue buffer is full, dropping the newest message")
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case s.messages <- message:
+				return nil
+			case <-s.done:
+				return nil
+			default:
+			}
+
+			select {
+			case <-s.messages:
+				log.Warnf("💡 The memory queue buffer is full, dropping the oldest message")
+			default:
+			}
+		}
+	default:
+		select {
+		case s.messages <- message:
+		case <-s.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// Subscribe to a topic, which may hold "*" and ">" wildcards, until the context is done or the queue is closed,
+// handling up to the context concurrency messages at the same time
+func (m *MemoryQueue) Subscribe(ctx context.Context, topic string, handler SubscribeHandler) error {
+	s := &memorySubscriber{
+		messages: make(chan memoryMessage, m.bufferSize),
+		done:     make(chan struct{}),
+	}
+
+	m.mu.Lock()
+
+	if m.closed {
+		m.mu.Unlock()
+		return ErrMemoryQueueClosed
+	}
+
+	if m.subscribers[topic] == nil {
+		m.subscribers[topic] = make(map[*memorySubscriber]struct{})
+	}
+
+	m.subscribers[topic][s] = struct{}{}
+
+	m.mu.Unlock()
+
+	defer m.unsubscribe(topic, s)
+
+	pool := newHandlerPool(Concurrency(ctx))
+
+	defer pool.Wait()
+
+	// a message is only taken from the buffer once a handler is free, so the overflow policy applies meanwhile
+	for pool.Acquire(ctx) {
+		select {
+		case <-ctx.Done():
+			pool.Release()
+			return nil
+		case <-s.done:
+			pool.Release()
+			return nil
+		case message := <-s.messages:
+			pool.Run(func() {
+				if err := handler(map[string][]string{"topic": {message.topic}}, message.body); err != nil {
+					log.Errorf("⛔ The memory queue handler failed on topic %v: %v", message.topic, err)
+				}
+			})
+		}
+	}
+
+	return nil
+}
+
+func (m *MemoryQueue) unsubscribe(topic string, s *memorySubscriber) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.subscribers[topic], s)
+
+	if len(m.subscribers[topic]) == 0 {
+		delete(m.subscribers, topic)
+	}
+
+	s.close()
+}
+
+// Returns how many subscribers the topic has
+func (m *MemoryQueue) SubscriberCount(topic string) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.subscribers[topic])
+}
+
+func (s *memorySubscriber) close() {
+	s.once.Do(func() { close(s.done) })
+}
+
+// Close the memory queue, releasing every subscriber
+func (m *MemoryQueue) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.closed = true
+
+	for _, subscribers := range m.subscribers {
+		for s := range subscribers {
+			s.close()
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryQueueFanOut(t *testing.T) {
+	queue := buildTestMemoryQueue(t, 8, OverflowBlock)
+
+	var wg sync.WaitGroup
+	received := make([][]string, 2)
+
+	for i := range received {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			queue.Subscribe(ctx, "events", func(header map[string][]string, body string) error {
+				assert.Equal(t, []string{"events"}, header["topic"])
+
+				received[i] = append(received[i], body)
+
+				if len(received[i]) == 2 {
+					cancel()
+				}
+
+				return nil
+			})
+		}(i)
+	}
+
+	waitForSubscribers(t, queue, "events", 2)
+
+	require.Nil(t, queue.Publish(context.Background(), "others", "ignored"))
+	require.Nil(t, queue.Publish(context.Background(), "events", "foo"))
+	require.Nil(t, queue.Publish(context.Background(), "events", "bar"))
+
+	wg.Wait()
+
+	for _, messages := range received {
+		assert.Equal(t, []string{"foo", "bar"}, messages)
+	}
+
+	assert.Equal(t, 0, queue.SubscriberCount("events"))
+}
+
+func TestMemoryQueueSubscribeWildcard(t *testing.T) {
+	queue := buildTestMemoryQueue(t, 8, OverflowBlock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	topics := make(chan string, 10)
+
+	go queue.Subscribe(ctx, "events.foo.*.python", func(header map[string][]string, body string) error {
+		topics <- header["topic"][0]
+		return nil
+	})
+
+	go queue.Subscribe(ctx, "events.>", func(header map[string][]string, body string) error {
+		topics <- header["topic"][0] + " " + body
+		return nil
+	})
+
+	waitForSubscribers(t, queue, "events.foo.*.python", 1)
+	waitForSubscribers(t, queue, "events.>", 1)
+
+	require.Nil(t, queue.Publish(context.Background(), "events", "a"))
+	require.Nil(t, queue.Publish(context.Background(), "events.foo.1.python", "b"))
+
+	assert.ElementsMatch(t, []string{"events.foo.1.python", "events.foo.1.python b"}, []string{<-topics, <-topics})
+	assert.Empty(t, topics)
+}
+
+func TestMemoryQueueOverflowDropNewest(t *testing.T) {
+	queue := buildTestMemoryQueue(t, 1, OverflowDropNewest)
+
+	assert.Equal(t, []string{"foo"}, publishWhileStalled(t, queue, "foo", "bar"))
+}
+
+func TestMemoryQueueOverflowDropOldest(t *testing.T) {
+	queue := buildTestMemoryQueue(t, 1, OverflowDropOldest)
+
+	assert.Equal(t, []string{"bar"}, publishWhileStalled(t, queue, "foo", "bar"))
+}
+
+func TestMemoryQueueOverflowBlock(t *testing.T) {
+	queue := buildTestMemoryQueue(t, 1, OverflowBlock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go queue.Subscribe(ctx, "events", func(header map[string][]string, body string) error {
+		<-ctx.Done()
+		return nil
+	})
+
+	waitForSubscribers(t, queue, "events", 1)
+
+	publishCtx, publishCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer publishCancel()
+
+	var err error
+
+	for i := 0; i < 3 && err == nil; i++ {
+		err = queue.Publish(publishCtx, "events", "foo")
+	}
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestMemoryQueueClose(t *testing.T) {
+	queue := buildTestMemoryQueue(t, 1, OverflowBlock)
+
+	done := make(chan error)
+
+	go func() {
+		done <- queue.Subscribe(context.Background(), "events", func(header map[string][]string, body string) error {
+			return nil
+		})
+	}()
+
+	waitForSubscribers(t, queue, "events", 1)
+
+	queue.Close()
+
+	assert.Nil(t, <-done)
+	assert.Equal(t, ErrMemoryQueueClosed, queue.Publish(context.Background(), "events", "foo"))
+}
+
+func TestMemoryQueueInvalidSettings(t *testing.T) {
+	_, err := NewMemoryQueue(0, OverflowBlock)
+	assert.True(t, errors.Is(err, ErrMemoryQueueSettings))
+
+	_, err = NewMemoryQueue(1, "unknown")
+	assert.True(t, errors.Is(err, ErrMemoryQueueSettings))
+}
+
+// Publish messages while the subscriber is stalled and returns what it later receives
+func publishWhileStalled(t *testing.T, queue *MemoryQueue, messages ...string) []string {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	release := make(chan struct{})
+	done := make(chan struct{})
+	var received []string
+
+	go func() {
+		defer close(done)
+
+		queue.Subscribe(ctx, "events", func(header map[string][]string, body string) error {
+			if body == "stall" {
+				<-release
+				return nil
+			}
+
+			received = append(received, body)
+
+			if len(received) == 1 {
+				cancel()
+			}
+
+			return nil
+		})
+	}()
+
+	waitForSubscribers(t, queue, "events", 1)
+
+	require.Nil(t, queue.Publish(context.Background(), "events", "stall"))
+
+	// waits until the stall message leaves the buffer
+	require.Eventually(t, func() bool {
+		return len(queue.firstSubscriber("events").messages) == 0
+	}, time.Second, time.Millisecond)
+
+	for _, message := range messages {
+		require.Nil(t, queue.Publish(context.Background(), "events", message))
+	}
+
+	close(release)
+	<-done
+
+	return received
+}
+
+func buildTestMemoryQueue(t *testing.T, bufferSize int, overflow OverflowPolicy) *MemoryQueue {
+	queue, err := NewMemoryQueue(bufferSize, overflow)
+
+	require.Nil(t, err)
+
+	t.Cleanup(queue.(*MemoryQueue).Close)
+
+	return queue.(*MemoryQueue)
+}
+
+func waitForSubscribers(t *testing.T, queue *MemoryQueue, topic string, count int) {
+	require.Eventually(t, func() bool {
+		return queue.SubscriberCount(topic) == count
+	}, time.Second, time.Millisecond)
+}
+
+func (m *MemoryQueue) firstSubscriber(topic string) *memorySubscriber {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for s := range m.subscribers[topic] {
+		return s
+	}
+
+	return nil
+}
@@ -3,8 +3,13 @@ package storage
 import (
 	"context"
 	"errors"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	log "github.com/sirupsen/logrus"
 )
 
 // Represents a Nats connection error
@@ -13,29 +18,104 @@ var ErrNatsConnection = errors.New("an error occurred while attempting to establ
 // Represents a Nats subscribe channel error
 var ErrNatsSubscribeChannel = errors.New("an error occurred while attempting to subscribe to a Nats channel")
 
+// Represents a Nats JetStream stream or consumer setup error
+var ErrNatsJetStream = errors.New("an error occurred while attempting to set up a Nats JetStream stream")
+
 // The Nats instance
 type Nats struct {
-	conn *nats.Conn
+	conn     *nats.Conn
+	js       jetstream.JetStream
+	settings NatsJetStreamSettings
+}
+
+// Represents the JetStream stream and durable consumer settings
+type NatsJetStreamSettings struct {
+	// The stream name
+	Stream string
+	// The subjects captured by the stream
+	Subjects []string
+	// The durable consumer name prefix
+	Durable string
+	// How long the server waits for an ack before redelivering
+	AckWait time.Duration
+	// The maximum delivery attempts of a message
+	MaxDeliver int
 }
 
 // Build a new Nats connection
 func NewNatsConnection(url string) (Queue, error) {
-	nc, err := nats.Connect(nats.DefaultURL)
+	nc, err := connectNats(url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Nats{conn: nc}, nil
+}
+
+// Build a new Nats connection backed by a JetStream stream with durable consumers
+func NewNatsJetStreamConnection(url string, settings NatsJetStreamSettings) (Queue, error) {
+	nc, err := connectNats(url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	js, err := jetstream.New(nc)
+
+	if err != nil {
+		nc.Close()
+		return nil, errors.Join(ErrNatsJetStream, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err = js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     settings.Stream,
+		Subjects: settings.Subjects,
+		Storage:  jetstream.FileStorage,
+	})
+
+	if err != nil {
+		nc.Close()
+		return nil, errors.Join(ErrNatsJetStream, err)
+	}
+
+	return &Nats{conn: nc, js: js, settings: settings}, nil
+}
+
+func connectNats(url string) (*nats.Conn, error) {
+	if url == "" {
+		url = nats.DefaultURL
+	}
+
+	nc, err := nats.Connect(url)
 
 	if err != nil {
 		return nil, errors.Join(ErrNatsConnection, err)
 	}
 
-	return &Nats{nc}, nil
+	return nc, nil
 }
 
 // Publish a message
-func (n *Nats) Publish(_ context.Context, topic string, message string) error {
+func (n *Nats) Publish(ctx context.Context, topic string, message string) error {
+	if n.js != nil {
+		_, err := n.js.Publish(ctx, topic, []byte(message))
+		return err
+	}
+
 	return n.conn.Publish(topic, []byte(message))
 }
 
-// Subscribe to a Nats channel
-func (n *Nats) Subscribe(_ context.Context, channel string, handler SubscribeHandler) error {
+// Subscribe to a Nats channel until the context is done,
+// handling up to the context concurrency messages at the same time
+func (n *Nats) Subscribe(ctx context.Context, channel string, handler SubscribeHandler) error {
+	if n.js != nil {
+		return n.subscribeJetStream(ctx, channel, handler)
+	}
+
 	ch := make(chan *nats.Msg, 64)
 	sub, err := n.conn.ChanSubscribe(channel, ch)
 
@@ -43,15 +123,153 @@ func (n *Nats) Subscribe(_ context.Context, channel string, handler SubscribeHan
 		return errors.Join(ErrNatsSubscribeChannel, err)
 	}
 
-	msg := <-ch
+	defer sub.Unsubscribe()
+
+	pool := newHandlerPool(Concurrency(ctx))
 
-	handler(msg.Header, msg.Reply)
+	defer pool.Wait()
 
-	sub.Unsubscribe()
+	for pool.Acquire(ctx) {
+		select {
+		case <-ctx.Done():
+			pool.Release()
+			return nil
+		case msg := <-ch:
+			pool.Run(func() {
+				if err := handler(msg.Header, string(msg.Data)); err != nil {
+					log.Errorf("⛔ The Nats handler failed on subject %v: %v", msg.Subject, err)
+				}
+			})
+		}
+	}
 
 	return nil
 }
 
+// Consume a durable JetStream consumer, acking messages when the handler succeeds
+func (n *Nats) subscribeJetStream(ctx context.Context, channel string, handler SubscribeHandler) error {
+	consumer, err := n.js.CreateOrUpdateConsumer(ctx, n.settings.Stream, jetstream.ConsumerConfig{
+		Durable:       natsDurableName(n.settings.Durable, channel),
+		FilterSubject: channel,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		AckWait:       n.settings.AckWait,
+		MaxDeliver:    n.settings.MaxDeliver,
+	})
+
+	if err != nil {
+		return errors.Join(ErrNatsSubscribeChannel, err)
+	}
+
+	pool := newHandlerPool(Concurrency(ctx))
+
+	// a message that arrives once the pool is stopped is left unacked, so it is redelivered
+	cc, err := consumer.Consume(func(msg jetstream.Msg) {
+		pool.Go(func() {
+			if err := handler(msg.Headers(), string(msg.Data())); err != nil {
+				log.Errorf("⛔ The Nats handler failed on subject %v, the message will be redelivered: %v", msg.Subject(), err)
+				msg.Nak()
+				return
+			}
+
+			msg.Ack()
+		})
+	})
+
+	if err != nil {
+		return errors.Join(ErrNatsSubscribeChannel, err)
+	}
+
+	<-ctx.Done()
+
+	cc.Stop()
+	pool.Wait()
+
+	return nil
+}
+
+// Reads the messages of the JetStream subject through an ordered consumer, which neither acks nor redelivers them
+func (n *Nats) Peek(ctx context.Context, channel string, handler SubscribeHandler) error {
+	if n.js == nil {
+		return ErrPeekUnsupported
+	}
+
+	consumer, err := n.js.OrderedConsumer(ctx, n.settings.Stream, jetstream.OrderedConsumerConfig{
+		FilterSubjects: []string{channel},
+		DeliverPolicy:  jetstream.DeliverAllPolicy,
+	})
+
+	if err != nil {
+		return errors.Join(ErrNatsSubscribeChannel, err)
+	}
+
+	for ctx.Err() == nil {
+		batch, err := consumer.Fetch(100, jetstream.FetchMaxWait(time.Second))
+
+		if err != nil {
+			return errors.Join(ErrNatsSubscribeChannel, err)
+		}
+
+		read, pending := 0, uint64(0)
+
+		for msg := range batch.Messages() {
+			if err := handler(msg.Headers(), string(msg.Data())); err != nil {
+				return err
+			}
+
+			read++
+
+			if metadata, err := msg.Metadata(); err == nil {
+				pending = metadata.NumPending
+			}
+		}
+
+		if read == 0 || pending == 0 {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// Builds a durable consumer name per subject, since names cannot contain dots or wildcards
+func natsDurableName(prefix string, channel string) string {
+	replacer := strings.NewReplacer(".", "_", "*", "any", ">", "all")
+	return prefix + "_" + replacer.Replace(channel)
+}
+
+// Returns the stream subjects plus the topics none of them capture, so publishing to those topics does not fail
+func natsStreamSubjects(subjects []string, topics ...string) []string {
+	result := slices.Clone(subjects)
+
+	for _, topic := range topics {
+		if topic == "" || slices.ContainsFunc(result, func(subject string) bool { return natsSubjectMatch(subject, topic) }) {
+			continue
+		}
+
+		result = append(result, topic)
+	}
+
+	return result
+}
+
+// Returns true when the subject pattern matches the subject, * matches a token and > the remaining tokens
+func natsSubjectMatch(pattern string, subject string) bool {
+	patternTokens := strings.Split(pattern, ".")
+	subjectTokens := strings.Split(subject, ".")
+
+	for i, token := range patternTokens {
+		if token == ">" {
+			return len(subjectTokens) > i
+		}
+
+		if i >= len(subjectTokens) || (token != "*" && token != subjectTokens[i]) {
+			return false
+		}
+	}
+
+	return len(patternTokens) == len(subjectTokens)
+}
+
 // Close Nats connection
 func (n *Nats) Close() {
 	n.conn.Drain()
@@ -0,0 +1,250 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNatsDurableName(t *testing.T) {
+	assert.Equal(t, "bugs-channel_events", natsDurableName("bugs-channel", "events"))
+	assert.Equal(t, "bugs-channel_events_dlq", natsDurableName("bugs-channel", "events.dlq"))
+	assert.Equal(t, "worker_events_any_all", natsDurableName("worker", "events.*.>"))
+}
+
+func TestNatsStreamSubjects(t *testing.T) {
+	subjects := []string{"events", "events.>"}
+
+	assert.Equal(t, []string{"events", "events.>", "issue.regressed", "dlq.events"}, natsStreamSubjects(subjects, "issue.regressed", "events.foo", "dlq.events", ""))
+	assert.Equal(t, []string{"events", "events.>"}, subjects)
+	assert.Equal(t, []string{"issue.*"}, natsStreamSubjects([]string{"issue.*"}, "issue.regressed"))
+}
+
+func TestNatsSubjectMatch(t *testing.T) {
+	assert.True(t, natsSubjectMatch("events", "events"))
+	assert.True(t, natsSubjectMatch("events.>", "events.dlq"))
+	assert.True(t, natsSubjectMatch("events.>", "events.foo.bar"))
+	assert.True(t, natsSubjectMatch("events.*.bar", "events.foo.bar"))
+	assert.False(t, natsSubjectMatch("events.>", "events"))
+	assert.False(t, natsSubjectMatch("events.*", "events.foo.bar"))
+	assert.False(t, natsSubjectMatch("events", "issue.regressed"))
+}
+
+func TestNatsPublishSubscribe(t *testing.T) {
+	srv := runTestNatsServer(t)
+
+	queue, err := NewNatsConnection(srv.ClientURL())
+
+	require.Nil(t, err)
+
+	defer queue.(*Nats).Close()
+
+	messages := make(chan string, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go queue.Subscribe(ctx, "events", func(header map[string][]string, body string) error {
+		messages <- body
+		return nil
+	})
+
+	// core Nats keeps no messages, so publish until the subscription receives one
+	require.Eventually(t, func() bool {
+		require.Nil(t, queue.Publish(context.Background(), "events", "foo"))
+
+		select {
+		case body := <-messages:
+			return body == "foo"
+		case <-time.After(10 * time.Millisecond):
+			return false
+		}
+	}, time.Second, 20*time.Millisecond)
+
+	assert.ErrorIs(t, queue.(*Nats).Peek(context.Background(), "events", nil), ErrPeekUnsupported)
+}
+
+func TestNatsJetStreamAck(t *testing.T) {
+	queue := buildTestNatsJetStream(t, runTestNatsServer(t), 3)
+
+	require.Nil(t, queue.Publish(context.Background(), "events", "foo"))
+	require.Nil(t, queue.Publish(context.Background(), "events", "bar"))
+
+	messages := make(chan string, 10)
+
+	subscribe(t, queue, "events", func(header map[string][]string, body string) error {
+		messages <- body
+		return nil
+	})
+
+	assert.Equal(t, "foo", <-messages)
+	assert.Equal(t, "bar", <-messages)
+
+	// the acked messages are not delivered to the durable consumer again
+	require.Eventually(t, func() bool {
+		return numAckPending(t, queue, "events") == 0
+	}, time.Second, 10*time.Millisecond)
+
+	subscribe(t, queue, "events", func(header map[string][]string, body string) error {
+		messages <- body
+		return nil
+	})
+
+	require.Nil(t, queue.Publish(context.Background(), "events", "baz"))
+
+	assert.Equal(t, "baz", <-messages)
+}
+
+func TestNatsJetStreamRedelivery(t *testing.T) {
+	queue := buildTestNatsJetStream(t, runTestNatsServer(t), 3)
+
+	require.Nil(t, queue.Publish(context.Background(), "events", "foo"))
+
+	attempts := make(chan string, 10)
+	failures := 1
+
+	// the nak redelivers the message without waiting the ack wait
+	subscribe(t, queue, "events", func(header map[string][]string, body string) error {
+		attempts <- body
+
+		if failures > 0 {
+			failures--
+			return errors.New("sink is down")
+		}
+
+		return nil
+	})
+
+	assert.Equal(t, "foo", <-attempts)
+	assert.Equal(t, "foo", <-attempts)
+
+	require.Eventually(t, func() bool {
+		return numAckPending(t, queue, "events") == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestNatsJetStreamMaxDeliver(t *testing.T) {
+	queue := buildTestNatsJetStream(t, runTestNatsServer(t), 2)
+
+	require.Nil(t, queue.Publish(context.Background(), "events", "foo"))
+
+	attempts := make(chan string, 10)
+
+	subscribe(t, queue, "events", func(header map[string][]string, body string) error {
+		attempts <- body
+		return errors.New("sink is down")
+	})
+
+	assert.Equal(t, "foo", <-attempts)
+	assert.Equal(t, "foo", <-attempts)
+
+	// the message is given up after the maximum deliveries
+	assert.Never(t, func() bool { return len(attempts) > 0 }, 200*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestNatsJetStreamPeek(t *testing.T) {
+	queue := buildTestNatsJetStream(t, runTestNatsServer(t), 3)
+
+	for _, body := range []string{"foo", "bar", "baz"} {
+		require.Nil(t, queue.Publish(context.Background(), "dlq.events", body))
+	}
+
+	require.Nil(t, queue.Publish(context.Background(), "events", "qux"))
+
+	var peeked []string
+
+	peek := func(header map[string][]string, body string) error {
+		peeked = append(peeked, body)
+		return nil
+	}
+
+	require.Nil(t, queue.Peek(context.Background(), "dlq.events", peek))
+	assert.Equal(t, []string{"foo", "bar", "baz"}, peeked)
+
+	// peeking again reads the same messages, since none was consumed
+	peeked = nil
+
+	require.Nil(t, queue.Peek(context.Background(), "dlq.events", peek))
+	assert.Equal(t, []string{"foo", "bar", "baz"}, peeked)
+
+	messages := make(chan string, 10)
+
+	subscribe(t, queue, "dlq.events", func(header map[string][]string, body string) error {
+		messages <- body
+		return nil
+	})
+
+	assert.Equal(t, "foo", <-messages)
+}
+
+func runTestNatsServer(t *testing.T) *server.Server {
+	srv, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      -1,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+
+	require.Nil(t, err)
+
+	go srv.Start()
+
+	require.True(t, srv.ReadyForConnections(5*time.Second))
+
+	t.Cleanup(srv.Shutdown)
+
+	return srv
+}
+
+func buildTestNatsJetStream(t *testing.T, srv *server.Server, maxDeliver int) *Nats {
+	queue, err := NewNatsJetStreamConnection(srv.ClientURL(), NatsJetStreamSettings{
+		Stream:     "events",
+		Subjects:   []string{"events", "events.>", "dlq.events"},
+		Durable:    "worker",
+		AckWait:    time.Minute,
+		MaxDeliver: maxDeliver,
+	})
+
+	require.Nil(t, err)
+
+	t.Cleanup(queue.(*Nats).Close)
+
+	return queue.(*Nats)
+}
+
+// Subscribes in background until the test ends
+func subscribe(t *testing.T, queue *Nats, channel string, handler SubscribeHandler) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		queue.Subscribe(ctx, channel, handler)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+func numAckPending(t *testing.T, queue *Nats, channel string) int {
+	consumer, err := queue.js.Consumer(context.Background(), queue.settings.Stream, natsDurableName(queue.settings.Durable, channel))
+
+	if err != nil {
+		return -1
+	}
+
+	info, err := consumer.Info(context.Background())
+
+	require.Nil(t, err)
+
+	return info.NumAckPending + int(info.NumPending)
+}
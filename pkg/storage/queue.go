@@ -1,7 +1,14 @@
 // This package contains Stprage implementations such as Nats
 package storage
 
-import "context"
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Represents an error when the queue cannot read messages without consuming them
+var ErrPeekUnsupported = errors.New("the queue cannot read messages without consuming them")
 
 // The queue interface
 type Queue interface {
@@ -12,5 +19,105 @@ type Queue interface {
 	Subscribe(ctx context.Context, topic string, handler SubscribeHandler) error
 }
 
+// The interface of queues able to read the retained messages of a topic without consuming them
+type Peeker interface {
+	// Reads the messages retained so far, leaving them queued and returning once they are read
+	Peek(ctx context.Context, topic string, handler SubscribeHandler) error
+}
+
 // The subscribe handler signature
 type SubscribeHandler func(header map[string][]string, body string) error
+
+type messageKeyContextKey struct{}
+
+// Returns a context carrying the message key, used by queues that partition messages
+func WithMessageKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, messageKeyContextKey{}, key)
+}
+
+// Returns the message key carried by the context
+func MessageKey(ctx context.Context) string {
+	key, _ := ctx.Value(messageKeyContextKey{}).(string)
+	return key
+}
+
+type concurrencyContextKey struct{}
+
+// Returns a context carrying how many messages a subscription handles at the same time
+func WithConcurrency(ctx context.Context, concurrency int) context.Context {
+	return context.WithValue(ctx, concurrencyContextKey{}, concurrency)
+}
+
+// Returns how many messages a subscription handles at the same time, one unless the context says otherwise
+func Concurrency(ctx context.Context) int {
+	concurrency, _ := ctx.Value(concurrencyContextKey{}).(int)
+	return max(concurrency, 1)
+}
+
+// Runs the handlers of a subscription, at most the pool size at the same time
+type handlerPool struct {
+	mu      sync.Mutex
+	slots   chan struct{}
+	wg      sync.WaitGroup
+	stopped bool
+}
+
+func newHandlerPool(size int) *handlerPool {
+	return &handlerPool{slots: make(chan struct{}, max(size, 1))}
+}
+
+// Waits for a free slot, returning false when the context is done or the pool was stopped
+func (p *handlerPool) Acquire(ctx context.Context) bool {
+	p.mu.Lock()
+
+	if p.stopped {
+		p.mu.Unlock()
+		return false
+	}
+
+	p.wg.Add(1)
+	p.mu.Unlock()
+
+	select {
+	case p.slots <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		p.wg.Done()
+		return false
+	}
+}
+
+// Frees a slot acquired without running a function on it
+func (p *handlerPool) Release() {
+	<-p.slots
+	p.wg.Done()
+}
+
+// Runs the function on an acquired slot, freeing it once the function returns
+func (p *handlerPool) Run(fn func()) {
+	go func() {
+		defer p.Release()
+
+		fn()
+	}()
+}
+
+// Runs the function once a slot is free, returning false when the pool was stopped
+func (p *handlerPool) Go(fn func()) bool {
+	if !p.Acquire(context.Background()) {
+		return false
+	}
+
+	p.Run(fn)
+
+	return true
+}
+
+// Stops accepting functions and waits for the running ones
+func (p *handlerPool) Wait() {
+	p.mu.Lock()
+	p.stopped = true
+	p.mu.Unlock()
+
+	p.wg.Wait()
+}
@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageKey(t *testing.T) {
+	assert.Equal(t, "", MessageKey(context.Background()))
+
+	ctx := WithMessageKey(context.Background(), "foo")
+
+	assert.Equal(t, "foo", MessageKey(ctx))
+}
+
+func TestConcurrency(t *testing.T) {
+	assert.Equal(t, 1, Concurrency(context.Background()))
+	assert.Equal(t, 1, Concurrency(WithConcurrency(context.Background(), 0)))
+	assert.Equal(t, 4, Concurrency(WithConcurrency(context.Background(), 4)))
+}
+
+func TestHandlerPool(t *testing.T) {
+	pool := newHandlerPool(2)
+
+	var running, peak, done atomic.Int32
+
+	for range 6 {
+		assert.True(t, pool.Go(func() {
+			current := running.Add(1)
+
+			for {
+				previous := peak.Load()
+
+				if current <= previous || peak.CompareAndSwap(previous, current) {
+					break
+				}
+			}
+
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+			done.Add(1)
+		}))
+	}
+
+	pool.Wait()
+
+	assert.Equal(t, int32(6), done.Load())
+	assert.Equal(t, int32(2), peak.Load())
+	assert.False(t, pool.Go(func() {}))
+}
@@ -0,0 +1,269 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	log "github.com/sirupsen/logrus"
+)
+
+// Represents a RabbitMQ connection error
+var ErrRabbitMQConnection = errors.New("an error occurred while attempting to establish a RabbitMQ connection")
+
+// Represents a RabbitMQ subscribe error
+var ErrRabbitMQSubscribe = errors.New("an error occurred while attempting to subscribe to a RabbitMQ queue")
+
+// Represents a RabbitMQ publish that was not confirmed by the broker
+var ErrRabbitMQNotConfirmed = errors.New("the RabbitMQ broker did not confirm the published message")
+
+// Represents a RabbitMQ publish that no queue is bound to, so the broker returned it
+var ErrRabbitMQUnroutable = errors.New("the RabbitMQ broker returned the published message, no queue is bound to its topic")
+
+// Represents the RabbitMQ exchange and consumer settings
+type RabbitMQSettings struct {
+	// The topic exchange name
+	Exchange string
+	// The queue name prefix, each topic gets its own durable queue
+	Queue string
+	// How many unacked messages a consumer may hold
+	Prefetch int
+	// The topics whose queues are declared on connect, so they keep the messages published before a consumer subscribes
+	Topics []string
+	// The topic deliveries go to once they failed again after being requeued, empty drops them
+	DeadLetterTopic string
+}
+
+// The RabbitMQ instance
+type RabbitMQ struct {
+	conn     *amqp.Connection
+	ch       *amqp.Channel
+	returns  chan amqp.Return
+	mu       sync.Mutex
+	settings RabbitMQSettings
+}
+
+// Build a new RabbitMQ connection with publisher confirms, declaring the queues of the settings topics
+func NewRabbitMQConnection(url string, settings RabbitMQSettings) (Queue, error) {
+	conn, err := amqp.Dial(url)
+
+	if err != nil {
+		return nil, errors.Join(ErrRabbitMQConnection, err)
+	}
+
+	r := &RabbitMQ{conn: conn, settings: settings}
+
+	r.ch, err = conn.Channel()
+
+	if err == nil {
+		err = r.ch.ExchangeDeclare(settings.Exchange, amqp.ExchangeTopic, true, false, false, false, nil)
+	}
+
+	for _, topic := range rabbitMQTopics(settings) {
+		if err == nil {
+			_, err = r.declareQueue(r.ch, topic)
+		}
+	}
+
+	if err == nil {
+		err = r.ch.Confirm(false)
+	}
+
+	if err != nil {
+		conn.Close()
+		return nil, errors.Join(ErrRabbitMQConnection, err)
+	}
+
+	r.returns = r.ch.NotifyReturn(make(chan amqp.Return, 1))
+
+	return r, nil
+}
+
+// Returns the settings topics and the dead letter topic, without duplicates
+func rabbitMQTopics(settings RabbitMQSettings) []string {
+	var topics []string
+
+	for _, topic := range append(slices.Clone(settings.Topics), settings.DeadLetterTopic) {
+		if topic != "" && !slices.Contains(topics, topic) {
+			topics = append(topics, topic)
+		}
+	}
+
+	return topics
+}
+
+// Publish a persistent message and wait for the broker confirmation.
+// The message is mandatory, so a topic without a bound queue fails instead of dropping it.
+func (r *RabbitMQ) Publish(ctx context.Context, topic string, message string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// a return left behind by a publish whose confirmation was not awaited does not belong to this one
+	select {
+	case <-r.returns:
+	default:
+	}
+
+	confirmation, err := r.ch.PublishWithDeferredConfirmWithContext(ctx, r.settings.Exchange, topic, true, false, amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		Body:         []byte(message),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if !acked {
+		return ErrRabbitMQNotConfirmed
+	}
+
+	// the broker sends the return before the confirmation of the same message
+	select {
+	case returned := <-r.returns:
+		return fmt.Errorf("%w: %v", ErrRabbitMQUnroutable, returned.RoutingKey)
+	default:
+	}
+
+	return nil
+}
+
+// Subscribe to a topic through a durable queue until the context is done,
+// handling up to the context concurrency deliveries at the same time
+func (r *RabbitMQ) Subscribe(ctx context.Context, topic string, handler SubscribeHandler) error {
+	ch, err := r.conn.Channel()
+
+	if err != nil {
+		return errors.Join(ErrRabbitMQSubscribe, err)
+	}
+
+	defer ch.Close()
+
+	concurrency := Concurrency(ctx)
+
+	deliveries, err := r.consume(ch, topic, max(r.settings.Prefetch, concurrency))
+
+	if err != nil {
+		return errors.Join(ErrRabbitMQSubscribe, err)
+	}
+
+	pool := newHandlerPool(concurrency)
+
+	defer pool.Wait()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case d, ok := <-deliveries:
+			if !ok {
+				return fmt.Errorf("%w: the delivery channel of %v was closed", ErrRabbitMQSubscribe, topic)
+			}
+
+			pool.Go(func() {
+				handleRabbitMQDelivery(d, handler, r.deadLetter)
+			})
+		}
+	}
+}
+
+// Consumes the topic queue, prefetching enough deliveries to keep every handler busy
+func (r *RabbitMQ) consume(ch *amqp.Channel, topic string, prefetch int) (<-chan amqp.Delivery, error) {
+	if err := ch.Qos(prefetch, 0, false); err != nil {
+		return nil, err
+	}
+
+	queue, err := r.declareQueue(ch, topic)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ch.Consume(queue.Name, "", false, false, false, false, nil)
+}
+
+// Declares the durable queue of the topic and binds it to the exchange
+func (r *RabbitMQ) declareQueue(ch *amqp.Channel, topic string) (amqp.Queue, error) {
+	queue, err := ch.QueueDeclare(fmt.Sprintf("%v.%v", r.settings.Queue, topic), true, false, false, false, nil)
+
+	if err != nil {
+		return queue, err
+	}
+
+	return queue, ch.QueueBind(queue.Name, rabbitMQBindingKey(topic), r.settings.Exchange, false, nil)
+}
+
+// Publishes the dead letter envelope of a delivery to the dead letter topic
+func (r *RabbitMQ) deadLetter(d amqp.Delivery, reason error) error {
+	if r.settings.DeadLetterTopic == "" {
+		return nil
+	}
+
+	envelope, err := buildDeadLetter(d.RoutingKey, "", string(d.Body), 2, reason)
+
+	if err != nil {
+		return err
+	}
+
+	return r.Publish(context.Background(), r.settings.DeadLetterTopic, envelope)
+}
+
+// Maps a topic to a binding key of the topic exchange, "*" matches a single word on both
+// and the NATS ">" becomes "#", which matches the remaining words
+func rabbitMQBindingKey(topic string) string {
+	return strings.ReplaceAll(topic, ">", "#")
+}
+
+// Acks handled deliveries, failed ones are requeued once and then dead-lettered,
+// a delivery that cannot be dead-lettered is requeued again so it is not lost
+func handleRabbitMQDelivery(d amqp.Delivery, handler SubscribeHandler, deadLetter func(amqp.Delivery, error) error) {
+	err := handler(buildRabbitMQHeaders(d.RoutingKey, d.Headers), string(d.Body))
+
+	if err == nil {
+		d.Ack(false)
+		return
+	}
+
+	log.Errorf("⛔ The RabbitMQ handler failed on routing key %v (redelivered: %v): %v", d.RoutingKey, d.Redelivered, err)
+
+	if !d.Redelivered {
+		d.Nack(false, true)
+		return
+	}
+
+	if dlqErr := deadLetter(d, err); dlqErr != nil {
+		log.Errorf("⛔ An error occurred while dead-lettering the RabbitMQ delivery of %v, requeuing it: %v", d.RoutingKey, dlqErr)
+		d.Nack(false, true)
+		return
+	}
+
+	d.Ack(false)
+}
+
+func buildRabbitMQHeaders(routingKey string, table amqp.Table) map[string][]string {
+	headers := map[string][]string{
+		"routing_key": {routingKey},
+	}
+
+	for key, value := range table {
+		headers[key] = []string{fmt.Sprintf("%v", value)}
+	}
+
+	return headers
+}
+
+// Close RabbitMQ connection
+func (r *RabbitMQ) Close() {
+	r.ch.Close()
+	r.conn.Close()
+}
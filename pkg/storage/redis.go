@@ -3,9 +3,9 @@ package storage
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"github.com/redis/go-redis/v9"
-	"github.com/williampsena/bugs-channel/pkg/config"
 )
 
 // Represents a Redis connection error
@@ -21,7 +21,7 @@ type Redis struct {
 
 // Build a new Redis connection
 func NewRedisConnection(url string) (Queue, error) {
-	opts, err := buildRedisOptions(config.RedisConnectionUrl())
+	opts, err := buildRedisOptions(url)
 
 	if err != nil {
 		return nil, err
@@ -45,22 +45,61 @@ func buildRedisOptions(url string) (*redis.Options, error) {
 
 // Publish a message
 func (r *Redis) Publish(ctx context.Context, topic string, message string) error {
-	return r.conn.Publish(ctx, topic, message).Err()
+	return r.conn.Publish(ctx, redisChannel(topic), message).Err()
 }
 
-// Subscribe to a Redis topic
-func (r *Redis) Subscribe(ctx context.Context, channel string, handler SubscribeHandler) error {
-	pubsub := r.conn.Subscribe(ctx, channel)
+// Subscribe to a Redis topic until the context is done, wildcard topics use a pattern subscription matched like NATS subjects.
+// Up to the context concurrency messages are handled at the same time.
+func (r *Redis) Subscribe(ctx context.Context, topic string, handler SubscribeHandler) error {
+	channel := redisChannel(topic)
+
+	var pubsub *redis.PubSub
+
+	if strings.Contains(channel, "*") {
+		pubsub = r.conn.PSubscribe(ctx, channel)
+	} else {
+		pubsub = r.conn.Subscribe(ctx, channel)
+	}
 
 	defer pubsub.Close()
 
 	ch := pubsub.Channel()
 
-	for msg := range ch {
-		handler(buildRedisHeaders(msg.Channel, msg.Pattern), msg.Payload)
+	pool := newHandlerPool(Concurrency(ctx))
+
+	defer pool.Wait()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			// the Redis glob matches across segments, so only the channels the topic matches are handled
+			if msg.Pattern != "" && !natsSubjectMatch(topic, redisTopic(msg.Channel)) {
+				continue
+			}
+
+			pool.Go(func() {
+				handler(buildRedisHeaders(msg.Channel, msg.Pattern), msg.Payload)
+			})
+		}
 	}
+}
+
+// Maps a topic to a Redis channel, "events.foo.>" becomes "events:foo:*" and "events.*.python" becomes "events:*:python"
+func redisChannel(topic string) string {
+	replacer := strings.NewReplacer(".", ":", ">", "*")
+
+	return replacer.Replace(topic)
+}
 
-	return nil
+// Maps a Redis channel back to its topic
+func redisTopic(channel string) string {
+	return strings.ReplaceAll(channel, ":", ".")
 }
 
 func buildRedisHeaders(channel string, pattern string) map[string][]string {
@@ -72,5 +111,5 @@ func buildRedisHeaders(channel string, pattern string) map[string][]string {
 
 // Close Redis connection
 func (r *Redis) Close() {
-	r.Close()
+	r.conn.Close()
 }
@@ -0,0 +1,213 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	log "github.com/sirupsen/logrus"
+)
+
+// Represents a Redis stream consumer group error
+var ErrRedisStreamGroup = errors.New("an error occurred while attempting to create a Redis stream consumer group")
+
+// The stream entry field that carries the message body
+const redisStreamBodyField = "body"
+
+// Represents the Redis streams settings
+type RedisStreamsSettings struct {
+	// The approximate maximum length of each stream
+	MaxLen int64
+	// The consumer group name
+	Group string
+	// The consumer name inside the group
+	Consumer string
+	// How long an entry may stay pending before another consumer claims it
+	ClaimIdle time.Duration
+	// How long a read blocks waiting for new entries
+	Block time.Duration
+}
+
+// The Redis streams instance
+type RedisStreams struct {
+	conn     *redis.Client
+	settings RedisStreamsSettings
+}
+
+// Build a new Redis streams connection
+func NewRedisStreamsConnection(url string, settings RedisStreamsSettings) (Queue, error) {
+	opts, err := buildRedisOptions(url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &RedisStreams{redis.NewClient(opts), settings}, nil
+}
+
+// Publish a message to a capped stream
+func (r *RedisStreams) Publish(ctx context.Context, topic string, message string) error {
+	return r.conn.XAdd(ctx, &redis.XAddArgs{
+		Stream: topic,
+		MaxLen: r.settings.MaxLen,
+		Approx: true,
+		Values: map[string]interface{}{redisStreamBodyField: message},
+	}).Err()
+}
+
+// Subscribe to a stream through the consumer group until the context is done,
+// handling up to the context concurrency entries at the same time, each one acked once handled
+func (r *RedisStreams) Subscribe(ctx context.Context, topic string, handler SubscribeHandler) error {
+	if err := r.createGroup(ctx, topic); err != nil {
+		return err
+	}
+
+	pool := newHandlerPool(Concurrency(ctx))
+
+	defer pool.Wait()
+
+	var lastClaim time.Time
+
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if time.Since(lastClaim) >= r.settings.ClaimIdle {
+			r.claimPending(ctx, topic, pool, handler)
+			lastClaim = time.Now()
+		}
+
+		streams, err := r.conn.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    r.settings.Group,
+			Consumer: r.settings.Consumer,
+			Streams:  []string{topic, ">"},
+			Count:    10,
+			Block:    r.settings.Block,
+		}).Result()
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			if !errors.Is(err, redis.Nil) {
+				log.Errorf("⛔ An error occurred while reading the Redis stream %v: %v", topic, err)
+				time.Sleep(time.Second)
+			}
+
+			continue
+		}
+
+		for _, stream := range streams {
+			r.handleMessages(ctx, topic, stream.Messages, pool, handler)
+		}
+	}
+}
+
+func (r *RedisStreams) createGroup(ctx context.Context, topic string) error {
+	err := r.conn.XGroupCreateMkStream(ctx, topic, r.settings.Group, "0").Err()
+
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return errors.Join(ErrRedisStreamGroup, err)
+	}
+
+	return nil
+}
+
+// Claims entries left pending by consumers that stopped before acking them
+func (r *RedisStreams) claimPending(ctx context.Context, topic string, pool *handlerPool, handler SubscribeHandler) {
+	start := "0-0"
+
+	for {
+		messages, next, err := r.conn.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   topic,
+			Group:    r.settings.Group,
+			Consumer: r.settings.Consumer,
+			MinIdle:  r.settings.ClaimIdle,
+			Start:    start,
+			Count:    100,
+		}).Result()
+
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Errorf("⛔ An error occurred while claiming pending entries of the Redis stream %v: %v", topic, err)
+			}
+
+			return
+		}
+
+		r.handleMessages(ctx, topic, messages, pool, handler)
+
+		if next == "0-0" || len(messages) == 0 {
+			return
+		}
+
+		start = next
+	}
+}
+
+func (r *RedisStreams) handleMessages(ctx context.Context, topic string, messages []redis.XMessage, pool *handlerPool, handler SubscribeHandler) {
+	for _, msg := range messages {
+		pool.Go(func() {
+			r.handleMessage(ctx, topic, msg, handler)
+		})
+	}
+}
+
+func (r *RedisStreams) handleMessage(ctx context.Context, topic string, msg redis.XMessage, handler SubscribeHandler) {
+	body, _ := msg.Values[redisStreamBodyField].(string)
+
+	if err := handler(buildRedisStreamHeaders(topic, msg.ID), body); err != nil {
+		log.Errorf("⛔ The Redis stream handler failed on entry %v, it will be claimed again: %v", msg.ID, err)
+		return
+	}
+
+	// acks even when the subscription is being cancelled, the entry was already handled
+	if err := r.conn.XAck(context.WithoutCancel(ctx), topic, r.settings.Group, msg.ID).Err(); err != nil {
+		log.Errorf("⛔ An error occurred while acking the Redis stream entry %v: %v", msg.ID, err)
+	}
+}
+
+// Reads the stream entries with XRANGE, outside the consumer group
+func (r *RedisStreams) Peek(ctx context.Context, topic string, handler SubscribeHandler) error {
+	start := "-"
+
+	for ctx.Err() == nil {
+		messages, err := r.conn.XRangeN(ctx, topic, start, "+", 100).Result()
+
+		if err != nil {
+			return err
+		}
+
+		for _, msg := range messages {
+			body, _ := msg.Values[redisStreamBodyField].(string)
+
+			if err := handler(buildRedisStreamHeaders(topic, msg.ID), body); err != nil {
+				return err
+			}
+
+			start = "(" + msg.ID
+		}
+
+		if len(messages) < 100 {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func buildRedisStreamHeaders(stream string, id string) map[string][]string {
+	return map[string][]string{
+		"stream": {stream},
+		"id":     {id},
+	}
+}
+
+// Close Redis streams connection
+func (r *RedisStreams) Close() {
+	r.conn.Close()
+}
@@ -0,0 +1,155 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisStreamsPublishSubscribe(t *testing.T) {
+	srv := miniredis.RunT(t)
+	queue := buildTestRedisStreams(t, srv, "consumer-1")
+
+	require.Nil(t, queue.Publish(context.Background(), "events", "foo"))
+	require.Nil(t, queue.Publish(context.Background(), "events", "bar"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var received []string
+
+	err := queue.Subscribe(ctx, "events", func(header map[string][]string, body string) error {
+		assert.Equal(t, []string{"events"}, header["stream"])
+
+		received = append(received, body)
+
+		if len(received) == 2 {
+			cancel()
+		}
+
+		return nil
+	})
+
+	require.Nil(t, err)
+	assert.Equal(t, []string{"foo", "bar"}, received)
+
+	pending, err := queue.conn.XPending(context.Background(), "events", "bugs-channel").Result()
+
+	require.Nil(t, err)
+	assert.Equal(t, int64(0), pending.Count)
+}
+
+func TestRedisStreamsClaimFailedEntries(t *testing.T) {
+	srv := miniredis.RunT(t)
+	failing := buildTestRedisStreams(t, srv, "consumer-1")
+	healthy := buildTestRedisStreams(t, srv, "consumer-2")
+
+	require.Nil(t, failing.Publish(context.Background(), "events", "foo"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	failing.Subscribe(ctx, "events", func(header map[string][]string, body string) error {
+		cancel()
+		return errors.New("handler failed")
+	})
+
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var mu sync.Mutex
+	var received []string
+
+	err := healthy.Subscribe(ctx, "events", func(header map[string][]string, body string) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		received = append(received, body)
+		cancel()
+
+		return nil
+	})
+
+	require.Nil(t, err)
+	assert.Equal(t, []string{"foo"}, received)
+}
+
+func TestRedisStreamsPeek(t *testing.T) {
+	srv := miniredis.RunT(t)
+	queue := buildTestRedisStreams(t, srv, "consumer-1")
+
+	require.Nil(t, queue.Publish(context.Background(), "events", "foo"))
+	require.Nil(t, queue.Publish(context.Background(), "events", "bar"))
+
+	for i := 0; i < 2; i++ {
+		var peeked []string
+
+		err := queue.Peek(context.Background(), "events", func(header map[string][]string, body string) error {
+			peeked = append(peeked, body)
+			return nil
+		})
+
+		require.Nil(t, err)
+		assert.Equal(t, []string{"foo", "bar"}, peeked)
+	}
+
+	// the entries were not delivered to the consumer group
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var received []string
+
+	err := queue.Subscribe(ctx, "events", func(header map[string][]string, body string) error {
+		received = append(received, body)
+
+		if len(received) == 2 {
+			cancel()
+		}
+
+		return nil
+	})
+
+	require.Nil(t, err)
+	assert.Equal(t, []string{"foo", "bar"}, received)
+
+	err = queue.Peek(context.Background(), "events", func(header map[string][]string, body string) error {
+		return errors.New("handler failed")
+	})
+
+	assert.EqualError(t, err, "handler failed")
+}
+
+func TestRedisStreamsMaxLen(t *testing.T) {
+	srv := miniredis.RunT(t)
+	queue := buildTestRedisStreams(t, srv, "consumer-1")
+
+	for i := 0; i < 10; i++ {
+		require.Nil(t, queue.Publish(context.Background(), "events", "foo"))
+	}
+
+	length, err := queue.conn.XLen(context.Background(), "events").Result()
+
+	require.Nil(t, err)
+	assert.LessOrEqual(t, length, int64(5))
+}
+
+func buildTestRedisStreams(t *testing.T, srv *miniredis.Miniredis, consumer string) *RedisStreams {
+	queue, err := NewRedisStreamsConnection("redis://"+srv.Addr(), RedisStreamsSettings{
+		MaxLen:    5,
+		Group:     "bugs-channel",
+		Consumer:  consumer,
+		ClaimIdle: time.Millisecond,
+		Block:     10 * time.Millisecond,
+	})
+
+	require.Nil(t, err)
+
+	t.Cleanup(queue.(*RedisStreams).Close)
+
+	return queue.(*RedisStreams)
+}
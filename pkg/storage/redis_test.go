@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRedisConnection(t *testing.T) {
+	srv := miniredis.RunT(t)
+
+	queue, err := NewRedisConnection("redis://" + srv.Addr())
+
+	require.Nil(t, err)
+	assert.Equal(t, srv.Addr(), queue.(*Redis).conn.Options().Addr)
+
+	queue.(*Redis).Close()
+
+	_, err = NewRedisConnection("not a url")
+
+	assert.ErrorIs(t, err, ErrRedisConnection)
+}
+
+func TestRedisChannel(t *testing.T) {
+	assert.Equal(t, "events", redisChannel("events"))
+	assert.Equal(t, "events:foo:1:python", redisChannel("events.foo.1.python"))
+	assert.Equal(t, "events:foo:*", redisChannel("events.foo.>"))
+	assert.Equal(t, "events:*:python", redisChannel("events.*.python"))
+	assert.Equal(t, "events.foo.python", redisTopic("events:foo:python"))
+}
+
+func TestRedisSubscribeWildcard(t *testing.T) {
+	srv := miniredis.RunT(t)
+	queue := &Redis{redis.NewClient(&redis.Options{Addr: srv.Addr()})}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	channels := make(chan string, 10)
+
+	go queue.Subscribe(ctx, "events.*.python", func(header map[string][]string, body string) error {
+		channels <- header["channel"][0]
+		return nil
+	})
+
+	require.Eventually(t, func() bool {
+		return srv.PubSubNumPat() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// * matches a single segment, as it does on NATS
+	require.Nil(t, queue.Publish(context.Background(), "events.foo.bar.python", "a"))
+	require.Nil(t, queue.Publish(context.Background(), "events.foo.go", "b"))
+	require.Nil(t, queue.Publish(context.Background(), "events.foo.python", "c"))
+
+	assert.Equal(t, "events:foo:python", <-channels)
+	assert.Empty(t, channels)
+}
@@ -0,0 +1,84 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Represents an error when the issue does not exist
+var ErrIssueNotFound = errors.New("the issue was not found")
+
+// Represents an error when the issue kept changing while it was being updated
+var ErrIssueConflict = errors.New("the issue was updated concurrently")
+
+// Represents the issue status
+type IssueStatus string
+
+const (
+	// The issue needs attention
+	IssueUnresolved IssueStatus = "unresolved"
+	// The issue was fixed, a new occurrence is a regression
+	IssueResolved IssueStatus = "resolved"
+	// The issue occurrences are ignored for a while or forever
+	IssueIgnored IssueStatus = "ignored"
+)
+
+// How many times an issue update is retried when it changes concurrently
+const issueUpdateAttempts = 5
+
+// Represents the events of a service grouped by fingerprint
+type Issue struct {
+	ServiceId   string      `json:"service_id"`
+	Fingerprint string      `json:"fingerprint"`
+	Title       string      `json:"title"`
+	Level       string      `json:"level"`
+	Status      IssueStatus `json:"status"`
+	FirstSeen   time.Time   `json:"first_seen"`
+	LastSeen    time.Time   `json:"last_seen"`
+	Count       int64       `json:"count"`
+	// The release of the last occurrence
+	LastRelease string `json:"last_release,omitempty"`
+	// When resolved in the next release, the release still expected to carry the issue
+	ResolvedInRelease string     `json:"resolved_in_release,omitempty"`
+	ResolvedAt        *time.Time `json:"resolved_at,omitempty"`
+	// The ignored issue becomes unresolved after this time, nil ignores forever
+	IgnoreUntil *time.Time `json:"ignore_until,omitempty"`
+	// The ignored issue becomes unresolved once the count goes beyond it, zero ignores forever
+	IgnoreUntilCount int64      `json:"ignore_until_count,omitempty"`
+	RegressedAt      *time.Time `json:"regressed_at,omitempty"`
+}
+
+// Represents the issue query filters, empty filters match everything
+type IssueQuery struct {
+	ServiceId string
+	Status    IssueStatus
+	Limit     int
+}
+
+// The issue repository interface
+type IssueRepository interface {
+	// Updates the issue atomically, fn receives a zero issue when it does not exist yet
+	// and nothing is written when it returns an error
+	UpdateIssue(ctx context.Context, serviceId string, fingerprint string, fn func(issue *Issue) error) (Issue, error)
+
+	// Returns the issue of the service fingerprint
+	GetIssue(ctx context.Context, serviceId string, fingerprint string) (Issue, error)
+
+	// Returns the issues matching the query, the most recently seen first
+	ListIssues(ctx context.Context, query IssueQuery) ([]Issue, error)
+}
+
+// Returns the query limit, falling back to the default page size
+func (q IssueQuery) limit() int {
+	return EventQuery{Limit: q.Limit}.limit()
+}
+
+// Returns the time in UTC or nil, so the database stores NULL
+func nullableTime(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+
+	return t.UTC()
+}
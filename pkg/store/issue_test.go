@@ -0,0 +1,116 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueQueryLimit(t *testing.T) {
+	assert.Equal(t, DefaultQueryLimit, IssueQuery{}.limit())
+	assert.Equal(t, 10, IssueQuery{Limit: 10}.limit())
+}
+
+// Checks the issue repository contract, shared by every store
+func testIssueRepository(t *testing.T, repo IssueRepository, serviceId string) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	_, err := repo.GetIssue(ctx, serviceId, "abc")
+
+	require.ErrorIs(t, err, ErrIssueNotFound)
+
+	issue, err := repo.UpdateIssue(ctx, serviceId, "abc", func(issue *Issue) error {
+		assert.Equal(t, Issue{ServiceId: serviceId, Fingerprint: "abc"}, *issue)
+
+		issue.Title = "ValueError"
+		issue.Status = IssueUnresolved
+		issue.FirstSeen = now
+		issue.LastSeen = now
+		issue.Count = 1
+
+		return nil
+	})
+
+	require.Nil(t, err)
+	assert.Equal(t, int64(1), issue.Count)
+
+	// a failing update writes nothing
+	errAbort := errors.New("abort")
+
+	_, err = repo.UpdateIssue(ctx, serviceId, "abc", func(issue *Issue) error {
+		issue.Count = 100
+		return errAbort
+	})
+
+	require.ErrorIs(t, err, errAbort)
+
+	var wg sync.WaitGroup
+
+	for range 5 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := repo.UpdateIssue(ctx, serviceId, "abc", func(issue *Issue) error {
+				issue.Count++
+				return nil
+			})
+
+			assert.Nil(t, err)
+		}()
+	}
+
+	wg.Wait()
+
+	resolvedAt := now.Add(time.Minute)
+
+	_, err = repo.UpdateIssue(ctx, serviceId, "abc", func(issue *Issue) error {
+		issue.Status = IssueResolved
+		issue.ResolvedAt = &resolvedAt
+		issue.ResolvedInRelease = "1.0.0"
+		return nil
+	})
+
+	require.Nil(t, err)
+
+	issue, err = repo.GetIssue(ctx, serviceId, "abc")
+
+	require.Nil(t, err)
+	assert.Equal(t, "ValueError", issue.Title)
+	assert.Equal(t, int64(6), issue.Count)
+	assert.Equal(t, IssueResolved, issue.Status)
+	assert.Equal(t, "1.0.0", issue.ResolvedInRelease)
+	require.NotNil(t, issue.ResolvedAt)
+	assert.True(t, resolvedAt.Equal(*issue.ResolvedAt))
+	assert.Nil(t, issue.IgnoreUntil)
+	assert.True(t, now.Equal(issue.FirstSeen))
+
+	_, err = repo.UpdateIssue(ctx, serviceId, "def", func(issue *Issue) error {
+		issue.Status = IssueUnresolved
+		issue.FirstSeen = now
+		issue.LastSeen = now.Add(time.Hour)
+		issue.Count = 1
+		return nil
+	})
+
+	require.Nil(t, err)
+
+	issues, err := repo.ListIssues(ctx, IssueQuery{ServiceId: serviceId})
+
+	require.Nil(t, err)
+	require.Len(t, issues, 2)
+	assert.Equal(t, "def", issues[0].Fingerprint)
+
+	issues, err = repo.ListIssues(ctx, IssueQuery{ServiceId: serviceId, Status: IssueResolved})
+
+	require.Nil(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "abc", issues[0].Fingerprint)
+}
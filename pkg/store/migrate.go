@@ -0,0 +1,113 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Represents an error when a migration could not be applied
+var ErrMigration = errors.New("an error occurred while attempting to migrate the database")
+
+// Represents a versioned migration, the version is the file name prefix, e.g. 0001_events.sql
+type migration struct {
+	version int
+	name    string
+	sql     string
+}
+
+// Applies the pending migrations of the directory, each one in its own transaction that checks whether it was applied
+func migrate(ctx context.Context, db *sql.DB, migrations fs.FS, dir string) error {
+	if _, err := db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY)"); err != nil {
+		return errors.Join(ErrMigration, err)
+	}
+
+	pending, err := readMigrations(migrations, dir)
+
+	if err != nil {
+		return errors.Join(ErrMigration, err)
+	}
+
+	for _, m := range pending {
+		if err := applyMigration(ctx, db, m); err != nil {
+			return fmt.Errorf("%w: %v: %w", ErrMigration, m.name, err)
+		}
+	}
+
+	return nil
+}
+
+func applyMigration(ctx context.Context, db *sql.DB, m migration) error {
+	tx, err := db.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	// the version is claimed before the migration runs, a concurrent migrator waits for the claim and skips it once committed
+	result, err := tx.ExecContext(ctx, fmt.Sprintf(
+		"INSERT INTO schema_migrations (version) SELECT %d WHERE NOT EXISTS (SELECT 1 FROM schema_migrations WHERE version >= %d) ON CONFLICT DO NOTHING",
+		m.version, m.version,
+	))
+
+	if err != nil {
+		return err
+	}
+
+	if claimed, err := result.RowsAffected(); err != nil || claimed == 0 {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, m.sql); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Returns the migrations of the directory sorted by version
+func readMigrations(migrations fs.FS, dir string) ([]migration, error) {
+	entries, err := fs.ReadDir(migrations, dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result []migration
+
+	for _, entry := range entries {
+		name := entry.Name()
+
+		if entry.IsDir() || !strings.HasSuffix(name, ".sql") {
+			continue
+		}
+
+		prefix, _, _ := strings.Cut(name, "_")
+
+		version, err := strconv.Atoi(prefix)
+
+		if err != nil {
+			return nil, fmt.Errorf("the migration %v has no version prefix", name)
+		}
+
+		content, err := fs.ReadFile(migrations, path.Join(dir, name))
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, migration{version, name, string(content)})
+	}
+
+	slices.SortFunc(result, func(a, b migration) int { return a.version - b.version })
+
+	return result, nil
+}
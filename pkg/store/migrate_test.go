@@ -0,0 +1,95 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "migrate.db"))
+
+	require.Nil(t, err)
+
+	defer db.Close()
+
+	migrations := fstest.MapFS{
+		"m/0002_bar.sql": {Data: []byte("CREATE TABLE bar (id TEXT)")},
+		"m/0001_foo.sql": {Data: []byte("CREATE TABLE foo (id TEXT)")},
+		"m/README.md":    {Data: []byte("ignored")},
+		"m/0010_baz.sql": {Data: []byte("CREATE TABLE baz (id TEXT)")},
+		"broken/foo.sql": {Data: []byte("SELECT 1")},
+	}
+
+	require.Nil(t, migrate(ctx, db, migrations, "m"))
+
+	// applying twice is a no-op
+	require.Nil(t, migrate(ctx, db, migrations, "m"))
+
+	var version int
+
+	require.Nil(t, db.QueryRow("SELECT MAX(version) FROM schema_migrations").Scan(&version))
+	assert.Equal(t, 10, version)
+
+	assert.ErrorIs(t, migrate(ctx, db, migrations, "broken"), ErrMigration)
+}
+
+func TestMigrateConcurrently(t *testing.T) {
+	ctx := context.Background()
+	dsn := "file:" + filepath.Join(t.TempDir(), "migrate.db") + "?_pragma=busy_timeout(5000)&_txlock=immediate"
+
+	migrations := fstest.MapFS{
+		"m/0001_foo.sql": {Data: []byte("CREATE TABLE foo (id TEXT)")},
+		"m/0002_bar.sql": {Data: []byte("CREATE TABLE bar (id TEXT)")},
+	}
+
+	errs := make(chan error, 4)
+
+	for range cap(errs) {
+		go func() {
+			db, err := sql.Open("sqlite", dsn)
+
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			defer db.Close()
+
+			errs <- migrate(ctx, db, migrations, "m")
+		}()
+	}
+
+	// each migration is applied once, whichever migrator claims it
+	for range cap(errs) {
+		assert.Nil(t, <-errs)
+	}
+}
+
+func TestMigrateRollsBack(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "migrate.db"))
+
+	require.Nil(t, err)
+
+	defer db.Close()
+
+	migrations := fstest.MapFS{
+		"m/0001_foo.sql": {Data: []byte("CREATE TABLE foo (id TEXT); CREATE TABLE foo (id TEXT);")},
+	}
+
+	assert.ErrorIs(t, migrate(ctx, db, migrations, "m"), ErrMigration)
+
+	var tables int
+
+	require.Nil(t, db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE name = 'foo'").Scan(&tables))
+	assert.Equal(t, 0, tables)
+}
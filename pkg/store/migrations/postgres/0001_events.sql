@@ -0,0 +1,29 @@
+CREATE TABLE events (
+    id TEXT NOT NULL,
+    service_id TEXT NOT NULL,
+    platform TEXT NOT NULL DEFAULT '',
+    level TEXT NOT NULL DEFAULT '',
+    fingerprint TEXT NOT NULL DEFAULT '',
+    tags JSONB NOT NULL DEFAULT '[]',
+    message TEXT NOT NULL DEFAULT '',
+    extra JSONB,
+    stack_trace JSONB,
+    event JSONB NOT NULL,
+    timestamp TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (id, timestamp)
+) PARTITION BY RANGE (timestamp);
+
+CREATE INDEX events_service_id_id ON events (service_id, id);
+CREATE INDEX events_service_id_timestamp ON events (service_id, timestamp DESC);
+CREATE INDEX events_fingerprint ON events (fingerprint) WHERE fingerprint <> '';
+CREATE INDEX events_tags ON events USING GIN (tags jsonb_path_ops);
+CREATE INDEX events_message ON events USING GIN (to_tsvector('simple', message));
+
+CREATE TABLE event_keys (
+    service_id TEXT NOT NULL,
+    id TEXT NOT NULL,
+    timestamp TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (service_id, id)
+);
+
+CREATE INDEX event_keys_timestamp ON event_keys (timestamp);
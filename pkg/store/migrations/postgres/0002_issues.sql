@@ -0,0 +1,12 @@
+CREATE TABLE issues (
+    service_id TEXT NOT NULL,
+    fingerprint TEXT NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    level TEXT NOT NULL DEFAULT '',
+    first_seen TIMESTAMPTZ NOT NULL,
+    last_seen TIMESTAMPTZ NOT NULL,
+    count BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (service_id, fingerprint)
+);
+
+CREATE INDEX issues_service_id_last_seen ON issues (service_id, last_seen DESC);
@@ -0,0 +1,10 @@
+ALTER TABLE issues
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'unresolved',
+    ADD COLUMN last_release TEXT NOT NULL DEFAULT '',
+    ADD COLUMN resolved_in_release TEXT NOT NULL DEFAULT '',
+    ADD COLUMN resolved_at TIMESTAMPTZ,
+    ADD COLUMN ignore_until TIMESTAMPTZ,
+    ADD COLUMN ignore_until_count BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN regressed_at TIMESTAMPTZ;
+
+CREATE INDEX issues_service_id_status_last_seen ON issues (service_id, status, last_seen DESC);
@@ -0,0 +1,18 @@
+CREATE TABLE events (
+    seq INTEGER PRIMARY KEY,
+    id TEXT NOT NULL,
+    service_id TEXT NOT NULL,
+    platform TEXT NOT NULL DEFAULT '',
+    level TEXT NOT NULL DEFAULT '',
+    fingerprint TEXT NOT NULL DEFAULT '',
+    tags TEXT NOT NULL DEFAULT '[]',
+    title TEXT NOT NULL DEFAULT '',
+    body TEXT NOT NULL DEFAULT '',
+    timestamp INTEGER NOT NULL,
+    event TEXT NOT NULL,
+    UNIQUE (service_id, id)
+);
+
+CREATE INDEX events_service_id_timestamp ON events (service_id, timestamp DESC);
+CREATE INDEX events_fingerprint ON events (fingerprint) WHERE fingerprint <> '';
+CREATE INDEX events_timestamp ON events (timestamp);
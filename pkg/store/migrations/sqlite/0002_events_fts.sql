@@ -0,0 +1,10 @@
+-- the index reads the title and body from the events, so the text is stored once
+CREATE VIRTUAL TABLE events_fts USING fts5 (title, body, content = 'events', content_rowid = 'seq');
+
+CREATE TRIGGER events_fts_insert AFTER INSERT ON events BEGIN
+    INSERT INTO events_fts (rowid, title, body) VALUES (new.seq, new.title, new.body);
+END;
+
+CREATE TRIGGER events_fts_delete AFTER DELETE ON events BEGIN
+    INSERT INTO events_fts (events_fts, rowid, title, body) VALUES ('delete', old.seq, old.title, old.body);
+END;
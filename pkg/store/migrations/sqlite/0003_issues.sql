@@ -0,0 +1,19 @@
+CREATE TABLE issues (
+    service_id TEXT NOT NULL,
+    fingerprint TEXT NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    level TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'unresolved',
+    first_seen INTEGER NOT NULL,
+    last_seen INTEGER NOT NULL,
+    count INTEGER NOT NULL DEFAULT 0,
+    last_release TEXT NOT NULL DEFAULT '',
+    resolved_in_release TEXT NOT NULL DEFAULT '',
+    resolved_at INTEGER,
+    ignore_until INTEGER,
+    ignore_until_count INTEGER NOT NULL DEFAULT 0,
+    regressed_at INTEGER,
+    PRIMARY KEY (service_id, fingerprint)
+);
+
+CREATE INDEX issues_service_id_status_last_seen ON issues (service_id, status, last_seen DESC);
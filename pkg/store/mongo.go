@@ -0,0 +1,189 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Represents a MongoDB connection error
+var ErrMongoConnection = errors.New("an error occurred while attempting to establish a MongoDB connection")
+
+// Represents a MongoDB index error
+var ErrMongoIndexes = errors.New("an error occurred while attempting to create the MongoDB indexes")
+
+// Represents the MongoDB database, collection and retention
+type MongoSettings struct {
+	// The database name
+	Database string
+	// The events collection name, issues are stored on the <collection>_issues collection
+	Collection string
+	// How long events are kept, zero keeps them forever
+	Retention time.Duration
+}
+
+// The MongoDB event repository
+type MongoEventRepository struct {
+	client     *mongo.Client
+	collection *mongo.Collection
+	issues     *mongo.Collection
+}
+
+// The MongoDB event key, event ids are unique per service
+type mongoEventKey struct {
+	ServiceId string `bson:"service_id"`
+	ID        string `bson:"id"`
+}
+
+// The MongoDB event document
+type mongoEvent struct {
+	ID          mongoEventKey `bson:"_id"`
+	EventRecord `bson:",inline"`
+}
+
+// Build a new MongoDB event repository, creating the collection indexes
+func NewMongoEventRepository(ctx context.Context, url string, settings MongoSettings) (*MongoEventRepository, error) {
+	opts := options.Client().
+		ApplyURI(url).
+		SetBSONOptions(&options.BSONOptions{UseJSONStructTags: true, DefaultDocumentM: true})
+
+	client, err := mongo.Connect(ctx, opts)
+
+	if err != nil {
+		return nil, errors.Join(ErrMongoConnection, err)
+	}
+
+	if err := client.Ping(ctx, nil); err != nil {
+		client.Disconnect(ctx)
+		return nil, errors.Join(ErrMongoConnection, err)
+	}
+
+	collection := client.Database(settings.Database).Collection(settings.Collection)
+	issues := client.Database(settings.Database).Collection(settings.Collection + "_issues")
+
+	if _, err := collection.Indexes().CreateMany(ctx, buildMongoIndexes(settings.Retention)); err != nil {
+		client.Disconnect(ctx)
+		return nil, errors.Join(ErrMongoIndexes, err)
+	}
+
+	if _, err := issues.Indexes().CreateMany(ctx, buildMongoIssueIndexes()); err != nil {
+		client.Disconnect(ctx)
+		return nil, errors.Join(ErrMongoIndexes, err)
+	}
+
+	return &MongoEventRepository{client, collection, issues}, nil
+}
+
+// Returns the events collection indexes, the timestamp index expires events after the retention
+func buildMongoIndexes(retention time.Duration) []mongo.IndexModel {
+	timestamp := options.Index().SetName("timestamp")
+
+	if retention > 0 {
+		timestamp.SetExpireAfterSeconds(int32(retention.Seconds()))
+	}
+
+	return []mongo.IndexModel{
+		{Keys: bson.D{{Key: "id", Value: 1}}, Options: options.Index().SetName("id")},
+		{Keys: bson.D{{Key: "service_id", Value: 1}, {Key: "timestamp", Value: -1}}, Options: options.Index().SetName("service_id_timestamp")},
+		{Keys: bson.D{{Key: "fingerprint", Value: 1}}, Options: options.Index().SetName("fingerprint").SetSparse(true)},
+		{Keys: bson.D{{Key: "tags", Value: 1}}, Options: options.Index().SetName("tags")},
+		{Keys: bson.D{{Key: "event.title", Value: "text"}, {Key: "event.body", Value: "text"}}, Options: options.Index().SetName("text")},
+		{Keys: bson.D{{Key: "timestamp", Value: 1}}, Options: timestamp},
+	}
+}
+
+// Save a event record, a redelivered event is saved once
+func (r *MongoEventRepository) Save(ctx context.Context, record EventRecord) (bool, error) {
+	_, err := r.collection.InsertOne(ctx, mongoEvent{mongoEventKey{record.ServiceId, record.ID}, record})
+
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// Returns the records matching the query, newest first
+func (r *MongoEventRepository) Find(ctx context.Context, query EventQuery) ([]EventRecord, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: -1}}).
+		SetLimit(int64(query.limit()))
+
+	cursor, err := r.collection.Find(ctx, buildMongoFilter(query), opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var documents []mongoEvent
+
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+
+	records := make([]EventRecord, 0, len(documents))
+
+	for _, d := range documents {
+		records = append(records, d.EventRecord)
+	}
+
+	return records, nil
+}
+
+// Returns the record of the service event id
+func (r *MongoEventRepository) Get(ctx context.Context, serviceId string, id string) (EventRecord, error) {
+	var document mongoEvent
+
+	err := r.collection.FindOne(ctx, bson.D{{Key: "service_id", Value: serviceId}, {Key: "id", Value: id}}).Decode(&document)
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return EventRecord{}, ErrEventNotFound
+	}
+
+	return document.EventRecord, err
+}
+
+// Close the MongoDB connection
+func (r *MongoEventRepository) Close() error {
+	return r.client.Disconnect(context.Background())
+}
+
+func buildMongoFilter(query EventQuery) bson.D {
+	filter := bson.D{}
+
+	if query.ServiceId != "" {
+		filter = append(filter, bson.E{Key: "service_id", Value: query.ServiceId})
+	}
+
+	if query.Fingerprint != "" {
+		filter = append(filter, bson.E{Key: "fingerprint", Value: query.Fingerprint})
+	}
+
+	if query.Tag != "" {
+		filter = append(filter, bson.E{Key: "tags", Value: query.Tag})
+	}
+
+	if query.Text != "" {
+		filter = append(filter, bson.E{Key: "$text", Value: bson.D{{Key: "$search", Value: query.Text}}})
+	}
+
+	timestamp := bson.D{}
+
+	if !query.Since.IsZero() {
+		timestamp = append(timestamp, bson.E{Key: "$gte", Value: query.Since})
+	}
+
+	if !query.Until.IsZero() {
+		timestamp = append(timestamp, bson.E{Key: "$lt", Value: query.Until})
+	}
+
+	if len(timestamp) > 0 {
+		filter = append(filter, bson.E{Key: "timestamp", Value: timestamp})
+	}
+
+	return filter
+}
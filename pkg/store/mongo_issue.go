@@ -0,0 +1,135 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// The MongoDB issue key
+type mongoIssueKey struct {
+	ServiceId   string `bson:"service_id"`
+	Fingerprint string `bson:"fingerprint"`
+}
+
+// The MongoDB issue document, the version guards concurrent updates
+type mongoIssue struct {
+	ID      mongoIssueKey `bson:"_id"`
+	Version int64         `bson:"version"`
+	Issue   `bson:",inline"`
+}
+
+// Returns the issues collection indexes
+func buildMongoIssueIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "service_id", Value: 1}, {Key: "status", Value: 1}, {Key: "last_seen", Value: -1}},
+			Options: options.Index().SetName("service_id_status_last_seen"),
+		},
+	}
+}
+
+// Updates the issue when its version is unchanged, retrying on concurrent updates
+func (r *MongoEventRepository) UpdateIssue(ctx context.Context, serviceId string, fingerprint string, fn func(issue *Issue) error) (Issue, error) {
+	key := mongoIssueKey{serviceId, fingerprint}
+
+	for range issueUpdateAttempts {
+		var document mongoIssue
+
+		err := r.issues.FindOne(ctx, bson.D{{Key: "_id", Value: key}}).Decode(&document)
+
+		exists := err == nil
+
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			document = mongoIssue{ID: key, Issue: Issue{ServiceId: serviceId, Fingerprint: fingerprint}}
+		} else if err != nil {
+			return Issue{}, err
+		}
+
+		if err := fn(&document.Issue); err != nil {
+			return Issue{}, err
+		}
+
+		version := document.Version
+		document.Version++
+
+		if !exists {
+			_, err := r.issues.InsertOne(ctx, document)
+
+			if mongo.IsDuplicateKeyError(err) {
+				continue
+			}
+
+			return document.Issue, err
+		}
+
+		result, err := r.issues.ReplaceOne(ctx, bson.D{{Key: "_id", Value: key}, {Key: "version", Value: version}}, document)
+
+		if err != nil {
+			return Issue{}, err
+		}
+
+		if result.MatchedCount == 1 {
+			return document.Issue, nil
+		}
+	}
+
+	return Issue{}, ErrIssueConflict
+}
+
+// Returns the issue of the service fingerprint
+func (r *MongoEventRepository) GetIssue(ctx context.Context, serviceId string, fingerprint string) (Issue, error) {
+	var document mongoIssue
+
+	err := r.issues.FindOne(ctx, bson.D{{Key: "_id", Value: mongoIssueKey{serviceId, fingerprint}}}).Decode(&document)
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return Issue{}, ErrIssueNotFound
+	}
+
+	return document.Issue, err
+}
+
+// Returns the issues matching the query, the most recently seen first
+func (r *MongoEventRepository) ListIssues(ctx context.Context, query IssueQuery) ([]Issue, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "last_seen", Value: -1}}).
+		SetLimit(int64(query.limit()))
+
+	cursor, err := r.issues.Find(ctx, buildMongoIssueFilter(query), opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var documents []mongoIssue
+
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+
+	issues := make([]Issue, 0, len(documents))
+
+	for _, d := range documents {
+		issues = append(issues, d.Issue)
+	}
+
+	return issues, nil
+}
+
+func buildMongoIssueFilter(query IssueQuery) bson.D {
+	filter := bson.D{}
+
+	if query.ServiceId != "" {
+		filter = append(filter, bson.E{Key: "service_id", Value: query.ServiceId})
+	}
+
+	if query.Status != "" {
+		filter = append(filter, bson.E{Key: "status", Value: query.Status})
+	}
+
+	return filter
+}
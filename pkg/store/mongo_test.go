@@ -0,0 +1,101 @@
+package store
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestBuildMongoFilter(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := since.Add(24 * time.Hour)
+
+	assert.Equal(t, bson.D{}, buildMongoFilter(EventQuery{}))
+
+	filter := buildMongoFilter(EventQuery{ServiceId: "1", Fingerprint: "abc", Tag: "env:production", Text: "foo", Since: since, Until: until})
+
+	assert.Equal(t, bson.D{
+		{Key: "service_id", Value: "1"},
+		{Key: "fingerprint", Value: "abc"},
+		{Key: "tags", Value: "env:production"},
+		{Key: "$text", Value: bson.D{{Key: "$search", Value: "foo"}}},
+		{Key: "timestamp", Value: bson.D{{Key: "$gte", Value: since}, {Key: "$lt", Value: until}}},
+	}, filter)
+}
+
+func TestBuildMongoIndexes(t *testing.T) {
+	indexes := buildMongoIndexes(24 * time.Hour)
+
+	require.Len(t, indexes, 6)
+	assert.Equal(t, int32(86400), *indexes[5].Options.ExpireAfterSeconds)
+
+	indexes = buildMongoIndexes(0)
+
+	assert.Nil(t, indexes[5].Options.ExpireAfterSeconds)
+}
+
+// Runs against a MongoDB server when MONGO_TEST_URL is set, e.g. mongodb://localhost:27017
+func TestMongoEventRepository(t *testing.T) {
+	url := os.Getenv("MONGO_TEST_URL")
+
+	if url == "" {
+		t.Skip("MONGO_TEST_URL is not set")
+	}
+
+	ctx := context.Background()
+
+	repo, err := NewMongoEventRepository(ctx, url, MongoSettings{
+		Database:   "bugs-channel-test",
+		Collection: "events_" + time.Now().Format("150405.000000"),
+		Retention:  time.Hour,
+	})
+
+	require.Nil(t, err)
+
+	defer repo.Close()
+	defer repo.collection.Drop(ctx)
+	defer repo.issues.Drop(ctx)
+
+	now := time.Now().Truncate(time.Millisecond)
+	e := event.Event{ID: "foo", ServiceId: "1", Platform: "python", Tags: []string{"fingerprint:abc"}, Extra: event.EventExtra{"foo": "bar"}}
+
+	assert.True(t, save(t, repo, NewEventRecord(e, now)))
+	assert.False(t, save(t, repo, NewEventRecord(e, now)))
+	assert.True(t, save(t, repo, NewEventRecord(event.Event{ID: "bar", ServiceId: "2"}, now)))
+
+	// the same id of another service is another event
+	assert.True(t, save(t, repo, NewEventRecord(event.Event{ID: "foo", ServiceId: "3"}, now)))
+
+	records, err := repo.Find(ctx, EventQuery{Fingerprint: "abc"})
+
+	require.Nil(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, e, records[0].Event)
+
+	record, err := repo.Get(ctx, "2", "bar")
+
+	require.Nil(t, err)
+	assert.Equal(t, "2", record.ServiceId)
+
+	// the id is looked up within the service
+	record, err = repo.Get(ctx, "3", "foo")
+
+	require.Nil(t, err)
+	assert.Equal(t, "3", record.ServiceId)
+
+	_, err = repo.Get(ctx, "1", "bar")
+
+	assert.ErrorIs(t, err, ErrEventNotFound)
+
+	_, err = repo.Get(ctx, "1", "baz")
+
+	assert.ErrorIs(t, err, ErrEventNotFound)
+
+	testIssueRepository(t, repo, "1")
+}
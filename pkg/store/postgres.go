@@ -0,0 +1,391 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+)
+
+//go:embed migrations/postgres/*.sql
+var postgresMigrations embed.FS
+
+// Represents a PostgreSQL connection error
+var ErrPostgresConnection = errors.New("an error occurred while attempting to establish a PostgreSQL connection")
+
+// The daily partition name layout, e.g. events_20240102
+const postgresPartitionLayout = "events_20060102"
+
+// Represents the PostgreSQL retention settings
+type PostgresSettings struct {
+	// How long events are kept, zero keeps them forever
+	Retention time.Duration
+	// How often the expired partitions are dropped
+	RetentionInterval time.Duration
+}
+
+// The PostgreSQL event repository, events are partitioned by day and grouped into issues by fingerprint
+type PostgresEventRepository struct {
+	db         *sql.DB
+	partitions sync.Map
+	stop       context.CancelFunc
+	wg         sync.WaitGroup
+}
+
+// Build a new PostgreSQL event repository, applying the pending migrations
+func NewPostgresEventRepository(ctx context.Context, url string, settings PostgresSettings) (*PostgresEventRepository, error) {
+	db, err := sql.Open("pgx", url)
+
+	if err != nil {
+		return nil, errors.Join(ErrPostgresConnection, err)
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, errors.Join(ErrPostgresConnection, err)
+	}
+
+	if err := migrate(ctx, db, postgresMigrations, "migrations/postgres"); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	retentionCtx, stop := context.WithCancel(context.Background())
+
+	r := &PostgresEventRepository{db: db, stop: stop}
+
+	if settings.Retention > 0 {
+		r.wg.Add(1)
+
+		go func() {
+			defer r.wg.Done()
+			runRetention(retentionCtx, r, settings.Retention, settings.RetentionInterval)
+		}()
+	}
+
+	return r, nil
+}
+
+// Save a event record, a redelivered event is saved once whatever time it was received again.
+// The event keys are not partitioned, so the first delivery decides the partition of the event.
+func (r *PostgresEventRepository) Save(ctx context.Context, record EventRecord) (bool, error) {
+	if err := r.ensurePartition(ctx, record.Timestamp); err != nil {
+		return false, err
+	}
+
+	tags, err := json.Marshal(record.Tags)
+
+	if err != nil {
+		return false, err
+	}
+
+	extra, err := marshalNullable(record.Event.Extra)
+
+	if err != nil {
+		return false, err
+	}
+
+	stackTrace, err := marshalNullable(record.Event.StackTrace)
+
+	if err != nil {
+		return false, err
+	}
+
+	// extra and stack trace live in their own columns
+	e := record.Event
+	e.Extra = nil
+	e.StackTrace = nil
+
+	body, err := json.Marshal(e)
+
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+
+	if err != nil {
+		return false, err
+	}
+
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		`INSERT INTO event_keys (service_id, id, timestamp) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`,
+		record.ServiceId, record.ID, record.Timestamp,
+	)
+
+	if err != nil {
+		return false, err
+	}
+
+	if inserted, err := result.RowsAffected(); err != nil || inserted == 0 {
+		return false, err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO events (id, service_id, platform, level, fingerprint, tags, message, extra, stack_trace, event, timestamp)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		record.ID, record.ServiceId, record.Platform, record.Level, record.Fingerprint, string(tags),
+		eventMessage(record.Event), extra, stackTrace, string(body), record.Timestamp,
+	)
+
+	if err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Creates the daily partition of the timestamp once
+func (r *PostgresEventRepository) ensurePartition(ctx context.Context, timestamp time.Time) error {
+	from := partitionDay(timestamp)
+	name := from.Format(postgresPartitionLayout)
+
+	if _, ok := r.partitions.Load(name); ok {
+		return nil
+	}
+
+	_, err := r.db.ExecContext(ctx, fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %v PARTITION OF events FOR VALUES FROM ('%v') TO ('%v')",
+		name, from.Format(time.RFC3339), from.AddDate(0, 0, 1).Format(time.RFC3339),
+	))
+
+	if err != nil {
+		return err
+	}
+
+	r.partitions.Store(name, struct{}{})
+
+	return nil
+}
+
+// Returns the records matching the query, newest first
+func (r *PostgresEventRepository) Find(ctx context.Context, query EventQuery) ([]EventRecord, error) {
+	where, args := buildPostgresFilter(query)
+
+	rows, err := r.db.QueryContext(ctx,
+		fmt.Sprintf("SELECT %v FROM events%v ORDER BY timestamp DESC LIMIT $%d", postgresEventColumns, where, len(args)+1),
+		append(args, query.limit())...,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	records := []EventRecord{}
+
+	for rows.Next() {
+		record, err := scanPostgresRecord(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
+
+// Returns the record of the service event id
+func (r *PostgresEventRepository) Get(ctx context.Context, serviceId string, id string) (EventRecord, error) {
+	row := r.db.QueryRowContext(ctx,
+		fmt.Sprintf("SELECT %v FROM events WHERE service_id = $1 AND id = $2 LIMIT 1", postgresEventColumns),
+		serviceId,
+		id,
+	)
+
+	record, err := scanPostgresRecord(row)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return EventRecord{}, ErrEventNotFound
+	}
+
+	return record, err
+}
+
+// Drops the daily partitions that ended before the given time and their event keys, returning how many events were dropped
+func (r *PostgresEventRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT c.relname FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		JOIN pg_class p ON p.oid = i.inhparent
+		WHERE p.relname = 'events'`,
+	)
+
+	if err != nil {
+		return 0, err
+	}
+
+	var expired []string
+
+	for rows.Next() {
+		var name string
+
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return 0, err
+		}
+
+		day, err := time.Parse(postgresPartitionLayout, name)
+
+		if err == nil && !day.AddDate(0, 0, 1).After(before) {
+			expired = append(expired, name)
+		}
+	}
+
+	rows.Close()
+
+	var purged int64
+
+	for _, name := range expired {
+		var count int64
+
+		if err := r.db.QueryRowContext(ctx, fmt.Sprintf("SELECT COUNT(*) FROM %v", name)).Scan(&count); err != nil {
+			return purged, err
+		}
+
+		if _, err := r.db.ExecContext(ctx, fmt.Sprintf("DROP TABLE %v", name)); err != nil {
+			return purged, err
+		}
+
+		r.partitions.Delete(name)
+
+		purged += count
+	}
+
+	// the keys of the dropped days go with them, the current day is kept whole
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM event_keys WHERE timestamp < $1", partitionDay(before)); err != nil {
+		return purged, err
+	}
+
+	return purged, nil
+}
+
+// Stop the retention job and close the database
+func (r *PostgresEventRepository) Close() error {
+	r.stop()
+	r.wg.Wait()
+
+	return r.db.Close()
+}
+
+const postgresEventColumns = "id, service_id, platform, level, fingerprint, tags, extra, stack_trace, event, timestamp"
+
+// Returns the UTC day the timestamp falls in
+func partitionDay(timestamp time.Time) time.Time {
+	year, month, day := timestamp.UTC().Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Returns the searchable exception message of the event
+func eventMessage(e event.Event) string {
+	return strings.TrimSpace(e.Title + "\n" + e.Body)
+}
+
+// Marshals the value to JSON, an empty value becomes NULL
+func marshalNullable[T ~map[string]interface{} | ~[]map[string]interface{}](value T) (any, error) {
+	if len(value) == 0 {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(value)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
+
+func buildPostgresFilter(query EventQuery) (string, []any) {
+	var conditions []string
+	var args []any
+
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if query.ServiceId != "" {
+		add("service_id = $%d", query.ServiceId)
+	}
+
+	if query.Fingerprint != "" {
+		add("fingerprint = $%d", query.Fingerprint)
+	}
+
+	if query.Tag != "" {
+		tag, _ := json.Marshal([]string{query.Tag})
+		add("tags @> $%d::jsonb", string(tag))
+	}
+
+	if query.Text != "" {
+		add("to_tsvector('simple', message) @@ plainto_tsquery('simple', $%d)", query.Text)
+	}
+
+	if !query.Since.IsZero() {
+		add("timestamp >= $%d", query.Since)
+	}
+
+	if !query.Until.IsZero() {
+		add("timestamp < $%d", query.Until)
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func scanPostgresRecord(row rowScanner) (EventRecord, error) {
+	var record EventRecord
+	var tags, extra, stackTrace, body []byte
+
+	err := row.Scan(&record.ID, &record.ServiceId, &record.Platform, &record.Level, &record.Fingerprint, &tags, &extra, &stackTrace, &body, &record.Timestamp)
+
+	if err != nil {
+		return record, err
+	}
+
+	record.Timestamp = record.Timestamp.UTC()
+
+	if err := json.Unmarshal(tags, &record.Tags); err != nil {
+		return record, err
+	}
+
+	if err := json.Unmarshal(body, &record.Event); err != nil {
+		return record, err
+	}
+
+	if extra != nil {
+		if err := json.Unmarshal(extra, &record.Event.Extra); err != nil {
+			return record, err
+		}
+	}
+
+	if stackTrace != nil {
+		if err := json.Unmarshal(stackTrace, &record.Event.StackTrace); err != nil {
+			return record, err
+		}
+	}
+
+	return record, nil
+}
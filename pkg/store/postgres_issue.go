@@ -0,0 +1,175 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const postgresIssueColumns = `service_id, fingerprint, title, level, status, first_seen, last_seen, count,
+	last_release, resolved_in_release, resolved_at, ignore_until, ignore_until_count, regressed_at`
+
+// Updates the issue in a transaction holding its row lock, a concurrent creation is retried
+func (r *PostgresEventRepository) UpdateIssue(ctx context.Context, serviceId string, fingerprint string, fn func(issue *Issue) error) (Issue, error) {
+	for range issueUpdateAttempts {
+		issue, updated, err := r.updateIssue(ctx, serviceId, fingerprint, fn)
+
+		if err != nil || updated {
+			return issue, err
+		}
+	}
+
+	return Issue{}, ErrIssueConflict
+}
+
+// Returns false when the issue was created concurrently
+func (r *PostgresEventRepository) updateIssue(ctx context.Context, serviceId string, fingerprint string, fn func(issue *Issue) error) (Issue, bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+
+	if err != nil {
+		return Issue{}, false, err
+	}
+
+	defer tx.Rollback()
+
+	row := tx.QueryRowContext(ctx,
+		fmt.Sprintf("SELECT %v FROM issues WHERE service_id = $1 AND fingerprint = $2 FOR UPDATE", postgresIssueColumns),
+		serviceId, fingerprint,
+	)
+
+	issue, err := scanPostgresIssue(row)
+
+	exists := err == nil
+
+	if errors.Is(err, sql.ErrNoRows) {
+		issue = Issue{ServiceId: serviceId, Fingerprint: fingerprint}
+	} else if err != nil {
+		return Issue{}, false, err
+	}
+
+	if err := fn(&issue); err != nil {
+		return Issue{}, false, err
+	}
+
+	args := []any{
+		issue.ServiceId, issue.Fingerprint, issue.Title, issue.Level, issue.Status, issue.FirstSeen, issue.LastSeen, issue.Count,
+		issue.LastRelease, issue.ResolvedInRelease, nullableTime(issue.ResolvedAt), nullableTime(issue.IgnoreUntil),
+		issue.IgnoreUntilCount, nullableTime(issue.RegressedAt),
+	}
+
+	query := `UPDATE issues SET title = $3, level = $4, status = $5, first_seen = $6, last_seen = $7, count = $8,
+		last_release = $9, resolved_in_release = $10, resolved_at = $11, ignore_until = $12, ignore_until_count = $13, regressed_at = $14
+		WHERE service_id = $1 AND fingerprint = $2`
+
+	if !exists {
+		query = fmt.Sprintf(`INSERT INTO issues (%v) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+			ON CONFLICT (service_id, fingerprint) DO NOTHING`, postgresIssueColumns)
+	}
+
+	result, err := tx.ExecContext(ctx, query, args...)
+
+	if err != nil {
+		return Issue{}, false, err
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return Issue{}, false, nil
+	}
+
+	return issue, true, tx.Commit()
+}
+
+// Returns the issue of the service fingerprint
+func (r *PostgresEventRepository) GetIssue(ctx context.Context, serviceId string, fingerprint string) (Issue, error) {
+	row := r.db.QueryRowContext(ctx,
+		fmt.Sprintf("SELECT %v FROM issues WHERE service_id = $1 AND fingerprint = $2", postgresIssueColumns),
+		serviceId, fingerprint,
+	)
+
+	issue, err := scanPostgresIssue(row)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return Issue{}, ErrIssueNotFound
+	}
+
+	return issue, err
+}
+
+// Returns the issues matching the query, the most recently seen first
+func (r *PostgresEventRepository) ListIssues(ctx context.Context, query IssueQuery) ([]Issue, error) {
+	var conditions []string
+	var args []any
+
+	if query.ServiceId != "" {
+		args = append(args, query.ServiceId)
+		conditions = append(conditions, fmt.Sprintf("service_id = $%d", len(args)))
+	}
+
+	if query.Status != "" {
+		args = append(args, query.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	where := ""
+
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		fmt.Sprintf("SELECT %v FROM issues%v ORDER BY last_seen DESC LIMIT $%d", postgresIssueColumns, where, len(args)+1),
+		append(args, query.limit())...,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	issues := []Issue{}
+
+	for rows.Next() {
+		issue, err := scanPostgresIssue(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		issues = append(issues, issue)
+	}
+
+	return issues, rows.Err()
+}
+
+func scanPostgresIssue(row rowScanner) (Issue, error) {
+	var issue Issue
+	var resolvedAt, ignoreUntil, regressedAt sql.NullTime
+
+	err := row.Scan(
+		&issue.ServiceId, &issue.Fingerprint, &issue.Title, &issue.Level, &issue.Status, &issue.FirstSeen, &issue.LastSeen, &issue.Count,
+		&issue.LastRelease, &issue.ResolvedInRelease, &resolvedAt, &ignoreUntil, &issue.IgnoreUntilCount, &regressedAt,
+	)
+
+	issue.FirstSeen = issue.FirstSeen.UTC()
+	issue.LastSeen = issue.LastSeen.UTC()
+	issue.ResolvedAt = timeOrNil(resolvedAt)
+	issue.IgnoreUntil = timeOrNil(ignoreUntil)
+	issue.RegressedAt = timeOrNil(regressedAt)
+
+	return issue, err
+}
+
+// Returns the UTC time or nil when it is NULL
+func timeOrNil(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	utc := t.Time.UTC()
+
+	return &utc
+}
@@ -0,0 +1,35 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Represents a repository whose expired events are purged by a retention job
+type purger interface {
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Purges the events older than the retention on every interval until the context is done
+func runRetention(ctx context.Context, p purger, retention time.Duration, interval time.Duration) {
+	ticker := time.NewTicker(max(interval, time.Second))
+	defer ticker.Stop()
+
+	for {
+		purged, err := p.Purge(ctx, time.Now().Add(-retention))
+
+		if err != nil && ctx.Err() == nil {
+			log.Errorf("⛔ Something went wrong when purging the expired events: %v", err)
+		} else if purged > 0 {
+			log.Infof("🧹 %v expired events were purged", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
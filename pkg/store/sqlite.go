@@ -0,0 +1,242 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	_ "modernc.org/sqlite"
+)
+
+//go:embed migrations/sqlite/*.sql
+var sqliteMigrations embed.FS
+
+// Represents a SQLite connection error
+var ErrSQLiteConnection = errors.New("an error occurred while attempting to open the SQLite database")
+
+// Represents the SQLite retention settings
+type SQLiteSettings struct {
+	// How long events are kept, zero keeps them forever
+	Retention time.Duration
+	// How often the expired events are purged
+	RetentionInterval time.Duration
+}
+
+// The SQLite event repository, meant for single node deployments
+type SQLiteEventRepository struct {
+	db   *sql.DB
+	stop context.CancelFunc
+	wg   sync.WaitGroup
+}
+
+// Build a new SQLite event repository at the file path, applying the pending migrations
+func NewSQLiteEventRepository(ctx context.Context, path string, settings SQLiteSettings) (*SQLiteEventRepository, error) {
+	dsn := fmt.Sprintf("file:%v?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_txlock=immediate", path)
+
+	db, err := sql.Open("sqlite", dsn)
+
+	if err != nil {
+		return nil, errors.Join(ErrSQLiteConnection, err)
+	}
+
+	if err := migrate(ctx, db, sqliteMigrations, "migrations/sqlite"); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	retentionCtx, stop := context.WithCancel(context.Background())
+
+	r := &SQLiteEventRepository{db: db, stop: stop}
+
+	if settings.Retention > 0 {
+		r.wg.Add(1)
+
+		go func() {
+			defer r.wg.Done()
+			runRetention(retentionCtx, r, settings.Retention, settings.RetentionInterval)
+		}()
+	}
+
+	return r, nil
+}
+
+// Save a event record, the full-text entry is indexed by a trigger and a redelivered event is saved once
+func (r *SQLiteEventRepository) Save(ctx context.Context, record EventRecord) (bool, error) {
+	tags, err := json.Marshal(record.Tags)
+
+	if err != nil {
+		return false, err
+	}
+
+	body, err := json.Marshal(record.Event)
+
+	if err != nil {
+		return false, err
+	}
+
+	result, err := r.db.ExecContext(ctx,
+		`INSERT OR IGNORE INTO events (id, service_id, platform, level, fingerprint, tags, title, body, timestamp, event)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		record.ID, record.ServiceId, record.Platform, record.Level, record.Fingerprint, string(tags),
+		record.Event.Title, record.Event.Body, record.Timestamp.UnixMilli(), string(body),
+	)
+
+	if err != nil {
+		return false, err
+	}
+
+	inserted, err := result.RowsAffected()
+
+	return inserted > 0, err
+}
+
+// Returns the records matching the query, newest first
+func (r *SQLiteEventRepository) Find(ctx context.Context, query EventQuery) ([]EventRecord, error) {
+	where, args := buildSQLiteFilter(query)
+
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT id, service_id, platform, level, fingerprint, tags, timestamp, event FROM events"+where+" ORDER BY timestamp DESC LIMIT ?",
+		append(args, query.limit())...,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	records := []EventRecord{}
+
+	for rows.Next() {
+		record, err := scanSQLiteRecord(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
+
+// Returns the record of the service event id
+func (r *SQLiteEventRepository) Get(ctx context.Context, serviceId string, id string) (EventRecord, error) {
+	row := r.db.QueryRowContext(ctx,
+		"SELECT id, service_id, platform, level, fingerprint, tags, timestamp, event FROM events WHERE service_id = ? AND id = ?",
+		serviceId,
+		id,
+	)
+
+	record, err := scanSQLiteRecord(row)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return EventRecord{}, ErrEventNotFound
+	}
+
+	return record, err
+}
+
+// Deletes the events received before the given time, returning how many were deleted
+func (r *SQLiteEventRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM events WHERE timestamp < ?", before.UnixMilli())
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// Stop the retention job and close the database
+func (r *SQLiteEventRepository) Close() error {
+	r.stop()
+	r.wg.Wait()
+
+	return r.db.Close()
+}
+
+func buildSQLiteFilter(query EventQuery) (string, []any) {
+	var conditions []string
+	var args []any
+
+	if query.ServiceId != "" {
+		conditions = append(conditions, "service_id = ?")
+		args = append(args, query.ServiceId)
+	}
+
+	if query.Fingerprint != "" {
+		conditions = append(conditions, "fingerprint = ?")
+		args = append(args, query.Fingerprint)
+	}
+
+	if query.Tag != "" {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM json_each(events.tags) WHERE value = ?)")
+		args = append(args, query.Tag)
+	}
+
+	if query.Text != "" {
+		conditions = append(conditions, "seq IN (SELECT rowid FROM events_fts WHERE events_fts MATCH ?)")
+		args = append(args, ftsPhrase(query.Text))
+	}
+
+	if !query.Since.IsZero() {
+		conditions = append(conditions, "timestamp >= ?")
+		args = append(args, query.Since.UnixMilli())
+	}
+
+	if !query.Until.IsZero() {
+		conditions = append(conditions, "timestamp < ?")
+		args = append(args, query.Until.UnixMilli())
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// Quotes the text as a FTS5 phrase, so the user input is never parsed as a query expression
+func ftsPhrase(text string) string {
+	return `"` + strings.ReplaceAll(text, `"`, `""`) + `"`
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSQLiteRecord(row rowScanner) (EventRecord, error) {
+	var record EventRecord
+	var tags, body string
+	var timestamp int64
+
+	err := row.Scan(&record.ID, &record.ServiceId, &record.Platform, &record.Level, &record.Fingerprint, &tags, &timestamp, &body)
+
+	if err != nil {
+		return record, err
+	}
+
+	record.Timestamp = time.UnixMilli(timestamp).UTC()
+
+	if err := json.Unmarshal([]byte(tags), &record.Tags); err != nil {
+		return record, err
+	}
+
+	var e event.Event
+
+	if err := json.Unmarshal([]byte(body), &e); err != nil {
+		return record, err
+	}
+
+	record.Event = e
+
+	return record, nil
+}
@@ -0,0 +1,159 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+)
+
+const sqliteIssueColumns = `service_id, fingerprint, title, level, status, first_seen, last_seen, count,
+	last_release, resolved_in_release, resolved_at, ignore_until, ignore_until_count, regressed_at`
+
+// Updates the issue in a transaction, transactions take the write lock on begin so updates never interleave
+func (r *SQLiteEventRepository) UpdateIssue(ctx context.Context, serviceId string, fingerprint string, fn func(issue *Issue) error) (Issue, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+
+	if err != nil {
+		return Issue{}, err
+	}
+
+	defer tx.Rollback()
+
+	row := tx.QueryRowContext(ctx,
+		"SELECT "+sqliteIssueColumns+" FROM issues WHERE service_id = ? AND fingerprint = ?",
+		serviceId, fingerprint,
+	)
+
+	issue, err := scanSQLiteIssue(row)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		issue = Issue{ServiceId: serviceId, Fingerprint: fingerprint}
+	} else if err != nil {
+		return Issue{}, err
+	}
+
+	if err := fn(&issue); err != nil {
+		return Issue{}, err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"INSERT OR REPLACE INTO issues ("+sqliteIssueColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		issue.ServiceId, issue.Fingerprint, issue.Title, issue.Level, issue.Status, issue.FirstSeen.UnixMilli(), issue.LastSeen.UnixMilli(), issue.Count,
+		issue.LastRelease, issue.ResolvedInRelease, nullableMillis(issue.ResolvedAt), nullableMillis(issue.IgnoreUntil),
+		issue.IgnoreUntilCount, nullableMillis(issue.RegressedAt),
+	)
+
+	if err != nil {
+		return Issue{}, err
+	}
+
+	return issue, tx.Commit()
+}
+
+// Returns the issue of the service fingerprint
+func (r *SQLiteEventRepository) GetIssue(ctx context.Context, serviceId string, fingerprint string) (Issue, error) {
+	row := r.db.QueryRowContext(ctx,
+		"SELECT "+sqliteIssueColumns+" FROM issues WHERE service_id = ? AND fingerprint = ?",
+		serviceId, fingerprint,
+	)
+
+	issue, err := scanSQLiteIssue(row)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return Issue{}, ErrIssueNotFound
+	}
+
+	return issue, err
+}
+
+// Returns the issues matching the query, the most recently seen first
+func (r *SQLiteEventRepository) ListIssues(ctx context.Context, query IssueQuery) ([]Issue, error) {
+	var conditions []string
+	var args []any
+
+	if query.ServiceId != "" {
+		conditions = append(conditions, "service_id = ?")
+		args = append(args, query.ServiceId)
+	}
+
+	if query.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, query.Status)
+	}
+
+	where := ""
+
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT "+sqliteIssueColumns+" FROM issues"+where+" ORDER BY last_seen DESC LIMIT ?",
+		append(args, query.limit())...,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	issues := []Issue{}
+
+	for rows.Next() {
+		issue, err := scanSQLiteIssue(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		issues = append(issues, issue)
+	}
+
+	return issues, rows.Err()
+}
+
+func scanSQLiteIssue(row rowScanner) (Issue, error) {
+	var issue Issue
+	var firstSeen, lastSeen int64
+	var resolvedAt, ignoreUntil, regressedAt sql.NullInt64
+
+	err := row.Scan(
+		&issue.ServiceId, &issue.Fingerprint, &issue.Title, &issue.Level, &issue.Status, &firstSeen, &lastSeen, &issue.Count,
+		&issue.LastRelease, &issue.ResolvedInRelease, &resolvedAt, &ignoreUntil, &issue.IgnoreUntilCount, &regressedAt,
+	)
+
+	if err != nil {
+		return issue, err
+	}
+
+	issue.FirstSeen = time.UnixMilli(firstSeen).UTC()
+	issue.LastSeen = time.UnixMilli(lastSeen).UTC()
+	issue.ResolvedAt = millisOrNil(resolvedAt)
+	issue.IgnoreUntil = millisOrNil(ignoreUntil)
+	issue.RegressedAt = millisOrNil(regressedAt)
+
+	return issue, nil
+}
+
+// Returns the unix milliseconds of the time or nil, so the database stores NULL
+func nullableMillis(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+
+	return t.UnixMilli()
+}
+
+// Returns the UTC time of the unix milliseconds or nil when it is NULL
+func millisOrNil(millis sql.NullInt64) *time.Time {
+	if !millis.Valid {
+		return nil
+	}
+
+	t := time.UnixMilli(millis.Int64).UTC()
+
+	return &t
+}
@@ -0,0 +1,145 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+)
+
+func TestSQLiteEventRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := buildTestSQLiteRepository(t)
+
+	now := time.Now().Truncate(time.Millisecond)
+
+	foo := event.Event{
+		ID:        "foo",
+		ServiceId: "1",
+		Platform:  "python",
+		Title:     "ValueError",
+		Body:      "invalid literal for int() with base 10",
+		Tags:      []string{"env:production", "fingerprint:abc"},
+		Extra:     event.EventExtra{"foo": "bar"},
+	}
+
+	assert.True(t, save(t, repo, NewEventRecord(foo, now.Add(-time.Minute))))
+	assert.False(t, save(t, repo, NewEventRecord(foo, now.Add(-time.Minute))))
+	assert.True(t, save(t, repo, NewEventRecord(event.Event{ID: "bar", ServiceId: "1", Title: "KeyError"}, now)))
+	assert.True(t, save(t, repo, NewEventRecord(event.Event{ID: "baz", ServiceId: "2"}, now)))
+
+	// the same id of another service is another event
+	assert.True(t, save(t, repo, NewEventRecord(event.Event{ID: "foo", ServiceId: "2"}, now)))
+
+	records, err := repo.Find(ctx, EventQuery{ServiceId: "1"})
+
+	require.Nil(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "bar", records[0].ID)
+	assert.Equal(t, "foo", records[1].ID)
+	assert.Equal(t, foo, records[1].Event)
+	assert.True(t, now.Add(-time.Minute).Equal(records[1].Timestamp))
+
+	records, err = repo.Find(ctx, EventQuery{Tag: "env:production", Fingerprint: "abc"})
+
+	require.Nil(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "foo", records[0].ID)
+
+	records, err = repo.Find(ctx, EventQuery{Text: "literal"})
+
+	require.Nil(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "foo", records[0].ID)
+
+	records, err = repo.Find(ctx, EventQuery{Text: `"unbalanced AND`})
+
+	require.Nil(t, err)
+	assert.Empty(t, records)
+
+	records, err = repo.Find(ctx, EventQuery{Since: now, Limit: 1})
+
+	require.Nil(t, err)
+	assert.Len(t, records, 1)
+
+	record, err := repo.Get(ctx, "2", "baz")
+
+	require.Nil(t, err)
+	assert.Equal(t, "2", record.ServiceId)
+
+	_, err = repo.Get(ctx, "1", "baz")
+
+	assert.ErrorIs(t, err, ErrEventNotFound)
+
+	_, err = repo.Get(ctx, "1", "qux")
+
+	assert.ErrorIs(t, err, ErrEventNotFound)
+}
+
+func TestSQLiteEventRepositoryPurge(t *testing.T) {
+	ctx := context.Background()
+	repo := buildTestSQLiteRepository(t)
+
+	now := time.Now()
+
+	assert.True(t, save(t, repo, NewEventRecord(event.Event{ID: "foo", Title: "ValueError"}, now.Add(-48*time.Hour))))
+	assert.True(t, save(t, repo, NewEventRecord(event.Event{ID: "bar", Title: "ValueError"}, now)))
+
+	purged, err := repo.Purge(ctx, now.Add(-24*time.Hour))
+
+	require.Nil(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	records, err := repo.Find(ctx, EventQuery{Text: "ValueError"})
+
+	require.Nil(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "bar", records[0].ID)
+
+	var entries int
+
+	// the purged event is removed from the index, not only from the events
+	require.Nil(t, repo.db.QueryRow("SELECT COUNT(*) FROM events_fts_docsize").Scan(&entries))
+	assert.Equal(t, 1, entries)
+}
+
+func TestSQLiteEventRepositoryRetention(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "events.db")
+
+	repo, err := NewSQLiteEventRepository(ctx, path, SQLiteSettings{})
+
+	require.Nil(t, err)
+	assert.True(t, save(t, repo, NewEventRecord(event.Event{ID: "foo"}, time.Now().Add(-48*time.Hour))))
+	require.Nil(t, repo.Close())
+
+	// reopening keeps the applied migrations and purges on start
+	repo, err = NewSQLiteEventRepository(ctx, path, SQLiteSettings{Retention: 24 * time.Hour, RetentionInterval: time.Hour})
+
+	require.Nil(t, err)
+
+	defer repo.Close()
+
+	assert.Eventually(t, func() bool {
+		_, err := repo.Get(ctx, "", "foo")
+		return err == ErrEventNotFound
+	}, time.Second, 10*time.Millisecond)
+}
+
+func buildTestSQLiteRepository(t *testing.T) *SQLiteEventRepository {
+	repo, err := NewSQLiteEventRepository(context.Background(), filepath.Join(t.TempDir(), "events.db"), SQLiteSettings{})
+
+	require.Nil(t, err)
+
+	t.Cleanup(func() { repo.Close() })
+
+	return repo
+}
+
+func TestSQLiteIssueRepository(t *testing.T) {
+	testIssueRepository(t, buildTestSQLiteRepository(t), "1")
+}
@@ -0,0 +1,107 @@
+// This package persists the consumed events and lets the web API query them
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/config"
+	"github.com/williampsena/bugs-channel/pkg/fingerprint"
+)
+
+// Represents an error when the event does not exist
+var ErrEventNotFound = errors.New("the event was not found")
+
+// Represents an error when the event store is unknown
+var ErrUnsupportedEventStore = errors.New("the event store is not supported")
+
+// The default page size of a query
+const DefaultQueryLimit = 50
+
+// Represents a persisted event and the fields it is queried by
+type EventRecord struct {
+	ID          string      `json:"id"`
+	ServiceId   string      `json:"service_id"`
+	Platform    string      `json:"platform"`
+	Level       string      `json:"level"`
+	Fingerprint string      `json:"fingerprint,omitempty"`
+	Tags        []string    `json:"tags"`
+	Timestamp   time.Time   `json:"timestamp"`
+	Event       event.Event `json:"event"`
+}
+
+// Represents the event query filters, empty filters match everything
+type EventQuery struct {
+	ServiceId   string
+	Fingerprint string
+	Tag         string
+	Text        string
+	Since       time.Time
+	Until       time.Time
+	Limit       int
+}
+
+// The event repository interface
+type EventRepository interface {
+	// Save a event record, returning false when the event was already saved
+	Save(ctx context.Context, record EventRecord) (bool, error)
+
+	// Returns the records matching the query, newest first
+	Find(ctx context.Context, query EventQuery) ([]EventRecord, error)
+
+	// Returns the record of the service event id
+	Get(ctx context.Context, serviceId string, id string) (EventRecord, error)
+
+	// Close the repository connection
+	Close() error
+}
+
+// Build the event repository of the given store from the environment settings
+func BuildEventRepository(ctx context.Context, eventStore string) (EventRepository, error) {
+	switch eventStore {
+	case "mongo":
+		return NewMongoEventRepository(ctx, config.MongoConnectionUrl(), MongoSettings{
+			Database:   config.MongoDatabase(),
+			Collection: "events",
+			Retention:  config.EventRetention(),
+		})
+	case "postgres":
+		return NewPostgresEventRepository(ctx, config.PostgresConnectionUrl(), PostgresSettings{
+			Retention:         config.EventRetention(),
+			RetentionInterval: time.Hour,
+		})
+	case "sqlite":
+		return NewSQLiteEventRepository(ctx, config.SQLitePath(), SQLiteSettings{
+			Retention:         config.EventRetention(),
+			RetentionInterval: time.Hour,
+		})
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrUnsupportedEventStore, eventStore)
+}
+
+// Build a new record of the event received at the given time
+func NewEventRecord(e event.Event, timestamp time.Time) EventRecord {
+	return EventRecord{
+		ID:          e.ID,
+		ServiceId:   e.ServiceId,
+		Platform:    e.Platform,
+		Level:       e.Level,
+		Fingerprint: fingerprint.FromTags(e.Tags),
+		Tags:        e.Tags,
+		Timestamp:   timestamp.UTC(),
+		Event:       e,
+	}
+}
+
+// Returns the query limit, falling back to the default page size
+func (q EventQuery) limit() int {
+	if q.Limit <= 0 {
+		return DefaultQueryLimit
+	}
+
+	return q.Limit
+}
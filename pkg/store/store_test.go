@@ -0,0 +1,54 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+)
+
+func TestNewEventRecord(t *testing.T) {
+	timestamp := time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("BRT", -3*60*60))
+
+	e := event.Event{
+		ID:        "foo",
+		ServiceId: "1",
+		Platform:  "python",
+		Level:     "error",
+		Tags:      []string{"env:production", "fingerprint:abc"},
+	}
+
+	record := NewEventRecord(e, timestamp)
+
+	assert.Equal(t, "foo", record.ID)
+	assert.Equal(t, "1", record.ServiceId)
+	assert.Equal(t, "python", record.Platform)
+	assert.Equal(t, "error", record.Level)
+	assert.Equal(t, "abc", record.Fingerprint)
+	assert.Equal(t, time.UTC, record.Timestamp.Location())
+	assert.True(t, timestamp.Equal(record.Timestamp))
+	assert.Equal(t, e, record.Event)
+}
+
+func TestBuildEventRepositoryUnsupported(t *testing.T) {
+	_, err := BuildEventRepository(context.Background(), "foo")
+
+	assert.ErrorIs(t, err, ErrUnsupportedEventStore)
+}
+
+func TestEventQueryLimit(t *testing.T) {
+	assert.Equal(t, DefaultQueryLimit, EventQuery{}.limit())
+	assert.Equal(t, 10, EventQuery{Limit: 10}.limit())
+}
+
+// Save the record, returning whether it was inserted
+func save(t *testing.T, repo EventRepository, record EventRecord) bool {
+	inserted, err := repo.Save(context.Background(), record)
+
+	require.Nil(t, err)
+
+	return inserted
+}
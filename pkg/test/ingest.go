@@ -0,0 +1,84 @@
+package test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	plugin "github.com/williampsena/bugs-channel-plugins/pkg/service"
+	"github.com/williampsena/bugs-channel/pkg/service"
+	"github.com/williampsena/bugs-channel/pkg/settings"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Represents a dispatcher recording the events, failing with the error when set
+type MockDispatcher struct {
+	Events []event.Event
+	Err    error
+}
+
+// Dispatch a event
+func (m *MockDispatcher) Dispatch(e event.Event) error {
+	if m.Err != nil {
+		return m.Err
+	}
+
+	m.Events = append(m.Events, e)
+
+	return nil
+}
+
+// Dispatch many events
+func (m *MockDispatcher) DispatchMany(events []event.Event) error {
+	for _, e := range events {
+		if err := m.Dispatch(e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Build the service fetcher of the settings fixture, read from a package directory
+func BuildServiceFetcher(t *testing.T) plugin.ServiceFetcher {
+	configFile, err := settings.BuildConfigFile("../../fixtures/settings/config.yml")
+
+	require.Nil(t, err)
+
+	return service.NewYAMLServiceFetcher(configFile.Services)
+}
+
+// Build a HTTP server of the handler, closed when the test ends
+func BuildServer(t *testing.T, handler http.Handler) *httptest.Server {
+	svr := httptest.NewServer(handler)
+
+	t.Cleanup(svr.Close)
+
+	return svr
+}
+
+// Build a client connection to the gRPC server through an in-memory listener, both closed when the test ends
+func BuildGrpcConn(t *testing.T, srv *grpc.Server) *grpc.ClientConn {
+	lis := bufconn.Listen(1 << 20)
+
+	go srv.Serve(lis)
+
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+
+	require.Nil(t, err)
+
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
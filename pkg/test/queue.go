@@ -0,0 +1,43 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel/pkg/storage"
+)
+
+// Build a memory queue, closed when the test ends
+func BuildMemoryQueue(t *testing.T) *storage.MemoryQueue {
+	queue, err := storage.NewMemoryQueue(100, storage.OverflowDropNewest)
+
+	require.Nil(t, err)
+
+	memory := queue.(*storage.MemoryQueue)
+
+	t.Cleanup(memory.Close)
+
+	return memory
+}
+
+// Subscribes to the topic until the test ends, returning the delivered messages once the subscription is registered
+func SubscribeMemoryQueue(t *testing.T, queue *storage.MemoryQueue, topic string) <-chan string {
+	ctx, cancel := context.WithCancel(context.Background())
+	messages := make(chan string, 100)
+	count := queue.SubscriberCount(topic)
+
+	go queue.Subscribe(ctx, topic, func(header map[string][]string, body string) error {
+		messages <- body
+		return nil
+	})
+
+	t.Cleanup(cancel)
+
+	require.Eventually(t, func() bool {
+		return queue.SubscriberCount(topic) > count
+	}, time.Second, time.Millisecond)
+
+	return messages
+}
@@ -0,0 +1,44 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	plugin "github.com/williampsena/bugs-channel-plugins/pkg/service"
+)
+
+// Represents an error when the request does not carry a valid authentication key
+var ErrUnauthorized = errors.New("the authentication key is missing or invalid")
+
+// The header that carries the service authentication key
+const AuthKeyHeader = "X-Auth-Key"
+
+type contextKey string
+
+const serviceContextKey contextKey = "service"
+
+// Resolves the service from the authentication key and stores it in the request context
+func authMiddleware(c *ServerContext) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			service, err := c.ServiceFetcher.GetServiceByAuthKey(r.Header.Get(AuthKeyHeader))
+
+			if err != nil {
+				HandleErrors(w, errors.Join(ErrUnauthorized, err), http.StatusUnauthorized)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), serviceContextKey, service)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// Returns the service resolved by the auth middleware
+func serviceFromRequest(r *http.Request) (plugin.Service, bool) {
+	service, ok := r.Context().Value(serviceContextKey).(plugin.Service)
+	return service, ok
+}
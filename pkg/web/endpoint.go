@@ -2,6 +2,7 @@
 package web
 
 import (
+	"expvar"
 	"fmt"
 	"net/http"
 
@@ -14,6 +15,35 @@ func HealthCheckEndpoint(w http.ResponseWriter, req *http.Request) {
 	fmt.Fprintf(w, "Keep calm I'm absolutely alive 🐛")
 }
 
+// The expvars served by the debug endpoint, the process command line and memory stats are left out
+var debugVars = []string{"spool"}
+
+// Writes the spool counters as the JSON object of the expvar handler
+func DebugVarsEndpoint(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	fmt.Fprintf(w, "{")
+
+	first := true
+
+	for _, name := range debugVars {
+		v := expvar.Get(name)
+
+		if v == nil {
+			continue
+		}
+
+		if !first {
+			fmt.Fprintf(w, ",")
+		}
+
+		first = false
+
+		fmt.Fprintf(w, "%q: %v", name, v)
+	}
+
+	fmt.Fprintf(w, "}")
+}
+
 func NoRouteEndpoint(w http.ResponseWriter, req *http.Request) {
 	w.WriteHeader(http.StatusNotFound)
 	fmt.Fprintf(w, "Oops! 👀")
@@ -1,6 +1,7 @@
 package web
 
 import (
+	"expvar"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -24,3 +25,14 @@ func TestHealthCheckEndPoint(t *testing.T) {
 
 	assert.Contains(t, string(data), "Keep calm I'm absolutely alive 🐛")
 }
+
+func TestDebugVarsEndpoint(t *testing.T) {
+	expvar.Publish("spool", expvar.Func(func() any { return map[string]int{"records": 2} }))
+
+	w := httptest.NewRecorder()
+
+	DebugVarsEndpoint(w, httptest.NewRequest(http.MethodGet, "/debug/vars", nil))
+
+	assert.JSONEq(t, `{"spool": {"records": 2}}`, w.Body.String())
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+}
@@ -0,0 +1,124 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/ingest"
+	"github.com/williampsena/bugs-channel/pkg/ratelimit"
+)
+
+// Represents an error when the request body is not a valid event payload
+var ErrInvalidEventPayload = errors.New("the event payload is invalid")
+
+// Represents an error when the events could not be dispatched
+var ErrDispatchEvents = errors.New("an error occurred while attempting to dispatch events")
+
+// The maximum size accepted for an event request body
+const maxEventBodyBytes = 1 << 20
+
+// The events dispatcher contract used by the web routes
+type EventsDispatcher interface {
+	// Dispatch a event
+	Dispatch(event event.Event) error
+
+	// Dispatch many events
+	DispatchMany(events []event.Event) error
+}
+
+// Represents the response of the event routes
+type EventResponse struct {
+	// The accepted event ids
+	Ids []string `json:"ids"`
+}
+
+// Receives a single event and dispatches it
+func EventEndpoint(c *ServerContext) EndpointHandler {
+	return func(w http.ResponseWriter, req *http.Request) {
+		var e event.Event
+
+		if err := decodeEventBody(w, req, &e); err != nil {
+			HandleErrors(w, err, http.StatusBadRequest)
+			return
+		}
+
+		events := []event.Event{e}
+
+		dispatchEvents(c, w, req, events)
+	}
+}
+
+// Receives a batch of events and dispatches them
+func EventBatchEndpoint(c *ServerContext) EndpointHandler {
+	return func(w http.ResponseWriter, req *http.Request) {
+		var events []event.Event
+
+		if err := decodeEventBody(w, req, &events); err != nil {
+			HandleErrors(w, err, http.StatusBadRequest)
+			return
+		}
+
+		if len(events) == 0 {
+			HandleErrors(w, ErrInvalidEventPayload, http.StatusBadRequest)
+			return
+		}
+
+		dispatchEvents(c, w, req, events)
+	}
+}
+
+func decodeEventBody(w http.ResponseWriter, req *http.Request, v any) error {
+	body := http.MaxBytesReader(w, req.Body, maxEventBodyBytes)
+
+	if err := json.NewDecoder(body).Decode(v); err != nil {
+		return errors.Join(ErrInvalidEventPayload, err)
+	}
+
+	return nil
+}
+
+func dispatchEvents(c *ServerContext, w http.ResponseWriter, req *http.Request, events []event.Event) {
+	service, ok := serviceFromRequest(req)
+
+	if !ok {
+		HandleErrors(w, ErrUnauthorized, http.StatusUnauthorized)
+		return
+	}
+
+	ids := make([]string, len(events))
+
+	for i := range events {
+		events[i].ServiceId = service.Id
+
+		if events[i].ID == "" {
+			id, err := ingest.NewEventId()
+
+			if err != nil {
+				HandleErrors(w, err, http.StatusInternalServerError)
+				return
+			}
+
+			events[i].ID = id
+		}
+
+		ids[i] = events[i].ID
+	}
+
+	if err := c.EventsDispatcher.DispatchMany(events); err != nil {
+		var limitErr *ratelimit.LimitError
+
+		if errors.As(err, &limitErr) {
+			writeServiceRateLimited(w, limitErr)
+			return
+		}
+
+		HandleErrors(w, errors.Join(ErrDispatchEvents, err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(EventResponse{Ids: ids})
+}
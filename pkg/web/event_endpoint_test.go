@@ -0,0 +1,118 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/test"
+)
+
+func TestEventEndpointSuccess(t *testing.T) {
+	queue := test.BuildMemoryQueue(t)
+	messages := test.SubscribeMemoryQueue(t, queue, "events")
+	c := buildTestServerContextWithQueue(t, queue)
+	svr := buildTestServerWithContext(t, c)
+
+	defer svr.Close()
+
+	res := doEventRequest(t, svr.URL+"/api/v1/events", "key", `{"id":"foo","platform":"python","tags":["app:foo"]}`)
+
+	require.Equal(t, http.StatusAccepted, res.StatusCode)
+
+	var body EventResponse
+	require.Nil(t, json.NewDecoder(res.Body).Decode(&body))
+
+	assert.Equal(t, []string{"foo"}, body.Ids)
+
+	var e event.Event
+	require.Nil(t, json.Unmarshal([]byte(<-messages), &e))
+
+	assert.Equal(t, "1", e.ServiceId)
+	assert.Equal(t, "python", e.Platform)
+}
+
+func TestEventBatchEndpointSuccess(t *testing.T) {
+	queue := test.BuildMemoryQueue(t)
+	messages := test.SubscribeMemoryQueue(t, queue, "events")
+	c := buildTestServerContextWithQueue(t, queue)
+	svr := buildTestServerWithContext(t, c)
+
+	defer svr.Close()
+
+	res := doEventRequest(t, svr.URL+"/api/v1/events/batch", "key", `[{"id":"foo"},{"platform":"go"}]`)
+
+	require.Equal(t, http.StatusAccepted, res.StatusCode)
+
+	var body EventResponse
+	require.Nil(t, json.NewDecoder(res.Body).Decode(&body))
+
+	require.Len(t, body.Ids, 2)
+	assert.Equal(t, "foo", body.Ids[0])
+	assert.NotEmpty(t, body.Ids[1])
+
+	assert.Contains(t, <-messages, `"id":"foo"`)
+	assert.Contains(t, <-messages, `"platform":"go"`)
+}
+
+func TestEventEndpointUnauthorized(t *testing.T) {
+	svr := buildTestServer(t)
+
+	defer svr.Close()
+
+	for _, key := range []string{"", "expired_key", "disabled_key", "unknown"} {
+		res := doEventRequest(t, svr.URL+"/api/v1/events", key, `{"id":"foo"}`)
+
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode, fmt.Sprintf("key %q", key))
+	}
+}
+
+func TestEventEndpointInvalidPayload(t *testing.T) {
+	svr := buildTestServer(t)
+
+	defer svr.Close()
+
+	res := doEventRequest(t, svr.URL+"/api/v1/events", "key", `{"id":`)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res = doEventRequest(t, svr.URL+"/api/v1/events/batch", "key", `[]`)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func TestEventEndpointDispatchError(t *testing.T) {
+	queue := test.BuildMemoryQueue(t)
+
+	// a closed queue refuses the event and its dead letter
+	queue.Close()
+
+	c := buildTestServerContextWithQueue(t, queue)
+	svr := buildTestServerWithContext(t, c)
+
+	defer svr.Close()
+
+	res := doEventRequest(t, svr.URL+"/api/v1/events", "key", `{"id":"foo"}`)
+
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+}
+
+func doEventRequest(t *testing.T, url string, authKey string, body string) *http.Response {
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+
+	require.Nil(t, err)
+
+	req.Header.Set(AuthKeyHeader, authKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+
+	require.Nil(t, err)
+
+	t.Cleanup(func() { res.Body.Close() })
+
+	return res
+}
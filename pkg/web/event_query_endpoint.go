@@ -0,0 +1,123 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/williampsena/bugs-channel/pkg/store"
+)
+
+// Represents an error when the event query parameters are invalid
+var ErrInvalidEventQuery = errors.New("the event query is invalid")
+
+// Represents an error when the events could not be queried
+var ErrQueryEvents = errors.New("an error occurred while attempting to query events")
+
+// The maximum page size of the event query
+const maxEventQueryLimit = 500
+
+// The repository contract used by the event query routes
+type EventRepository interface {
+	// Returns the records matching the query, newest first
+	Find(ctx context.Context, query store.EventQuery) ([]store.EventRecord, error)
+
+	// Returns the record of the service event id
+	Get(ctx context.Context, serviceId string, id string) (store.EventRecord, error)
+}
+
+// Returns the events of the authenticated service filtered by fingerprint, tag, text, since, until and limit
+func EventQueryEndpoint(c *ServerContext) EndpointHandler {
+	return func(w http.ResponseWriter, req *http.Request) {
+		service, ok := serviceFromRequest(req)
+
+		if !ok {
+			HandleErrors(w, ErrUnauthorized, http.StatusUnauthorized)
+			return
+		}
+
+		query, err := buildEventQuery(req.URL.Query())
+
+		if err != nil {
+			HandleErrors(w, err, http.StatusBadRequest)
+			return
+		}
+
+		query.ServiceId = service.Id
+
+		records, err := c.EventRepository.Find(req.Context(), query)
+
+		if err != nil {
+			HandleErrors(w, errors.Join(ErrQueryEvents, err), http.StatusInternalServerError)
+			return
+		}
+
+		writeJson(w, records)
+	}
+}
+
+// Returns the event of the authenticated service by id
+func EventGetEndpoint(c *ServerContext) EndpointHandler {
+	return func(w http.ResponseWriter, req *http.Request) {
+		service, ok := serviceFromRequest(req)
+
+		if !ok {
+			HandleErrors(w, ErrUnauthorized, http.StatusUnauthorized)
+			return
+		}
+
+		record, err := c.EventRepository.Get(req.Context(), service.Id, mux.Vars(req)["id"])
+
+		if errors.Is(err, store.ErrEventNotFound) {
+			HandleErrors(w, err, http.StatusNotFound)
+			return
+		}
+
+		if err != nil {
+			HandleErrors(w, errors.Join(ErrQueryEvents, err), http.StatusInternalServerError)
+			return
+		}
+
+		writeJson(w, record)
+	}
+}
+
+func buildEventQuery(values url.Values) (store.EventQuery, error) {
+	query := store.EventQuery{
+		Fingerprint: values.Get("fingerprint"),
+		Tag:         values.Get("tag"),
+		Text:        values.Get("q"),
+	}
+
+	var err error
+
+	if v := values.Get("since"); v != "" {
+		if query.Since, err = time.Parse(time.RFC3339, v); err != nil {
+			return query, errors.Join(ErrInvalidEventQuery, err)
+		}
+	}
+
+	if v := values.Get("until"); v != "" {
+		if query.Until, err = time.Parse(time.RFC3339, v); err != nil {
+			return query, errors.Join(ErrInvalidEventQuery, err)
+		}
+	}
+
+	if v := values.Get("limit"); v != "" {
+		if query.Limit, err = strconv.Atoi(v); err != nil || query.Limit < 0 || query.Limit > maxEventQueryLimit {
+			return query, errors.Join(ErrInvalidEventQuery, err)
+		}
+	}
+
+	return query, nil
+}
+
+func writeJson(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
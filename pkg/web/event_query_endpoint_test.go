@@ -0,0 +1,149 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/store"
+)
+
+func TestEventQueryEndpoint(t *testing.T) {
+	repo := &mockRepository{records: []store.EventRecord{{ID: "foo", ServiceId: "1", Event: event.Event{ID: "foo"}}}}
+	svr := buildTestServerWithRepository(t, repo)
+
+	defer svr.Close()
+
+	res := doQueryRequest(t, svr.URL+"/api/v1/events?fingerprint=abc&tag=env:production&q=ValueError&since=2024-01-01T00:00:00Z&limit=10", "key")
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var records []store.EventRecord
+	require.Nil(t, json.NewDecoder(res.Body).Decode(&records))
+
+	require.Len(t, records, 1)
+	assert.Equal(t, "foo", records[0].ID)
+
+	assert.Equal(t, store.EventQuery{
+		ServiceId:   "1",
+		Fingerprint: "abc",
+		Tag:         "env:production",
+		Text:        "ValueError",
+		Since:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Limit:       10,
+	}, repo.query)
+}
+
+func TestEventQueryEndpointInvalid(t *testing.T) {
+	svr := buildTestServerWithRepository(t, &mockRepository{})
+
+	defer svr.Close()
+
+	for _, query := range []string{"since=yesterday", "until=1", "limit=foo", "limit=1000"} {
+		res := doQueryRequest(t, svr.URL+"/api/v1/events?"+query, "key")
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, query)
+	}
+}
+
+func TestEventGetEndpoint(t *testing.T) {
+	// another service stored an event with the same id first
+	repo := &mockRepository{records: []store.EventRecord{
+		{ID: "foo", ServiceId: "2"},
+		{ID: "foo", ServiceId: "1"},
+		{ID: "bar", ServiceId: "2"},
+	}}
+	svr := buildTestServerWithRepository(t, repo)
+
+	defer svr.Close()
+
+	res := doQueryRequest(t, svr.URL+"/api/v1/events/foo", "key")
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var record store.EventRecord
+	require.Nil(t, json.NewDecoder(res.Body).Decode(&record))
+
+	assert.Equal(t, "foo", record.ID)
+	assert.Equal(t, "1", record.ServiceId)
+
+	// another service event
+	res = doQueryRequest(t, svr.URL+"/api/v1/events/bar", "key")
+
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+
+	res = doQueryRequest(t, svr.URL+"/api/v1/events/baz", "key")
+
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
+func TestEventQueryEndpointUnauthorized(t *testing.T) {
+	svr := buildTestServerWithRepository(t, &mockRepository{})
+
+	defer svr.Close()
+
+	res := doQueryRequest(t, svr.URL+"/api/v1/events", "invalid")
+
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+}
+
+func TestEventQueryEndpointWithoutRepository(t *testing.T) {
+	svr := buildTestServer(t)
+
+	defer svr.Close()
+
+	res := doQueryRequest(t, svr.URL+"/api/v1/events/foo", "key")
+
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
+func buildTestServerWithRepository(t *testing.T, repo *mockRepository) *httptest.Server {
+	c := buildTestServerContext(t)
+	c.EventRepository = repo
+
+	return buildTestServerWithContext(t, c)
+}
+
+func doQueryRequest(t *testing.T, url string, authKey string) *http.Response {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+
+	require.Nil(t, err)
+
+	req.Header.Set(AuthKeyHeader, authKey)
+
+	res, err := http.DefaultClient.Do(req)
+
+	require.Nil(t, err)
+
+	t.Cleanup(func() { res.Body.Close() })
+
+	return res
+}
+
+type mockRepository struct {
+	records []store.EventRecord
+	query   store.EventQuery
+}
+
+// Returns every record
+func (m *mockRepository) Find(ctx context.Context, query store.EventQuery) ([]store.EventRecord, error) {
+	m.query = query
+	return m.records, nil
+}
+
+// Returns the record of the service event id
+func (m *mockRepository) Get(ctx context.Context, serviceId string, id string) (store.EventRecord, error) {
+	for _, r := range m.records {
+		if r.ServiceId == serviceId && r.ID == id {
+			return r, nil
+		}
+	}
+
+	return store.EventRecord{}, store.ErrEventNotFound
+}
@@ -0,0 +1,171 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/williampsena/bugs-channel/pkg/issue"
+	"github.com/williampsena/bugs-channel/pkg/store"
+)
+
+// Represents an error when the issue query parameters are invalid
+var ErrInvalidIssueQuery = errors.New("the issue query is invalid")
+
+// Represents an error when the issues could not be queried or updated
+var ErrQueryIssues = errors.New("an error occurred while attempting to query issues")
+
+// The repository contract used by the issue routes
+type IssueRepository interface {
+	// Updates the issue of the fingerprint atomically
+	UpdateIssue(ctx context.Context, serviceId string, fingerprint string, fn func(issue *store.Issue) error) (store.Issue, error)
+
+	// Returns the issue of the fingerprint
+	GetIssue(ctx context.Context, serviceId string, fingerprint string) (store.Issue, error)
+
+	// Returns the issues matching the query, last seen first
+	ListIssues(ctx context.Context, query store.IssueQuery) ([]store.Issue, error)
+}
+
+// Represents the issue status change request
+type IssueStatusChange struct {
+	Status            store.IssueStatus `json:"status"`
+	InNextRelease     bool              `json:"in_next_release"`
+	IgnoreOccurrences int64             `json:"ignore_occurrences"`
+	IgnoreUntil       *time.Time        `json:"ignore_until"`
+}
+
+// Returns the issues of the authenticated service filtered by status and limit
+func IssueQueryEndpoint(c *ServerContext) EndpointHandler {
+	return func(w http.ResponseWriter, req *http.Request) {
+		service, ok := serviceFromRequest(req)
+
+		if !ok {
+			HandleErrors(w, ErrUnauthorized, http.StatusUnauthorized)
+			return
+		}
+
+		query, err := buildIssueQuery(req.URL.Query())
+
+		if err != nil {
+			HandleErrors(w, err, http.StatusBadRequest)
+			return
+		}
+
+		query.ServiceId = service.Id
+
+		issues, err := c.IssueRepository.ListIssues(req.Context(), query)
+
+		if err != nil {
+			HandleErrors(w, errors.Join(ErrQueryIssues, err), http.StatusInternalServerError)
+			return
+		}
+
+		writeJson(w, issues)
+	}
+}
+
+// Returns the issue of the authenticated service by fingerprint
+func IssueGetEndpoint(c *ServerContext) EndpointHandler {
+	return func(w http.ResponseWriter, req *http.Request) {
+		service, ok := serviceFromRequest(req)
+
+		if !ok {
+			HandleErrors(w, ErrUnauthorized, http.StatusUnauthorized)
+			return
+		}
+
+		found, err := c.IssueRepository.GetIssue(req.Context(), service.Id, mux.Vars(req)["fingerprint"])
+
+		if errors.Is(err, store.ErrIssueNotFound) {
+			HandleErrors(w, err, http.StatusNotFound)
+			return
+		}
+
+		if err != nil {
+			HandleErrors(w, errors.Join(ErrQueryIssues, err), http.StatusInternalServerError)
+			return
+		}
+
+		writeJson(w, found)
+	}
+}
+
+// Resolves, ignores or unresolves the issue of the authenticated service
+func IssueUpdateEndpoint(c *ServerContext) EndpointHandler {
+	return func(w http.ResponseWriter, req *http.Request) {
+		service, ok := serviceFromRequest(req)
+
+		if !ok {
+			HandleErrors(w, ErrUnauthorized, http.StatusUnauthorized)
+			return
+		}
+
+		var change IssueStatusChange
+
+		if err := json.NewDecoder(req.Body).Decode(&change); err != nil {
+			HandleErrors(w, errors.Join(issue.ErrInvalidStatusChange, err), http.StatusBadRequest)
+			return
+		}
+
+		updated, err := c.IssueRepository.UpdateIssue(req.Context(), service.Id, mux.Vars(req)["fingerprint"], func(found *store.Issue) error {
+			// issues are only created by events
+			if found.FirstSeen.IsZero() {
+				return store.ErrIssueNotFound
+			}
+
+			return applyStatusChange(found, change)
+		})
+
+		switch {
+		case errors.Is(err, store.ErrIssueNotFound):
+			HandleErrors(w, err, http.StatusNotFound)
+		case errors.Is(err, issue.ErrInvalidStatusChange):
+			HandleErrors(w, err, http.StatusBadRequest)
+		case err != nil:
+			HandleErrors(w, errors.Join(ErrQueryIssues, err), http.StatusInternalServerError)
+		default:
+			writeJson(w, updated)
+		}
+	}
+}
+
+func applyStatusChange(found *store.Issue, change IssueStatusChange) error {
+	switch change.Status {
+	case store.IssueResolved:
+		issue.Resolve(found, change.InNextRelease, time.Now().UTC())
+		return nil
+	case store.IssueIgnored:
+		return issue.Ignore(found, issue.IgnoreOptions{Occurrences: change.IgnoreOccurrences, Until: change.IgnoreUntil})
+	case store.IssueUnresolved:
+		issue.Unresolve(found)
+		return nil
+	}
+
+	return issue.ErrInvalidStatusChange
+}
+
+func buildIssueQuery(values url.Values) (store.IssueQuery, error) {
+	query := store.IssueQuery{Status: store.IssueStatus(values.Get("status"))}
+
+	switch query.Status {
+	case "", store.IssueUnresolved, store.IssueResolved, store.IssueIgnored:
+	default:
+		return query, ErrInvalidIssueQuery
+	}
+
+	if v := values.Get("limit"); v != "" {
+		var err error
+
+		if query.Limit, err = strconv.Atoi(v); err != nil || query.Limit < 0 || query.Limit > maxEventQueryLimit {
+			return query, errors.Join(ErrInvalidIssueQuery, err)
+		}
+	}
+
+	return query, nil
+}
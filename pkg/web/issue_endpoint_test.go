@@ -0,0 +1,205 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel/pkg/store"
+)
+
+func TestIssueQueryEndpoint(t *testing.T) {
+	repo := buildMockIssueRepository()
+	svr := buildTestServerWithIssueRepository(t, repo)
+
+	defer svr.Close()
+
+	res := doQueryRequest(t, svr.URL+"/api/v1/issues?status=unresolved&limit=10", "key")
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var issues []store.Issue
+	require.Nil(t, json.NewDecoder(res.Body).Decode(&issues))
+
+	require.Len(t, issues, 1)
+	assert.Equal(t, "abc", issues[0].Fingerprint)
+	assert.Equal(t, store.IssueQuery{ServiceId: "1", Status: store.IssueUnresolved, Limit: 10}, repo.query)
+
+	for _, query := range []string{"status=foo", "limit=foo", "limit=1000"} {
+		res := doQueryRequest(t, svr.URL+"/api/v1/issues?"+query, "key")
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, query)
+	}
+}
+
+func TestIssueGetEndpoint(t *testing.T) {
+	svr := buildTestServerWithIssueRepository(t, buildMockIssueRepository())
+
+	defer svr.Close()
+
+	res := doQueryRequest(t, svr.URL+"/api/v1/issues/abc", "key")
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var found store.Issue
+	require.Nil(t, json.NewDecoder(res.Body).Decode(&found))
+
+	assert.Equal(t, int64(3), found.Count)
+
+	res = doQueryRequest(t, svr.URL+"/api/v1/issues/xyz", "key")
+
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
+func TestIssueUpdateEndpoint(t *testing.T) {
+	repo := buildMockIssueRepository()
+	svr := buildTestServerWithIssueRepository(t, repo)
+
+	defer svr.Close()
+
+	res := doPatchRequest(t, svr.URL+"/api/v1/issues/abc", `{"status": "resolved", "in_next_release": true}`)
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	found := repo.issues["abc"]
+
+	assert.Equal(t, store.IssueResolved, found.Status)
+	assert.Equal(t, "1.0.0", found.ResolvedInRelease)
+	assert.NotNil(t, found.ResolvedAt)
+
+	res = doPatchRequest(t, svr.URL+"/api/v1/issues/abc", `{"status": "ignored", "ignore_occurrences": 10}`)
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	found = repo.issues["abc"]
+
+	assert.Equal(t, store.IssueIgnored, found.Status)
+	assert.Equal(t, int64(13), found.IgnoreUntilCount)
+	assert.Nil(t, found.ResolvedAt)
+
+	res = doPatchRequest(t, svr.URL+"/api/v1/issues/abc", `{"status": "unresolved"}`)
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, store.IssueUnresolved, repo.issues["abc"].Status)
+}
+
+func TestIssueUpdateEndpointInvalid(t *testing.T) {
+	repo := buildMockIssueRepository()
+	svr := buildTestServerWithIssueRepository(t, repo)
+
+	defer svr.Close()
+
+	for _, body := range []string{`{"status": "foo"}`, `{"status": "ignored", "ignore_occurrences": -1}`, `foo`} {
+		res := doPatchRequest(t, svr.URL+"/api/v1/issues/abc", body)
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, body)
+	}
+
+	assert.Equal(t, store.IssueUnresolved, repo.issues["abc"].Status)
+
+	res := doPatchRequest(t, svr.URL+"/api/v1/issues/xyz", `{"status": "resolved"}`)
+
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	assert.NotContains(t, repo.issues, "xyz")
+}
+
+func TestIssueEndpointWithoutRepository(t *testing.T) {
+	svr := buildTestServer(t)
+
+	defer svr.Close()
+
+	res := doQueryRequest(t, svr.URL+"/api/v1/issues", "key")
+
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
+func buildTestServerWithIssueRepository(t *testing.T, repo *mockIssueRepository) *httptest.Server {
+	c := buildTestServerContext(t)
+	c.IssueRepository = repo
+
+	return buildTestServerWithContext(t, c)
+}
+
+func doPatchRequest(t *testing.T, url string, body string) *http.Response {
+	req, err := http.NewRequest(http.MethodPatch, url, strings.NewReader(body))
+
+	require.Nil(t, err)
+
+	req.Header.Set(AuthKeyHeader, "key")
+
+	res, err := http.DefaultClient.Do(req)
+
+	require.Nil(t, err)
+
+	t.Cleanup(func() { res.Body.Close() })
+
+	return res
+}
+
+func buildMockIssueRepository() *mockIssueRepository {
+	firstSeen := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	return &mockIssueRepository{issues: map[string]store.Issue{
+		"abc": {
+			ServiceId:   "1",
+			Fingerprint: "abc",
+			Status:      store.IssueUnresolved,
+			FirstSeen:   firstSeen,
+			LastSeen:    firstSeen,
+			Count:       3,
+			LastRelease: "1.0.0",
+		},
+	}}
+}
+
+type mockIssueRepository struct {
+	issues map[string]store.Issue
+	query  store.IssueQuery
+}
+
+// Updates the issue of the fingerprint
+func (m *mockIssueRepository) UpdateIssue(ctx context.Context, serviceId string, fingerprint string, fn func(issue *store.Issue) error) (store.Issue, error) {
+	issue, ok := m.issues[fingerprint]
+
+	if !ok {
+		issue = store.Issue{ServiceId: serviceId, Fingerprint: fingerprint}
+	}
+
+	if err := fn(&issue); err != nil {
+		return store.Issue{}, err
+	}
+
+	m.issues[fingerprint] = issue
+
+	return issue, nil
+}
+
+// Returns the issue of the fingerprint
+func (m *mockIssueRepository) GetIssue(ctx context.Context, serviceId string, fingerprint string) (store.Issue, error) {
+	issue, ok := m.issues[fingerprint]
+
+	if !ok || issue.ServiceId != serviceId {
+		return store.Issue{}, store.ErrIssueNotFound
+	}
+
+	return issue, nil
+}
+
+// Returns every issue
+func (m *mockIssueRepository) ListIssues(ctx context.Context, query store.IssueQuery) ([]store.Issue, error) {
+	m.query = query
+
+	issues := []store.Issue{}
+
+	for _, issue := range m.issues {
+		issues = append(issues, issue)
+	}
+
+	return issues, nil
+}
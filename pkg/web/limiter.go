@@ -1,19 +1,46 @@
 package web
 
 import (
+	"fmt"
+	"math"
+	"net/http"
 	"time"
 
 	"github.com/didip/tollbooth/v7"
 	"github.com/didip/tollbooth/v7/limiter"
+	"github.com/williampsena/bugs-channel/pkg/ratelimit"
 )
 
+// The message sent when a rate limit is reached
+const rateLimitMessage = "😥 Wow, so many bugs. 🐜"
+
 func BuildRateLimitMiddleware(rateLimit int64) *limiter.Limiter {
 	rate := float64(rateLimit) / float64(time.Minute.Seconds())
 
 	lmt := tollbooth.NewLimiter(rate, nil)
 	lmt.SetTokenBucketExpirationTTL(time.Minute)
 	lmt.SetHeaderEntryExpirationTTL(time.Minute)
-	lmt.SetMessage("😥 Wow, so many bugs. 🐜")
+	lmt.SetMessage(rateLimitMessage)
+
+	if rate > 0 {
+		retryAfter := fmt.Sprintf("%v", int(math.Ceil(1/rate)))
+
+		lmt.SetOnLimitReached(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", retryAfter)
+		})
+	}
 
 	return lmt
 }
+
+// Writes the 429 response of a service refused by its rate limit
+func writeServiceRateLimited(w http.ResponseWriter, limitErr *ratelimit.LimitError) {
+	retryAfter := fmt.Sprintf("%v", int(limitErr.Result.RetryAfter.Seconds()))
+
+	w.Header().Set("X-RateLimit-Limit", fmt.Sprintf("%v", limitErr.Result.Limit))
+	w.Header().Set("X-RateLimit-Remaining", fmt.Sprintf("%v", limitErr.Result.Remaining))
+	w.Header().Set("X-RateLimit-Reset", retryAfter)
+	w.Header().Set("Retry-After", retryAfter)
+	w.WriteHeader(http.StatusTooManyRequests)
+	fmt.Fprint(w, rateLimitMessage)
+}
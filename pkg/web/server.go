@@ -14,6 +14,7 @@ import (
 	gorilla "github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
+	plugin "github.com/williampsena/bugs-channel-plugins/pkg/service"
 	"github.com/williampsena/bugs-channel/pkg/config"
 	"github.com/williampsena/bugs-channel/pkg/storage"
 )
@@ -29,7 +30,11 @@ type Server struct {
 // The web server context
 type ServerContext struct {
 	context.Context
-	Queue storage.Queue
+	Queue            storage.Queue
+	ServiceFetcher   plugin.ServiceFetcher
+	EventsDispatcher EventsDispatcher
+	EventRepository  EventRepository
+	IssueRepository  IssueRepository
 }
 
 // Creates and returns a new instance of Server
@@ -104,11 +109,32 @@ func maybeUseRatelimitHandler(r *mux.Router) {
 	r.Use(handler)
 }
 
-func buildRouter() (*mux.Router, error) {
+func buildRouter(c *ServerContext) (*mux.Router, error) {
 	r := mux.NewRouter()
 
 	r.PathPrefix("/health").HandlerFunc(HealthCheckEndpoint).Methods("GET")
 
+	debug := r.PathPrefix("/debug").Subrouter()
+	debug.HandleFunc("/vars", DebugVarsEndpoint).Methods("GET")
+	debug.Use(authMiddleware(c))
+
+	api := r.PathPrefix("/api/v1").Subrouter()
+	api.HandleFunc("/events", EventEndpoint(c)).Methods("POST")
+	api.HandleFunc("/events/batch", EventBatchEndpoint(c)).Methods("POST")
+
+	if c.EventRepository != nil {
+		api.HandleFunc("/events", EventQueryEndpoint(c)).Methods("GET")
+		api.HandleFunc("/events/{id}", EventGetEndpoint(c)).Methods("GET")
+	}
+
+	if c.IssueRepository != nil {
+		api.HandleFunc("/issues", IssueQueryEndpoint(c)).Methods("GET")
+		api.HandleFunc("/issues/{fingerprint}", IssueGetEndpoint(c)).Methods("GET")
+		api.HandleFunc("/issues/{fingerprint}", IssueUpdateEndpoint(c)).Methods("PATCH")
+	}
+
+	api.Use(authMiddleware(c))
+
 	r.PathPrefix("/").HandlerFunc(NoRouteEndpoint)
 
 	r.Use(mux.CORSMethodMiddleware(r))
@@ -120,7 +146,7 @@ func buildRouter() (*mux.Router, error) {
 
 // Setup the bugs channel web server
 func SetupServer(context *ServerContext) (*Server, error) {
-	r, err := buildRouter()
+	r, err := buildRouter(context)
 
 	if err != nil {
 		return nil, err
@@ -1,13 +1,22 @@
 package web
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	bcevent "github.com/williampsena/bugs-channel/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/ratelimit"
+	"github.com/williampsena/bugs-channel/pkg/service"
+	"github.com/williampsena/bugs-channel/pkg/settings"
+	"github.com/williampsena/bugs-channel/pkg/storage"
+	"github.com/williampsena/bugs-channel/pkg/test"
 )
 
 func TestServer(t *testing.T) {
@@ -26,6 +35,34 @@ func TestServer(t *testing.T) {
 	assert.Equal(t, 200, res.StatusCode)
 }
 
+func TestServerDebugVars(t *testing.T) {
+	svr := buildTestServer(t)
+
+	defer svr.Close()
+
+	res, err := http.Get(fmt.Sprintf("%v/debug/vars", svr.URL))
+
+	require.Nil(t, err)
+	assert.Equal(t, 401, res.StatusCode)
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("%v/debug/vars", svr.URL), nil)
+
+	require.Nil(t, err)
+
+	req.Header.Set(AuthKeyHeader, "key")
+
+	res, err = http.DefaultClient.Do(req)
+
+	require.Nil(t, err)
+	assert.Equal(t, 200, res.StatusCode)
+
+	var vars map[string]any
+
+	require.Nil(t, json.NewDecoder(res.Body).Decode(&vars))
+	assert.NotContains(t, vars, "cmdline")
+	assert.NotContains(t, vars, "memstats")
+}
+
 func TestServerRateLimit(t *testing.T) {
 	t.Setenv("WEB_RATE_LIMIT", "1")
 
@@ -49,13 +86,56 @@ func TestServerRateLimit(t *testing.T) {
 	res = doRequest(-1)
 
 	assert.Equal(t, 429, res.StatusCode)
+	assert.Equal(t, "60", res.Header.Get("Retry-After"))
+}
+
+func TestServerServiceRateLimit(t *testing.T) {
+	c := buildTestServerContext(t)
+	c.EventsDispatcher = bcevent.NewRateLimitedDispatcher(c.EventsDispatcher, ratelimit.NewServiceLimiter(buildTestServices(t)))
+	svr := buildTestServerWithContext(t, c)
+
+	defer svr.Close()
+
+	// every event of a batch counts against the limit
+	res := doEventRequest(t, svr.URL+"/api/v1/events/batch", "key", `[{"id":"foo"},{"id":"bar"}]`)
+
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+	assert.Equal(t, "1", res.Header.Get("Retry-After"))
+	assert.Equal(t, "1", res.Header.Get("X-RateLimit-Limit"))
+	assert.Equal(t, "0", res.Header.Get("X-RateLimit-Remaining"))
 }
 
 func buildTestServer(t *testing.T) *httptest.Server {
-	router, err := buildRouter()
+	return buildTestServerWithContext(t, buildTestServerContext(t))
+}
+
+func buildTestServerWithContext(t *testing.T, c *ServerContext) *httptest.Server {
+	router, err := buildRouter(c)
 
 	require.Nil(t, err)
 
 	return httptest.NewServer(router)
+}
+
+func buildTestServerContext(t *testing.T) *ServerContext {
+	return buildTestServerContextWithQueue(t, test.BuildMemoryQueue(t))
+}
+
+func buildTestServerContextWithQueue(t *testing.T, queue storage.Queue) *ServerContext {
+	settings := bcevent.DefaultDispatcherSettings()
+	settings.Retry = bcevent.RetryPolicy{Attempts: 1, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	return &ServerContext{
+		Context:          context.Background(),
+		ServiceFetcher:   service.NewYAMLServiceFetcher(buildTestServices(t)),
+		EventsDispatcher: bcevent.NewDispatcherWithSettings(queue, settings),
+	}
+}
+
+func buildTestServices(t *testing.T) []settings.ConfigFileService {
+	configFile, err := settings.BuildConfigFile("../../fixtures/settings/config.yml")
+
+	require.Nil(t, err)
 
+	return configFile.Services
 }
@@ -0,0 +1,203 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	bcevent "github.com/williampsena/bugs-channel/pkg/event"
+)
+
+// Represents an error when a webhook answers with an unexpected status
+var ErrDelivery = errors.New("the webhook delivery failed")
+
+const (
+	// The unix time the payload was signed at
+	TimestampHeader = "X-BugsChannel-Timestamp"
+	// The HMAC-SHA256 of the timestamp, a dot and the body, e.g. sha256=<hex>
+	SignatureHeader = "X-BugsChannel-Signature"
+	// The payload type, event or issue.regressed
+	TypeHeader = "X-BugsChannel-Type"
+	// The delivery id, retries and redeliveries of a payload share it
+	DeliveryHeader = "X-BugsChannel-Delivery"
+)
+
+// Represents a webhook destination settings
+type Settings struct {
+	// The url the payloads are posted to
+	Url string
+	// The HMAC-SHA256 signing secret, empty sends unsigned payloads
+	Secret string
+	// The JSON text/template of the body, empty sends the payload as JSON
+	Template string
+	// Extra request headers
+	Headers map[string]string
+	// The payload types sent, event by default
+	Types []string
+	// How many payloads are posted at the same time, one by default
+	Concurrency int
+}
+
+// Posts payloads to a webhook, limited to a number of concurrent requests
+type Destination struct {
+	settings  Settings
+	template  *template.Template
+	semaphore chan struct{}
+	client    *http.Client
+	retry     bcevent.RetryPolicy
+}
+
+// Indicate that the destination receives the payload type
+func (d *Destination) Accepts(payloadType string) bool {
+	return slices.Contains(d.settings.Types, payloadType)
+}
+
+// Posts the payload, retrying network errors, 429 and 5xx with backoff
+func (d *Destination) Send(ctx context.Context, p Payload) error {
+	body, err := render(d.template, p)
+
+	if err != nil {
+		return err
+	}
+
+	select {
+	case d.semaphore <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	defer func() { <-d.semaphore }()
+
+	var refused error
+
+	_, err = d.retry.Do(ctx, func() error {
+		err := d.post(ctx, p, body)
+
+		var deliveryErr *DeliveryError
+
+		if errors.As(err, &deliveryErr) && !deliveryErr.Retryable() {
+			refused = err
+			return nil
+		}
+
+		return err
+	})
+
+	if err == nil {
+		err = refused
+	}
+
+	return err
+}
+
+func (d *Destination) post(ctx context.Context, p Payload, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.settings.Url, bytes.NewReader(body))
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	for key, value := range d.settings.Headers {
+		req.Header.Set(key, value)
+	}
+
+	req.Header.Set(TypeHeader, p.Type)
+	req.Header.Set(DeliveryHeader, p.DeliveryId())
+
+	if d.settings.Secret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, Sign(d.settings.Secret, timestamp, body))
+	}
+
+	res, err := d.client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		reason, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return &DeliveryError{Url: d.settings.Url, Status: res.StatusCode, Reason: strings.TrimSpace(string(reason))}
+	}
+
+	return nil
+}
+
+// Returns the signature header value of the body, sha256=<hex of HMAC-SHA256(secret, timestamp.body)>
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Represents a delivery answered with an unexpected status
+type DeliveryError struct {
+	Url    string
+	Status int
+	Reason string
+}
+
+// Returns the url, status and reason
+func (e *DeliveryError) Error() string {
+	return fmt.Sprintf("%v: %v: status %v: %v", ErrDelivery, e.Url, e.Status, e.Reason)
+}
+
+// Returns ErrDelivery
+func (e *DeliveryError) Unwrap() error {
+	return ErrDelivery
+}
+
+// Indicate that the delivery may succeed on retry
+func (e *DeliveryError) Retryable() bool {
+	return e.Status == http.StatusTooManyRequests || e.Status >= 500
+}
+
+// Build a new destination, parsing its template
+func NewDestination(settings Settings, client *http.Client, retry bcevent.RetryPolicy) (*Destination, error) {
+	if len(settings.Types) == 0 {
+		settings.Types = []string{EventType}
+	}
+
+	if settings.Concurrency <= 0 {
+		settings.Concurrency = 1
+	}
+
+	d := &Destination{
+		settings:  settings,
+		semaphore: make(chan struct{}, settings.Concurrency),
+		client:    client,
+		retry:     retry,
+	}
+
+	if settings.Template != "" {
+		tmpl, err := parseTemplate(settings.Template)
+
+		if err != nil {
+			return nil, err
+		}
+
+		d.template = tmpl
+	}
+
+	return d, nil
+}
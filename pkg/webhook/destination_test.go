@@ -0,0 +1,145 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	bcevent "github.com/williampsena/bugs-channel/pkg/event"
+)
+
+var retry = bcevent.RetryPolicy{Attempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+func TestDestinationSend(t *testing.T) {
+	hook := newMockWebhook(t)
+
+	d, err := NewDestination(Settings{Url: hook.URL, Secret: "secret", Headers: map[string]string{"Authorization": "Bearer foo"}}, http.DefaultClient, retry)
+
+	require.Nil(t, err)
+	require.Nil(t, d.Send(context.Background(), NewEventPayload(event.Event{ID: "foo", ServiceId: "1"})))
+
+	require.Len(t, hook.requests, 1)
+
+	req := hook.requests[0]
+
+	assert.Equal(t, "Bearer foo", req.header.Get("Authorization"))
+	assert.Equal(t, "event", req.header.Get(TypeHeader))
+	assert.Equal(t, "event:foo", req.header.Get(DeliveryHeader))
+	assert.Equal(t, Sign("secret", req.header.Get(TimestampHeader), req.body), req.header.Get(SignatureHeader))
+}
+
+func TestDestinationRetry(t *testing.T) {
+	hook := newMockWebhook(t)
+	hook.statuses = []int{http.StatusServiceUnavailable, http.StatusTooManyRequests}
+
+	d, err := NewDestination(Settings{Url: hook.URL}, http.DefaultClient, retry)
+
+	require.Nil(t, err)
+	require.Nil(t, d.Send(context.Background(), NewEventPayload(event.Event{ID: "foo"})))
+
+	assert.Len(t, hook.requests, 3)
+	assert.Empty(t, hook.requests[0].header.Get(SignatureHeader))
+}
+
+func TestDestinationRefused(t *testing.T) {
+	hook := newMockWebhook(t)
+	hook.statuses = []int{http.StatusBadRequest}
+
+	d, err := NewDestination(Settings{Url: hook.URL}, http.DefaultClient, retry)
+
+	require.Nil(t, err)
+
+	err = d.Send(context.Background(), NewEventPayload(event.Event{ID: "foo"}))
+
+	require.ErrorIs(t, err, ErrDelivery)
+	assert.Len(t, hook.requests, 1)
+}
+
+func TestDestinationConcurrency(t *testing.T) {
+	hook := newMockWebhook(t)
+	hook.delay = 20 * time.Millisecond
+
+	d, err := NewDestination(Settings{Url: hook.URL, Concurrency: 2}, http.DefaultClient, retry)
+
+	require.Nil(t, err)
+
+	var wg sync.WaitGroup
+
+	for range 6 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, d.Send(context.Background(), NewEventPayload(event.Event{ID: "foo"})))
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Len(t, hook.requests, 6)
+	assert.Equal(t, 2, hook.peak)
+}
+
+func TestSign(t *testing.T) {
+	assert.Equal(t,
+		"sha256=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163",
+		Sign("secret", "1700000000", []byte(`{}`)),
+	)
+}
+
+type mockRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// Records the requests, answering with the queued statuses and then 200
+type mockWebhook struct {
+	*httptest.Server
+	mu       sync.Mutex
+	statuses []int
+	requests []mockRequest
+	delay    time.Duration
+	inFlight int
+	peak     int
+}
+
+func newMockWebhook(t *testing.T) *mockWebhook {
+	hook := &mockWebhook{}
+	hook.Server = httptest.NewServer(http.HandlerFunc(hook.handle))
+
+	t.Cleanup(hook.Close)
+
+	return hook
+}
+
+func (h *mockWebhook) handle(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	h.mu.Lock()
+	h.inFlight++
+	h.peak = max(h.peak, h.inFlight)
+	h.requests = append(h.requests, mockRequest{req.Header, body})
+
+	status := http.StatusOK
+
+	if len(h.statuses) > 0 {
+		status, h.statuses = h.statuses[0], h.statuses[1:]
+	}
+
+	h.mu.Unlock()
+
+	time.Sleep(h.delay)
+
+	h.mu.Lock()
+	h.inFlight--
+	h.mu.Unlock()
+
+	w.WriteHeader(status)
+}
@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	bcevent "github.com/williampsena/bugs-channel/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/settings"
+)
+
+// Forwards payloads to the webhook destinations of their service
+type Forwarder struct {
+	destinations map[string][]*Destination
+}
+
+// Sends the payload to the service destinations accepting its type at the same time, joining the errors
+func (f *Forwarder) Forward(ctx context.Context, p Payload) error {
+	var wg sync.WaitGroup
+
+	destinations := f.destinations[p.ServiceId]
+	errs := make([]error, len(destinations))
+
+	for i, d := range destinations {
+		if !d.Accepts(p.Type) {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			errs[i] = d.Send(ctx, p)
+		}()
+	}
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// Indicate that a destination receives the payload type
+func (f *Forwarder) Accepts(payloadType string) bool {
+	for _, destinations := range f.destinations {
+		for _, d := range destinations {
+			if d.Accepts(payloadType) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Build a new forwarder of the service destinations
+func NewForwarder(destinations map[string][]*Destination) *Forwarder {
+	return &Forwarder{destinations}
+}
+
+// Build the forwarder of the service webhooks in the configuration file
+func BuildForwarder(configFile *settings.ConfigFile, timeout time.Duration, retry bcevent.RetryPolicy) (*Forwarder, error) {
+	client := &http.Client{Timeout: timeout}
+	destinations := make(map[string][]*Destination)
+
+	for _, service := range configFile.Services {
+		for _, webhook := range service.Settings.Webhooks {
+			d, err := NewDestination(Settings{
+				Url:         webhook.Url,
+				Secret:      webhook.Secret,
+				Template:    webhook.Template,
+				Headers:     webhook.Headers,
+				Types:       webhook.Types,
+				Concurrency: webhook.Concurrency,
+			}, client, retry)
+
+			if err != nil {
+				return nil, err
+			}
+
+			destinations[service.Id] = append(destinations[service.Id], d)
+		}
+	}
+
+	return NewForwarder(destinations), nil
+}
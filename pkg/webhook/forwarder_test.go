@@ -0,0 +1,72 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/issue"
+	"github.com/williampsena/bugs-channel/pkg/settings"
+	"github.com/williampsena/bugs-channel/pkg/store"
+)
+
+func TestForwarder(t *testing.T) {
+	events := newMockWebhook(t)
+	issues := newMockWebhook(t)
+	failing := newMockWebhook(t)
+	failing.statuses = []int{http.StatusBadRequest}
+
+	configFile := &settings.ConfigFile{
+		Services: []settings.ConfigFileService{
+			{Id: "1", Settings: settings.ConfigFileServiceSettings{Webhooks: []settings.ConfigFileWebhook{
+				{Url: events.URL},
+				{Url: issues.URL, Types: []string{issue.RegressedMessageType}},
+			}}},
+			{Id: "2", Settings: settings.ConfigFileServiceSettings{Webhooks: []settings.ConfigFileWebhook{
+				{Url: failing.URL, Types: []string{EventType, issue.RegressedMessageType}},
+			}}},
+		},
+	}
+
+	forwarder, err := BuildForwarder(configFile, time.Second, retry)
+
+	require.Nil(t, err)
+	assert.True(t, forwarder.Accepts(issue.RegressedMessageType))
+
+	ctx := context.Background()
+
+	require.Nil(t, forwarder.Forward(ctx, NewEventPayload(event.Event{ID: "foo", ServiceId: "1"})))
+	require.Nil(t, forwarder.Forward(ctx, NewRegressedPayload(issue.RegressedMessage{
+		Type:    issue.RegressedMessageType,
+		EventId: "bar",
+		Issue:   store.Issue{ServiceId: "1"},
+	})))
+
+	// a service without webhooks
+	require.Nil(t, forwarder.Forward(ctx, NewEventPayload(event.Event{ID: "baz", ServiceId: "3"})))
+
+	require.ErrorIs(t, forwarder.Forward(ctx, NewEventPayload(event.Event{ID: "foo", ServiceId: "2"})), ErrDelivery)
+
+	require.Len(t, events.requests, 1)
+	assert.Equal(t, "event:foo", events.requests[0].header.Get(DeliveryHeader))
+	require.Len(t, issues.requests, 1)
+	assert.Equal(t, "issue.regressed:bar", issues.requests[0].header.Get(DeliveryHeader))
+}
+
+func TestBuildForwarderInvalidTemplate(t *testing.T) {
+	configFile := &settings.ConfigFile{
+		Services: []settings.ConfigFileService{
+			{Id: "1", Settings: settings.ConfigFileServiceSettings{Webhooks: []settings.ConfigFileWebhook{
+				{Url: "http://localhost", Template: "{{ .Event"},
+			}}},
+		},
+	}
+
+	_, err := BuildForwarder(configFile, time.Second, retry)
+
+	require.NotNil(t, err)
+}
@@ -0,0 +1,79 @@
+// This package forwards events and issue regressions to the webhooks of their service
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"text/template"
+
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/issue"
+	"github.com/williampsena/bugs-channel/pkg/store"
+)
+
+// Represents an error when the template does not render valid JSON
+var ErrInvalidPayload = errors.New("the webhook payload is not valid JSON")
+
+// The payload type of an event
+const EventType = "event"
+
+// Represents the data sent to a webhook and given to its template
+type Payload struct {
+	// The event or issue.regressed type
+	Type string `json:"type"`
+	// The service of the event or issue
+	ServiceId string `json:"service_id"`
+	// The event id, the regression event of an issue
+	EventId string `json:"event_id"`
+	// The event of an event payload
+	Event *event.Event `json:"event,omitempty"`
+	// The issue of an issue payload
+	Issue *store.Issue `json:"issue,omitempty"`
+}
+
+// Build the payload of an event
+func NewEventPayload(e event.Event) Payload {
+	return Payload{Type: EventType, ServiceId: e.ServiceId, EventId: e.ID, Event: &e}
+}
+
+// Build the payload of an issue regression
+func NewRegressedPayload(m issue.RegressedMessage) Payload {
+	return Payload{Type: m.Type, ServiceId: m.Issue.ServiceId, EventId: m.EventId, Issue: &m.Issue}
+}
+
+// Returns the delivery id, the same payload always has the same id
+func (p Payload) DeliveryId() string {
+	return p.Type + ":" + p.EventId
+}
+
+var templateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// Parses a JSON template, e.g. {"text": {{ json .Event.Title }}}
+func parseTemplate(text string) (*template.Template, error) {
+	return template.New("webhook").Funcs(templateFuncs).Option("missingkey=zero").Parse(text)
+}
+
+// Renders the payload with the template, or as JSON without one
+func render(tmpl *template.Template, p Payload) ([]byte, error) {
+	if tmpl == nil {
+		return json.Marshal(p)
+	}
+
+	var buf bytes.Buffer
+
+	if err := tmpl.Execute(&buf, p); err != nil {
+		return nil, err
+	}
+
+	if !json.Valid(buf.Bytes()) {
+		return nil, ErrInvalidPayload
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,53 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/issue"
+	"github.com/williampsena/bugs-channel/pkg/store"
+)
+
+func TestRender(t *testing.T) {
+	p := NewEventPayload(event.Event{ID: "foo", ServiceId: "1", Title: `Value"Error`})
+
+	body, err := render(nil, p)
+
+	require.Nil(t, err)
+	assert.Contains(t, string(body), `"type":"event","service_id":"1","event_id":"foo","event":{`)
+
+	tmpl, err := parseTemplate(`{"text": {{ json .Event.Title }}, "service": "{{ .ServiceId }}"}`)
+
+	require.Nil(t, err)
+
+	body, err = render(tmpl, p)
+
+	require.Nil(t, err)
+	assert.JSONEq(t, `{"text": "Value\"Error", "service": "1"}`, string(body))
+}
+
+func TestRenderInvalid(t *testing.T) {
+	tmpl, err := parseTemplate(`{"text": {{ .Event.Title }}}`)
+
+	require.Nil(t, err)
+
+	_, err = render(tmpl, NewEventPayload(event.Event{Title: "ValueError"}))
+
+	require.ErrorIs(t, err, ErrInvalidPayload)
+}
+
+func TestNewRegressedPayload(t *testing.T) {
+	p := NewRegressedPayload(issue.RegressedMessage{
+		Type:    issue.RegressedMessageType,
+		EventId: "foo",
+		Issue:   store.Issue{ServiceId: "1", Fingerprint: "abc"},
+	})
+
+	assert.Equal(t, "issue.regressed", p.Type)
+	assert.Equal(t, "1", p.ServiceId)
+	assert.Equal(t, "issue.regressed:foo", p.DeliveryId())
+	assert.Equal(t, "abc", p.Issue.Fingerprint)
+	assert.Nil(t, p.Event)
+}
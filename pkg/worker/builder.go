@@ -0,0 +1,36 @@
+package worker
+
+import (
+	"github.com/williampsena/bugs-channel/pkg/config"
+	"github.com/williampsena/bugs-channel/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/routing"
+	"github.com/williampsena/bugs-channel/pkg/settings"
+	"github.com/williampsena/bugs-channel/pkg/sink"
+	"github.com/williampsena/bugs-channel/pkg/storage"
+)
+
+// Build a worker consuming the queue from the environment settings and the service settings,
+// the worker topics default to the topics the routing sends events to
+func BuildWorker(queue storage.Queue, configFile *settings.ConfigFile) (*Worker, error) {
+	sinks, err := sink.BuildSinks(config.WorkerSinks(), queue, configFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	topics := config.WorkerTopics()
+
+	if len(topics) == 0 {
+		topics = routing.NewRouter(configFile).Topics()
+	}
+
+	return NewWorker(queue, sinks, Settings{
+		Topics:      topics,
+		Concurrency: config.WorkerConcurrency(),
+		Retry: event.RetryPolicy{
+			Attempts:       config.DispatchRetryAttempts(),
+			InitialBackoff: config.DispatchRetryBackoff(),
+			MaxBackoff:     config.DispatchRetryMaxBackoff(),
+		},
+	}), nil
+}
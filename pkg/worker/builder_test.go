@@ -0,0 +1,46 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel/pkg/settings"
+	"github.com/williampsena/bugs-channel/pkg/sink"
+)
+
+func TestBuildWorker(t *testing.T) {
+	t.Setenv("WORKER_SINKS", "stdout")
+	t.Setenv("WORKER_TOPIC", "events,events.>")
+	t.Setenv("WORKER_CONCURRENCY", "2")
+
+	w, err := BuildWorker(&mockQueue{}, &settings.ConfigFile{})
+
+	require.Nil(t, err)
+	assert.Equal(t, []string{"events", "events.>"}, w.settings.Topics)
+	assert.Equal(t, 2, w.settings.Concurrency)
+	require.Len(t, w.sinks, 1)
+	assert.Equal(t, "stdout", w.sinks[0].Name())
+}
+
+func TestBuildWorkerRoutedTopics(t *testing.T) {
+	t.Setenv("WORKER_SINKS", "stdout")
+	t.Setenv("WORKER_TOPIC", "")
+
+	configFile, err := settings.BuildConfigFile("../../fixtures/settings/config.yml")
+
+	require.Nil(t, err)
+
+	w, err := BuildWorker(&mockQueue{}, configFile)
+
+	require.Nil(t, err)
+	assert.Equal(t, []string{"events", "events.foo.*.*"}, w.settings.Topics)
+}
+
+func TestBuildWorkerUnsupportedSink(t *testing.T) {
+	t.Setenv("WORKER_SINKS", "foo")
+
+	_, err := BuildWorker(&mockQueue{}, &settings.ConfigFile{})
+
+	assert.ErrorIs(t, err, sink.ErrUnsupportedSink)
+}
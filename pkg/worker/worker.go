@@ -0,0 +1,150 @@
+// This package consumes the events topic and writes the events to the sinks
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	bcevent "github.com/williampsena/bugs-channel/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/sink"
+	"github.com/williampsena/bugs-channel/pkg/storage"
+)
+
+// Represents the worker topics, concurrency and sink retry policy
+type Settings struct {
+	// The topics events are consumed from
+	Topics []string
+	// How many events of a topic are written to the sinks at the same time
+	Concurrency int
+	// The retry policy of each sink write
+	Retry bcevent.RetryPolicy
+}
+
+// The events consumer
+type Worker struct {
+	queue    storage.Queue
+	sinks    []sink.Sink
+	settings Settings
+	metrics  *expvar.Map
+}
+
+// Consumes the topics until the context is done or a subscription fails, then closes the sinks.
+// Up to the concurrency events per topic are in flight, each one acked once every sink wrote it;
+// a sink failure is returned so the queue redelivers the event.
+func (w *Worker) Run(ctx context.Context) error {
+	log.Infof("👷 Worker consuming %v with %v sinks", strings.Join(w.settings.Topics, ", "), len(w.sinks))
+
+	ctx, cancel := context.WithCancel(storage.WithConcurrency(ctx, w.concurrency()))
+	defer cancel()
+
+	errs := make([]error, len(w.settings.Topics))
+
+	var wg sync.WaitGroup
+
+	for i, topic := range w.settings.Topics {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			errs[i] = w.queue.Subscribe(ctx, topic, func(header map[string][]string, body string) error {
+				return w.handle(ctx, body)
+			})
+
+			if errs[i] != nil {
+				log.Errorf("⛔ Worker subscription to %v failed: %v", topic, errs[i])
+				cancel()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	log.Info("👷 Worker stopped, closing sinks")
+
+	if closeErr := sink.CloseSinks(w.sinks); closeErr != nil {
+		log.Errorf("⛔ Something went wrong when closing the sinks: %v", closeErr)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Decodes the message and writes it to the sinks, a malformed message is dropped
+func (w *Worker) handle(ctx context.Context, body string) error {
+	var e event.Event
+
+	if err := json.Unmarshal([]byte(body), &e); err != nil {
+		log.Warnf("⚠️ Dropping a malformed event: %v", err)
+		w.metrics.Add("malformed", 1)
+		return nil
+	}
+
+	w.metrics.Add("consumed", 1)
+
+	// the event in flight is finished on shutdown, so it is not redelivered half written
+	return w.process(context.WithoutCancel(ctx), e)
+}
+
+// Writes the event to every sink at the same time, returning the errors of the sinks that failed after the retries.
+// The sinks that succeeded write the event again on redelivery, so they must be idempotent.
+func (w *Worker) process(ctx context.Context, e event.Event) error {
+	errs := make([]error, len(w.sinks))
+
+	var wg sync.WaitGroup
+
+	for i, s := range w.sinks {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			attempts, err := w.settings.Retry.Do(ctx, func() error {
+				return s.Write(ctx, e)
+			})
+
+			if err != nil {
+				log.Errorf("⛔ Sink %v failed to write event %v after %v attempts: %v", s.Name(), e.ID, attempts, err)
+				w.metrics.Add(s.Name()+".errors", 1)
+				errs[i] = fmt.Errorf("sink %v: %w", s.Name(), err)
+				return
+			}
+
+			w.metrics.Add(s.Name()+".written", 1)
+		}()
+	}
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+func (w *Worker) concurrency() int {
+	return max(w.settings.Concurrency, 1)
+}
+
+// Returns the worker counters
+func (w *Worker) Metrics() *expvar.Map {
+	return w.metrics
+}
+
+// Publish the worker counters to expvar
+func (w *Worker) PublishMetrics() {
+	expvar.Publish("worker", w.metrics)
+}
+
+// Build a new worker
+func NewWorker(queue storage.Queue, sinks []sink.Sink, settings Settings) *Worker {
+	return &Worker{
+		queue:    queue,
+		sinks:    sinks,
+		settings: settings,
+		metrics:  new(expvar.Map).Init(),
+	}
+}
@@ -0,0 +1,230 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/williampsena/bugs-channel-plugins/pkg/event"
+	bcevent "github.com/williampsena/bugs-channel/pkg/event"
+	"github.com/williampsena/bugs-channel/pkg/sink"
+	"github.com/williampsena/bugs-channel/pkg/storage"
+)
+
+func TestWorkerRun(t *testing.T) {
+	queue := &mockQueue{messages: []string{`{"id": "foo"}`, `not json`, `{"id": "bar"}`}}
+	good := &mockSink{name: "good"}
+	bad := &mockSink{name: "bad", err: errors.New("sink is down")}
+
+	w := NewWorker(queue, []sink.Sink{bad, good}, Settings{
+		Topics:      []string{"events"},
+		Concurrency: 2,
+		Retry:       bcevent.RetryPolicy{Attempts: 2},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	queue.onDelivered = cancel
+
+	require.Nil(t, w.Run(ctx))
+
+	assert.Equal(t, []string{"events"}, queue.topics)
+	assert.Equal(t, []string{"foo", "bar"}, good.ids())
+	assert.True(t, good.closed)
+	assert.True(t, bad.closed)
+	assert.Equal(t, 4, bad.calls)
+
+	metrics := w.Metrics()
+
+	assert.Equal(t, "2", metrics.Get("consumed").String())
+	assert.Equal(t, "1", metrics.Get("malformed").String())
+	assert.Equal(t, "2", metrics.Get("good.written").String())
+	assert.Equal(t, "2", metrics.Get("bad.errors").String())
+
+	// the failed events are returned to the queue, the malformed one is acked
+	require.Len(t, queue.errs, 3)
+	assert.ErrorContains(t, queue.errs[0], "sink bad: sink is down")
+	assert.Nil(t, queue.errs[1])
+	assert.ErrorContains(t, queue.errs[2], "sink bad: sink is down")
+}
+
+func TestWorkerAcksAfterSinks(t *testing.T) {
+	queue := &mockQueue{messages: []string{`{"id": "foo"}`}}
+	s := &mockSink{name: "good"}
+
+	w := NewWorker(queue, []sink.Sink{s}, Settings{Topics: []string{"events"}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	queue.onDelivered = func() {
+		// the handler returned, so the sink already has the event
+		assert.Equal(t, []string{"foo"}, s.ids())
+		cancel()
+	}
+
+	require.Nil(t, w.Run(ctx))
+	assert.Equal(t, []error{nil}, queue.errs)
+}
+
+func TestWorkerEventsInFlight(t *testing.T) {
+	queue, err := storage.NewMemoryQueue(10, storage.OverflowBlock)
+
+	require.Nil(t, err)
+
+	s := &blockingSink{started: make(chan string, 10), release: make(chan struct{})}
+
+	w := NewWorker(queue, []sink.Sink{s}, Settings{
+		Topics:      []string{"events", "events.foo"},
+		Concurrency: 2,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() { done <- w.Run(ctx) }()
+
+	memory := queue.(*storage.MemoryQueue)
+
+	require.Eventually(t, func() bool {
+		return memory.SubscriberCount("events") == 1 && memory.SubscriberCount("events.foo") == 1
+	}, time.Second, 5*time.Millisecond)
+
+	for _, id := range []string{"foo", "bar", "baz"} {
+		require.Nil(t, queue.Publish(ctx, "events", `{"id": "`+id+`"}`))
+	}
+
+	require.Nil(t, queue.Publish(ctx, "events.foo", `{"id": "qux"}`))
+
+	// two events of each topic are written at the same time, the third one waits for a slot
+	started := []string{<-s.started, <-s.started, <-s.started}
+
+	assert.ElementsMatch(t, []string{"foo", "bar", "qux"}, started)
+
+	select {
+	case id := <-s.started:
+		assert.Fail(t, "the event was written before a slot was free", id)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(s.release)
+
+	assert.Equal(t, "baz", <-s.started)
+
+	require.Eventually(t, func() bool {
+		return w.Metrics().Get("blocking.written").String() == "4"
+	}, time.Second, 5*time.Millisecond)
+
+	cancel()
+
+	require.Nil(t, <-done)
+	assert.True(t, s.closed.Load())
+}
+
+type mockQueue struct {
+	mu          sync.Mutex
+	topics      []string
+	messages    []string
+	errs        []error
+	onDelivered func()
+}
+
+// Publish a message
+func (m *mockQueue) Publish(ctx context.Context, topic string, message string) error {
+	return nil
+}
+
+// Delivers the messages and waits for the context
+func (m *mockQueue) Subscribe(ctx context.Context, topic string, handler storage.SubscribeHandler) error {
+	m.mu.Lock()
+	m.topics = append(m.topics, topic)
+	m.mu.Unlock()
+
+	// a handler error nacks the message like the brokers do
+	for _, message := range m.messages {
+		m.errs = append(m.errs, handler(nil, message))
+	}
+
+	m.onDelivered()
+
+	<-ctx.Done()
+
+	return nil
+}
+
+type mockSink struct {
+	name   string
+	err    error
+	mu     sync.Mutex
+	events []event.Event
+	calls  int
+	closed bool
+}
+
+// Returns the sink name
+func (m *mockSink) Name() string {
+	return m.name
+}
+
+// Write a event
+func (m *mockSink) Write(ctx context.Context, e event.Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls++
+
+	if m.err != nil {
+		return m.err
+	}
+
+	m.events = append(m.events, e)
+
+	return nil
+}
+
+// Close the sink
+func (m *mockSink) Close() error {
+	m.closed = true
+	return nil
+}
+
+func (m *mockSink) ids() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ids := make([]string, 0, len(m.events))
+
+	for _, e := range m.events {
+		ids = append(ids, e.ID)
+	}
+
+	return ids
+}
+
+type blockingSink struct {
+	started chan string
+	release chan struct{}
+	closed  atomic.Bool
+}
+
+// Returns the sink name
+func (b *blockingSink) Name() string {
+	return "blocking"
+}
+
+// Write a event once the sink is released
+func (b *blockingSink) Write(ctx context.Context, e event.Event) error {
+	b.started <- e.ID
+	<-b.release
+
+	return nil
+}
+
+// Close the sink
+func (b *blockingSink) Close() error {
+	b.closed.Store(true)
+	return nil
+}